@@ -27,8 +27,42 @@ type APISchemeConditionType string
 const (
 	ConditionError APISchemeConditionType = "Error"
 	ConditionReady APISchemeConditionType = "Ready"
+	// ConditionDegraded is true when the last reconcile failed
+	ConditionDegraded APISchemeConditionType = "Degraded"
+	// ConditionLoadBalancerReady is true when the cloud load balancer for the management API exists
+	ConditionLoadBalancerReady APISchemeConditionType = "LoadBalancerReady"
+	// ConditionDNSReady is true when the management API DNS record points to the load balancer
+	ConditionDNSReady APISchemeConditionType = "DNSReady"
 )
 
+const (
+	// ReasonCloudClientError is used when the cloud client couldn't be created or the platform has none
+	ReasonCloudClientError = "CloudClientError"
+	// ReasonLoadBalancerNotReady is used while the cloud provider creates the load balancer
+	ReasonLoadBalancerNotReady = "LoadBalancerNotReady"
+	// ReasonLoadBalancerDeleted is used when a previously ready load balancer has disappeared
+	ReasonLoadBalancerDeleted = "LoadBalancerDeleted"
+	// ReasonForwardingRuleNotFound is used when the GCP forwarding rule of the load balancer is missing
+	ReasonForwardingRuleNotFound = "ForwardingRuleNotFound"
+	// ReasonDNSUpdateFailed is used when the DNS record couldn't be created or updated
+	ReasonDNSUpdateFailed = "DNSUpdateFailed"
+	// ReasonDNSDeleteFailed is used when the DNS record couldn't be removed
+	ReasonDNSDeleteFailed = "DNSDeleteFailed"
+	// ReasonCloudAPIRetrying is used while a throttled or transient cloud API failure is retried
+	ReasonCloudAPIRetrying = "CloudAPIRetrying"
+	// ReasonDNSNameConflict is used when the DNS name is reserved or held by another APIScheme or Service
+	ReasonDNSNameConflict = "DNSNameConflict"
+	// ReasonScopeChanging is used while the load balancer is recreated in another scope
+	ReasonScopeChanging = "ScopeChanging"
+	// ReasonLoadBalancerTypeChanging is used while the load balancer is recreated with another type
+	ReasonLoadBalancerTypeChanging = "LoadBalancerTypeChanging"
+	// ReasonIPFamilyPolicyChanging is used while the load balancer is recreated with other IP families
+	ReasonIPFamilyPolicyChanging = "IPFamilyPolicyChanging"
+)
+
+// APISchemeHistoryLimit is the number of condition transitions kept in the APIScheme status history
+const APISchemeHistoryLimit = 20
+
 // APISchemeSpec defines the desired state of APIScheme
 type APISchemeSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
@@ -41,18 +75,49 @@ type APISchemeSpec struct {
 type ManagementAPIServerIngress struct {
 	// Enabled to create the Management API endpoint or not.
 	Enabled bool `json:"enabled"`
-	// DNSName is the name that should be used for DNS of the management API, eg rh-api
+	// DNSName is the name that should be used for DNS of the management API, eg rh-api.
+	// It names the LoadBalancer Service of the management API too, so every APIScheme needs its own.
 	DNSName string `json:"dnsName"`
-	// AllowedCIDRBlocks is the list of CIDR blocks that should be allowed to access the management API
+	// AllowedCIDRBlocks is the list of IPv4 and IPv6 CIDR blocks that should be allowed to access the management API
 	AllowedCIDRBlocks []string `json:"allowedCIDRBlocks"`
+	// Listening is the scope of the management API. An external one has an internet-facing load balancer and
+	// records in the public and private zones, an internal one an internal load balancer and a record in the
+	// private zone only. Empty is external.
+	// +kubebuilder:validation:Enum=internal;external
+	// +optional
+	Listening Listening `json:"listening,omitempty"`
+	// Type is the type of the AWS load balancer of the management API, Classic or NLB. An NLB preserves the
+	// source IP of the clients. Empty is Classic. It is ignored on the other platforms.
+	// +optional
+	Type Type `json:"type,omitempty"`
+	// IPFamilyPolicy is the IP family policy of the Service of the management API. With PreferDualStack or
+	// RequireDualStack the load balancer has IPv4 and IPv6 addresses and AAAA records are published alongside
+	// the A records, which needs an NLB on AWS. Empty is SingleStack, IPv4 only.
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// +optional
+	IPFamilyPolicy corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
 }
 
 // APISchemeStatus defines the observed state of APIScheme
 type APISchemeStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
-	CloudLoadBalancerDNSName string                 `json:"cloudLoadBalancerDNSName,omitempty"`
-	Conditions               []APISchemeCondition   `json:"conditions,omitempty"`
-	State                    APISchemeConditionType `json:"state,omitempty"`
+	CloudLoadBalancerDNSName string `json:"cloudLoadBalancerDNSName,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the Ready, Degraded, LoadBalancerReady and DNSReady state of the APIScheme
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// History holds the most recent condition transitions, oldest first
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	History []APISchemeCondition   `json:"history,omitempty"`
+	State   APISchemeConditionType `json:"state,omitempty"`
+	// DNSDrift is the result of the last periodic check of the management API records in the cluster zones
+	// +optional
+	DNSDrift *DNSDriftStatus `json:"dnsDrift,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,226 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CloudIngressOperatorConfigName is the name of the only CloudIngressOperatorConfig read by the operator
+const CloudIngressOperatorConfigName = "cluster"
+
+// CloudIngressOperatorConfigConditionType is the type of a CloudIngressOperatorConfig condition
+type CloudIngressOperatorConfigConditionType string
+
+const (
+	// CloudIngressOperatorConfigApplied is true when the operator runs with the configuration in the spec
+	CloudIngressOperatorConfigApplied CloudIngressOperatorConfigConditionType = "Applied"
+)
+
+const (
+	// ReasonInvalidConfig is used when the spec is rejected and the operator keeps its previous configuration
+	ReasonInvalidConfig = "InvalidConfig"
+)
+
+// CloudIngressOperatorConfigSpec defines the operator settings. Fields which
+// are left empty fall back to the defaults built into the operator.
+type CloudIngressOperatorConfigSpec struct {
+	// AdminAPIName is the DNS name given to the management API when an APIScheme doesn't set one, eg rh-api
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:default=rh-api
+	// +optional
+	AdminAPIName string `json:"adminAPIName,omitempty"`
+
+	// AdminAPIListenerPort is the port the management API Service listens on
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=6443
+	// +optional
+	AdminAPIListenerPort int32 `json:"adminAPIListenerPort,omitempty"`
+
+	// TargetGroupSuffixes are appended to the infrastructure name to name the API target groups on AWS
+	// +optional
+	TargetGroupSuffixes TargetGroupSuffixes `json:"targetGroupSuffixes,omitempty"`
+
+	// MaxAPIRetries is how many times a throttled or failed cloud API call is attempted
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	// +optional
+	MaxAPIRetries int32 `json:"maxAPIRetries,omitempty"`
+
+	// CredentialsSecrets are the names of the Secrets, in the operator namespace, holding the cloud credentials
+	// +optional
+	CredentialsSecrets CredentialsSecrets `json:"credentialsSecrets,omitempty"`
+
+	// RequeueIntervals are how long the controllers wait before checking again on a change they're waiting for
+	// +optional
+	RequeueIntervals RequeueIntervals `json:"requeueIntervals,omitempty"`
+
+	// ELBIdleTimeoutSeconds is the idle timeout set on the AWS load balancers of the management API and the IngressControllers
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4000
+	// +kubebuilder:default=1800
+	// +optional
+	ELBIdleTimeoutSeconds int32 `json:"elbIdleTimeoutSeconds,omitempty"`
+
+	// DNSDrift configures the periodic check of the api and management API DNS records
+	// +optional
+	DNSDrift DNSDrift `json:"dnsDrift,omitempty"`
+
+	// OrphanedResources configures the periodic search for cloud resources of the cluster which nothing uses anymore
+	// +optional
+	OrphanedResources OrphanedResources `json:"orphanedResources,omitempty"`
+}
+
+// DNSDriftPolicy is what the operator does with a DNS record which has drifted
+// +kubebuilder:validation:Enum=ReportOnly;AutoHeal
+type DNSDriftPolicy string
+
+const (
+	// DNSDriftReportOnly only reports the records which have drifted
+	DNSDriftReportOnly DNSDriftPolicy = "ReportOnly"
+	// DNSDriftAutoHeal also sets the records back to the expected target
+	DNSDriftAutoHeal DNSDriftPolicy = "AutoHeal"
+)
+
+// DNSDrift configures the DNS drift detection
+type DNSDrift struct {
+	// Policy is ReportOnly to only report the records which have drifted, or AutoHeal to also repair them
+	// +kubebuilder:default=ReportOnly
+	// +optional
+	Policy DNSDriftPolicy `json:"policy,omitempty"`
+	// CheckInterval is how often the records are compared with the load balancers, at least 1m
+	// +kubebuilder:default="10m"
+	// +optional
+	CheckInterval *metav1.Duration `json:"checkInterval,omitempty"`
+}
+
+// OrphanedResourcesPolicy is what the operator does with an orphaned cloud resource
+// +kubebuilder:validation:Enum=ReportOnly;Delete
+type OrphanedResourcesPolicy string
+
+const (
+	// OrphanedResourcesReportOnly only reports the orphaned resources
+	OrphanedResourcesReportOnly OrphanedResourcesPolicy = "ReportOnly"
+	// OrphanedResourcesDelete also deletes them once they have been orphaned for the grace period
+	OrphanedResourcesDelete OrphanedResourcesPolicy = "Delete"
+)
+
+// OrphanedResources configures the search for orphaned cloud resources
+type OrphanedResources struct {
+	// Policy is ReportOnly to only report the orphaned resources, or Delete to also delete them
+	// +kubebuilder:default=ReportOnly
+	// +optional
+	Policy OrphanedResourcesPolicy `json:"policy,omitempty"`
+	// CheckInterval is how often the cloud resources are searched, at least 1m
+	// +kubebuilder:default="1h"
+	// +optional
+	CheckInterval *metav1.Duration `json:"checkInterval,omitempty"`
+	// GracePeriod is how long a resource has to be found orphaned before it is deleted
+	// +kubebuilder:default="1h"
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// TargetGroupSuffixes are the suffixes of the API target groups, eg aext for <infra>-aext
+type TargetGroupSuffixes struct {
+	// ExternalAPI is the suffix of the target group behind the external API load balancer
+	// +kubebuilder:validation:MaxLength=4
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+$`
+	// +kubebuilder:default=aext
+	// +optional
+	ExternalAPI string `json:"externalAPI,omitempty"`
+	// DedicatedExternalAPI is the suffix of the target group the operator creates behind the
+	// external API load balancer when the ExternalAPI one is associated with another load balancer
+	// +kubebuilder:validation:MaxLength=4
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+$`
+	// +kubebuilder:default=cext
+	// +optional
+	DedicatedExternalAPI string `json:"dedicatedExternalAPI,omitempty"`
+}
+
+// CredentialsSecrets are the names of the Secrets holding the cloud credentials
+type CredentialsSecrets struct {
+	// AWS is the Secret holding the AWS credentials
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:default=cloud-ingress-operator-credentials-aws
+	// +optional
+	AWS string `json:"aws,omitempty"`
+	// GCP is the Secret holding the GCP service account
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:default=cloud-ingress-operator-credentials-gcp
+	// +optional
+	GCP string `json:"gcp,omitempty"`
+	// Azure is the Secret holding the Azure service principal
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:default=cloud-ingress-operator-credentials-azure
+	// +optional
+	Azure string `json:"azure,omitempty"`
+}
+
+// RequeueIntervals are the requeue delays of the controllers
+type RequeueIntervals struct {
+	// Short is used while waiting on quick changes, such as a Service being recreated
+	// +kubebuilder:default="10s"
+	// +optional
+	Short *metav1.Duration `json:"short,omitempty"`
+	// Long is used while waiting on the cloud provider, such as a load balancer being provisioned
+	// +kubebuilder:default="60s"
+	// +optional
+	Long *metav1.Duration `json:"long,omitempty"`
+}
+
+// CloudIngressOperatorConfigStatus defines the observed state of CloudIngressOperatorConfig
+type CloudIngressOperatorConfigStatus struct {
+	// ObservedGeneration is the most recent generation observed by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions tell whether the spec is in effect
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// CloudIngressOperatorConfig is the Schema for the cloudingressoperatorconfigs API.
+// Only the one named cluster is read, changes to it are applied without restarting the operator.
+type CloudIngressOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudIngressOperatorConfigSpec   `json:"spec,omitempty"`
+	Status CloudIngressOperatorConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CloudIngressOperatorConfigList contains a list of CloudIngressOperatorConfig
+type CloudIngressOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudIngressOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CloudIngressOperatorConfig{}, &CloudIngressOperatorConfigList{})
+}
@@ -64,10 +64,280 @@ const (
 	External Listening = "external"
 )
 
+// APIScopeDryRunAnnotation is set to "true" on a PublishingStrategy to only plan the changes needed to move the
+// default API to the scope in the spec. The plan is published in status.defaultAPIServerIngressPlan and is applied
+// once the annotation is removed.
+const APIScopeDryRunAnnotation = "cloudingress.managed.openshift.io/api-scope-dry-run"
+
+// PublishingStrategyConditionType is a valid value for the type of a PublishingStrategy condition
+type PublishingStrategyConditionType string
+
+const (
+	// PublishingStrategyReady means the default API scope and every ApplicationIngress match the spec
+	PublishingStrategyReady PublishingStrategyConditionType = "Ready"
+	// PublishingStrategyProgressing means the operator is still converging towards the spec
+	PublishingStrategyProgressing PublishingStrategyConditionType = "Progressing"
+	// PublishingStrategyDegraded means the last reconcile failed
+	PublishingStrategyDegraded PublishingStrategyConditionType = "Degraded"
+)
+
+const (
+	// ReasonAsExpected is used when the observed state matches the spec
+	ReasonAsExpected = "AsExpected"
+	// ReasonIngressControllerChanging is used while an IngressController is being created, patched or recreated
+	ReasonIngressControllerChanging = "IngressControllerChanging"
+	// ReasonDefaultAPIScopeMismatch is used while the observed API scope differs from the spec
+	ReasonDefaultAPIScopeMismatch = "DefaultAPIScopeMismatch"
+	// ReasonReconcileFailed is used when the last reconcile returned an error
+	ReasonReconcileFailed = "ReconcileFailed"
+	// ReasonAPIScopeChangePlanned is used while a default API scope change is only planned because of the
+	// APIScopeDryRunAnnotation
+	ReasonAPIScopeChangePlanned = "APIScopeChangePlanned"
+	// ReasonControlPlaneMachineSetReactivating is used while the ControlPlaneMachineSet is being set back to Active
+	// after a load balancer was removed from it
+	ReasonControlPlaneMachineSetReactivating = "ControlPlaneMachineSetReactivating"
+	// ReasonControlPlaneMachineSetReactivationFailing is used when the last attempt to set the ControlPlaneMachineSet
+	// back to Active failed
+	ReasonControlPlaneMachineSetReactivationFailing = "ControlPlaneMachineSetReactivationFailing"
+)
+
+// IngressControllerAction is the last change made to the IngressController backing an ApplicationIngress
+type IngressControllerAction string
+
+const (
+	// IngressControllerCreated means the IngressController was missing and has been created
+	IngressControllerCreated IngressControllerAction = "Created"
+	// IngressControllerPatched means a mutable field of the IngressController has been patched
+	IngressControllerPatched IngressControllerAction = "Patched"
+	// IngressControllerRecreated means the IngressController has been deleted so it can be recreated
+	// with an immutable field changed
+	IngressControllerRecreated IngressControllerAction = "Recreated"
+	// IngressControllerDeleting means the operator is waiting for the IngressController deletion to complete
+	IngressControllerDeleting IngressControllerAction = "Deleting"
+)
+
 // PublishingStrategyStatus defines the observed state of PublishingStrategy
 type PublishingStrategyStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// ObservedGeneration is the most recent generation observed by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the Ready, Progressing and Degraded state of the PublishingStrategy
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// DefaultAPIServerIngress is the default API scope as observed from the cloud provider
+	// +optional
+	DefaultAPIServerIngress DefaultAPIServerIngress `json:"defaultAPIServerIngress,omitempty"`
+	// ApplicationIngress holds the observed state of each ApplicationIngress in the spec
+	// +optional
+	ApplicationIngress []ApplicationIngressStatus `json:"applicationIngress,omitempty"`
+	// DefaultAPIServerIngressPlan lists the changes needed to move the default API to the scope in the spec.
+	// It is only set when the APIScopeDryRunAnnotation is set and there is something to change.
+	// +optional
+	DefaultAPIServerIngressPlan *APIScopeChangePlan `json:"defaultAPIServerIngressPlan,omitempty"`
+	// ControlPlaneMachineSetReactivation tracks setting the ControlPlaneMachineSet back to Active after it was
+	// deleted to remove a load balancer from it. It is only set until the ControlPlaneMachineSet is Active again.
+	// +optional
+	ControlPlaneMachineSetReactivation *ControlPlaneMachineSetReactivation `json:"controlPlaneMachineSetReactivation,omitempty"`
+	// DNSDrift is the result of the last periodic check of the api records in the cluster zones
+	// +optional
+	DNSDrift *DNSDriftStatus `json:"dnsDrift,omitempty"`
+
+	// OrphanedResources is the result of the last periodic search for cloud resources of the cluster which
+	// nothing uses anymore
+	// +optional
+	OrphanedResources *OrphanedResourcesStatus `json:"orphanedResources,omitempty"`
+}
+
+// DNSRecordZone is the cluster zone a DNS record is in
+type DNSRecordZone string
+
+const (
+	// DNSRecordZonePublic is the public zone of the cluster, eg abcd.s1.devshift.org
+	DNSRecordZonePublic DNSRecordZone = "Public"
+	// DNSRecordZonePrivate is the private zone of the cluster, eg mycluster.abcd.s1.devshift.org
+	DNSRecordZonePrivate DNSRecordZone = "Private"
+)
+
+// DNSRecordDrift is a DNS record which doesn't point where the operator expects it to
+type DNSRecordDrift struct {
+	// Name is the fully qualified name of the record
+	Name string `json:"name"`
+	// Zone is the cluster zone the record is in
+	Zone DNSRecordZone `json:"zone"`
+	// Expected is the target the record should have, the alias target on AWS or the addresses and TTL on GCP
+	Expected string `json:"expected"`
+	// Actual is the target the record has, empty when the record is missing
+	// +optional
+	Actual string `json:"actual,omitempty"`
+	// Repaired is true when the record was set back to the expected target
+	// +optional
+	Repaired bool `json:"repaired,omitempty"`
+}
+
+// DNSDriftStatus is the result of the last check of the DNS records managed by the operator
+type DNSDriftStatus struct {
+	// LastCheckTime is when a check last found other records or another error than the previous one.
+	// The status isn't written again while the checks find the same.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+	// Records are the records which had drifted at the last check, empty when they all matched
+	// +optional
+	Records []DNSRecordDrift `json:"records,omitempty"`
+	// LastError is the error of the last check, empty when it succeeded
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// OrphanedResourceKind is the kind of a cloud resource
+type OrphanedResourceKind string
+
+const (
+	// OrphanedLoadBalancer is an AWS Classic ELB or NLB
+	OrphanedLoadBalancer OrphanedResourceKind = "LoadBalancer"
+	// OrphanedSecurityGroup is the AWS security group of a Classic ELB
+	OrphanedSecurityGroup OrphanedResourceKind = "SecurityGroup"
+	// OrphanedForwardingRule is a GCP forwarding rule
+	OrphanedForwardingRule OrphanedResourceKind = "ForwardingRule"
+	// OrphanedIPAddress is a GCP static IP address
+	OrphanedIPAddress OrphanedResourceKind = "IPAddress"
+	// OrphanedDNSRecord is an A record in a cluster zone
+	OrphanedDNSRecord OrphanedResourceKind = "DNSRecord"
+)
+
+// OrphanedResource is a cloud resource of the cluster which nothing uses anymore
+type OrphanedResource struct {
+	// Kind is the kind of the resource
+	Kind OrphanedResourceKind `json:"kind"`
+	// Name is the name of the resource, the ID of a security group or the fully qualified name of a DNS record
+	Name string `json:"name"`
+	// Zone is the cluster zone of a DNS record
+	// +optional
+	Zone DNSRecordZone `json:"zone,omitempty"`
+	// Reason tells why nothing uses the resource
+	Reason string `json:"reason"`
+	// FirstSeenTime is when the resource was first found orphaned
+	FirstSeenTime metav1.Time `json:"firstSeenTime"`
+	// Deleted is true when the resource was deleted
+	// +optional
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// OrphanedResourcesStatus is the result of the last search for orphaned cloud resources
+type OrphanedResourcesStatus struct {
+	// LastCheckTime is when the resources were last searched
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+	// Resources are the orphaned resources found by the last search
+	// +optional
+	Resources []OrphanedResource `json:"resources,omitempty"`
+	// LastError is the error of the last search, empty when it succeeded
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// ControlPlaneMachineSetReactivationPhase is the step a ControlPlaneMachineSetReactivation is at
+type ControlPlaneMachineSetReactivationPhase string
+
+const (
+	// CPMSReactivationRemovingLoadBalancers means the load balancers have still to be removed from the inactive
+	// ControlPlaneMachineSet the deleted one is recreated as
+	CPMSReactivationRemovingLoadBalancers ControlPlaneMachineSetReactivationPhase = "RemovingLoadBalancers"
+	// CPMSReactivationActivating means the ControlPlaneMachineSet has still to be set back to Active
+	CPMSReactivationActivating ControlPlaneMachineSetReactivationPhase = "Activating"
+)
+
+// ControlPlaneMachineSetReactivation is the progress of setting the ControlPlaneMachineSet back to Active.
+// It is recorded before the ControlPlaneMachineSet is deleted, so it is resumed after an operator restart.
+type ControlPlaneMachineSetReactivation struct {
+	// Phase is the next step of the reactivation
+	Phase ControlPlaneMachineSetReactivationPhase `json:"phase"`
+	// LoadBalancers are the load balancers to remove from the ControlPlaneMachineSet
+	// +optional
+	LoadBalancers []string `json:"loadBalancers,omitempty"`
+	// StartTime is when the ControlPlaneMachineSet was deleted
+	StartTime metav1.Time `json:"startTime"`
+	// Attempts is the number of failed attempts
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// LastAttemptTime is when the last attempt was made
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+	// LastError is the error of the last attempt, empty when it succeeded
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// APIScopeChangePlan lists the changes the operator would make to move the default API to another scope
+type APIScopeChangePlan struct {
+	// Listening is the scope the default API would be moved to
+	Listening Listening `json:"listening"`
+	// GeneratedTime is when the plan was first computed, it is kept as long as the plan doesn't change
+	GeneratedTime metav1.Time `json:"generatedTime"`
+	// Actions are the changes which would be made, in order
+	// +optional
+	Actions []PlannedAction `json:"actions,omitempty"`
+}
+
+// PlannedActionType is the kind of change a PlannedAction would make
+type PlannedActionType string
+
+const (
+	// PlannedActionCreate means the resource would be created
+	PlannedActionCreate PlannedActionType = "Create"
+	// PlannedActionUpdate means the resource would be changed
+	PlannedActionUpdate PlannedActionType = "Update"
+	// PlannedActionDelete means the resource would be deleted or released
+	PlannedActionDelete PlannedActionType = "Delete"
+)
+
+// PlannedResourceKind is the kind of resource a PlannedAction would change
+type PlannedResourceKind string
+
+const (
+	// PlannedResourceLoadBalancer is a cloud load balancer, or a GCP forwarding rule
+	PlannedResourceLoadBalancer PlannedResourceKind = "LoadBalancer"
+	// PlannedResourceListener is an AWS load balancer listener
+	PlannedResourceListener PlannedResourceKind = "Listener"
+	// PlannedResourceTargetGroup is an AWS load balancer target group
+	PlannedResourceTargetGroup PlannedResourceKind = "TargetGroup"
+	// PlannedResourceMachine is a control plane Machine
+	PlannedResourceMachine PlannedResourceKind = "Machine"
+	// PlannedResourceControlPlaneMachineSet is the cluster ControlPlaneMachineSet
+	PlannedResourceControlPlaneMachineSet PlannedResourceKind = "ControlPlaneMachineSet"
+	// PlannedResourceDNSRecord is a DNS record in one of the cluster zones
+	PlannedResourceDNSRecord PlannedResourceKind = "DNSRecord"
+	// PlannedResourceIPAddress is a reserved cloud IP address
+	PlannedResourceIPAddress PlannedResourceKind = "IPAddress"
+)
+
+// PlannedAction is a single change of an APIScopeChangePlan
+type PlannedAction struct {
+	// Action is the change which would be made
+	Action PlannedActionType `json:"action"`
+	// Kind of the resource which would be changed
+	Kind PlannedResourceKind `json:"kind"`
+	// Name of the resource which would be changed
+	Name string `json:"name"`
+	// Description of the change
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// ApplicationIngressStatus defines the observed state of an ApplicationIngress
+type ApplicationIngressStatus struct {
+	// DNSName of the ApplicationIngress this entry refers to
+	DNSName string `json:"dnsName"`
+	// IngressControllerName is the name of the IngressController backing the ApplicationIngress
+	IngressControllerName string `json:"ingressControllerName,omitempty"`
+	// LastAction is the last change made to the IngressController
+	// +optional
+	LastAction IngressControllerAction `json:"lastAction,omitempty"`
+	// LastActionTime is when LastAction happened
+	// +optional
+	LastActionTime *metav1.Time `json:"lastActionTime,omitempty"`
+	// LastError is the error from the last reconcile of this ApplicationIngress, empty on success
+	// +optional
+	LastError string `json:"lastError,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -110,11 +111,23 @@ func (in *APISchemeStatus) DeepCopyInto(out *APISchemeStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
 		*out = make([]APISchemeCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSDrift != nil {
+		in, out := &in.DNSDrift, &out.DNSDrift
+		*out = new(DNSDriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APISchemeStatus.
@@ -127,6 +140,27 @@ func (in *APISchemeStatus) DeepCopy() *APISchemeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIScopeChangePlan) DeepCopyInto(out *APIScopeChangePlan) {
+	*out = *in
+	in.GeneratedTime.DeepCopyInto(&out.GeneratedTime)
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]PlannedAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIScopeChangePlan.
+func (in *APIScopeChangePlan) DeepCopy() *APIScopeChangePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(APIScopeChangePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationIngress) DeepCopyInto(out *ApplicationIngress) {
 	*out = *in
@@ -144,6 +178,222 @@ func (in *ApplicationIngress) DeepCopy() *ApplicationIngress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationIngressStatus) DeepCopyInto(out *ApplicationIngressStatus) {
+	*out = *in
+	if in.LastActionTime != nil {
+		in, out := &in.LastActionTime, &out.LastActionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationIngressStatus.
+func (in *ApplicationIngressStatus) DeepCopy() *ApplicationIngressStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationIngressStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIngressOperatorConfig) DeepCopyInto(out *CloudIngressOperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIngressOperatorConfig.
+func (in *CloudIngressOperatorConfig) DeepCopy() *CloudIngressOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIngressOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudIngressOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIngressOperatorConfigList) DeepCopyInto(out *CloudIngressOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudIngressOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIngressOperatorConfigList.
+func (in *CloudIngressOperatorConfigList) DeepCopy() *CloudIngressOperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIngressOperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudIngressOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIngressOperatorConfigSpec) DeepCopyInto(out *CloudIngressOperatorConfigSpec) {
+	*out = *in
+	out.TargetGroupSuffixes = in.TargetGroupSuffixes
+	out.CredentialsSecrets = in.CredentialsSecrets
+	in.RequeueIntervals.DeepCopyInto(&out.RequeueIntervals)
+	in.DNSDrift.DeepCopyInto(&out.DNSDrift)
+	in.OrphanedResources.DeepCopyInto(&out.OrphanedResources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIngressOperatorConfigSpec.
+func (in *CloudIngressOperatorConfigSpec) DeepCopy() *CloudIngressOperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIngressOperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIngressOperatorConfigStatus) DeepCopyInto(out *CloudIngressOperatorConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIngressOperatorConfigStatus.
+func (in *CloudIngressOperatorConfigStatus) DeepCopy() *CloudIngressOperatorConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIngressOperatorConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneMachineSetReactivation) DeepCopyInto(out *ControlPlaneMachineSetReactivation) {
+	*out = *in
+	if in.LoadBalancers != nil {
+		in, out := &in.LoadBalancers, &out.LoadBalancers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneMachineSetReactivation.
+func (in *ControlPlaneMachineSetReactivation) DeepCopy() *ControlPlaneMachineSetReactivation {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneMachineSetReactivation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSecrets) DeepCopyInto(out *CredentialsSecrets) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSecrets.
+func (in *CredentialsSecrets) DeepCopy() *CredentialsSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSDrift) DeepCopyInto(out *DNSDrift) {
+	*out = *in
+	if in.CheckInterval != nil {
+		in, out := &in.CheckInterval, &out.CheckInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSDrift.
+func (in *DNSDrift) DeepCopy() *DNSDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSDriftStatus) DeepCopyInto(out *DNSDriftStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]DNSRecordDrift, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSDriftStatus.
+func (in *DNSDriftStatus) DeepCopy() *DNSDriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSDriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordDrift) DeepCopyInto(out *DNSRecordDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordDrift.
+func (in *DNSRecordDrift) DeepCopy() *DNSRecordDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DefaultAPIServerIngress) DeepCopyInto(out *DefaultAPIServerIngress) {
 	*out = *in
@@ -179,13 +429,92 @@ func (in *ManagementAPIServerIngress) DeepCopy() *ManagementAPIServerIngress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedResource) DeepCopyInto(out *OrphanedResource) {
+	*out = *in
+	in.FirstSeenTime.DeepCopyInto(&out.FirstSeenTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedResource.
+func (in *OrphanedResource) DeepCopy() *OrphanedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedResources) DeepCopyInto(out *OrphanedResources) {
+	*out = *in
+	if in.CheckInterval != nil {
+		in, out := &in.CheckInterval, &out.CheckInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedResources.
+func (in *OrphanedResources) DeepCopy() *OrphanedResources {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedResourcesStatus) DeepCopyInto(out *OrphanedResourcesStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]OrphanedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedResourcesStatus.
+func (in *OrphanedResourcesStatus) DeepCopy() *OrphanedResourcesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedResourcesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedAction) DeepCopyInto(out *PlannedAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedAction.
+func (in *PlannedAction) DeepCopy() *PlannedAction {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublishingStrategy) DeepCopyInto(out *PublishingStrategy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishingStrategy.
@@ -264,6 +593,41 @@ func (in *PublishingStrategySpec) DeepCopy() *PublishingStrategySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublishingStrategyStatus) DeepCopyInto(out *PublishingStrategyStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.DefaultAPIServerIngress = in.DefaultAPIServerIngress
+	if in.ApplicationIngress != nil {
+		in, out := &in.ApplicationIngress, &out.ApplicationIngress
+		*out = make([]ApplicationIngressStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultAPIServerIngressPlan != nil {
+		in, out := &in.DefaultAPIServerIngressPlan, &out.DefaultAPIServerIngressPlan
+		*out = new(APIScopeChangePlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneMachineSetReactivation != nil {
+		in, out := &in.ControlPlaneMachineSetReactivation, &out.ControlPlaneMachineSetReactivation
+		*out = new(ControlPlaneMachineSetReactivation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSDrift != nil {
+		in, out := &in.DNSDrift, &out.DNSDrift
+		*out = new(DNSDriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanedResources != nil {
+		in, out := &in.OrphanedResources, &out.OrphanedResources
+		*out = new(OrphanedResourcesStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishingStrategyStatus.
@@ -275,3 +639,43 @@ func (in *PublishingStrategyStatus) DeepCopy() *PublishingStrategyStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequeueIntervals) DeepCopyInto(out *RequeueIntervals) {
+	*out = *in
+	if in.Short != nil {
+		in, out := &in.Short, &out.Short
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Long != nil {
+		in, out := &in.Long, &out.Long
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequeueIntervals.
+func (in *RequeueIntervals) DeepCopy() *RequeueIntervals {
+	if in == nil {
+		return nil
+	}
+	out := new(RequeueIntervals)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupSuffixes) DeepCopyInto(out *TargetGroupSuffixes) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupSuffixes.
+func (in *TargetGroupSuffixes) DeepCopy() *TargetGroupSuffixes {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupSuffixes)
+	in.DeepCopyInto(out)
+	return out
+}
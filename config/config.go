@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 const (
 	// AdminAPIName is the name of the API endpoint for non-customer use (eg Hive)
 	AdminAPIName string = "rh-api"
@@ -29,6 +31,13 @@ const (
 	InternalAPITargetGroupSuffix string = "aint"
 	// ExternalAPITargetGroupSuffix external api target group suffix
 	ExternalAPITargetGroupSuffix string = "aext"
+	// DedicatedExternalAPITargetGroupSuffix default suffix of the external api
+	// target group the operator creates when the installer's one is already
+	// associated with another load balancer. It isn't aext as originally
+	// requested: the installer already owns <infra>-aext, the dedicated target
+	// group needs a name of its own. Target group names are limited to 32
+	// characters, the infrastructure name takes up to 27 of them.
+	DedicatedExternalAPITargetGroupSuffix string = "cext"
 
 	// OperatorName is the name of this operator
 	OperatorName string = "cloud-ingress-operator"
@@ -52,9 +61,41 @@ const (
 	// GCPSecretName
 	GCPSecretName string = "cloud-ingress-operator-credentials-gcp" //#nosec G101 -- This is a false positive
 
+	// AzureSecretName
+	AzureSecretName string = "cloud-ingress-operator-credentials-azure" //#nosec G101 -- This is a false positive
+
 	// OperatorNamespace
 	OperatorNamespace string = "openshift-cloud-ingress-operator"
 
+	// ELBIdleTimeoutSeconds is the idle timeout of the AWS load balancers of the
+	// admin API and the IngressControllers
+	ELBIdleTimeoutSeconds int32 = 1800
+
+	// ShortRequeueInterval is how long the controllers wait on quick changes
+	ShortRequeueInterval time.Duration = 10 * time.Second
+
+	// LongRequeueInterval is how long the controllers wait on the cloud provider
+	LongRequeueInterval time.Duration = 60 * time.Second
+
+	// DNSDriftPolicy is what is done with the DNS records which have drifted,
+	// ReportOnly or AutoHeal
+	DNSDriftPolicy string = "ReportOnly"
+
+	// DNSDriftCheckInterval is how often the DNS records are checked for drift
+	DNSDriftCheckInterval time.Duration = 10 * time.Minute
+
+	// OrphanedResourcesPolicy is what is done with the cloud resources which
+	// nothing uses anymore, ReportOnly or Delete
+	OrphanedResourcesPolicy string = "ReportOnly"
+
+	// OrphanedResourcesCheckInterval is how often the orphaned cloud resources
+	// are searched
+	OrphanedResourcesCheckInterval time.Duration = time.Hour
+
+	// OrphanedResourcesGracePeriod is how long a cloud resource has to be
+	// orphaned before it is deleted
+	OrphanedResourcesGracePeriod time.Duration = time.Hour
+
 	// olm.skipRange annotation added to CSV --SREP-96
 	EnableOLMSkipRange string = "true"
 )
@@ -19,23 +19,34 @@ package apischeme
 import (
 	"context"
 	"fmt"
-	"time"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/retry"
 	localctlutils "github.com/openshift/cloud-ingress-operator/pkg/controllerutils"
 	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
 	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -46,13 +57,26 @@ import (
 const (
 	reconcileFinalizerDNS         = "dns.cloudingress.managed.openshift.io"
 	elbAnnotationIdleTimeoutKey   = "service.beta.kubernetes.io/aws-load-balancer-connection-idle-timeout"
-	elbAnnotationIdleTimeoutValue = "1800"
 	elbAnnotationResourceTagKey   = "service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags"
 	elbAnnotationResourceTagValue = "red-hat-managed=true"
-	longwait                      = 60
-	shortwait                     = 10
+	// elbAnnotationTypeKey makes the AWS cloud provider create an NLB instead of a Classic ELB
+	elbAnnotationTypeKey   = "service.beta.kubernetes.io/aws-load-balancer-type"
+	elbAnnotationTypeValue = "nlb"
+	// elbAnnotationIPAddressTypeKey gives the NLB IPv6 addresses as well
+	elbAnnotationIPAddressTypeKey   = "service.beta.kubernetes.io/aws-load-balancer-ip-address-type"
+	elbAnnotationIPAddressTypeValue = "dualstack"
+	// apiSchemeLabel names the APIScheme which manages a Service
+	apiSchemeLabel = "apischeme_cr"
 )
 
+// internalLoadBalancerAnnotations make the cloud providers create an internal load balancer for a Service.
+// They are all set on the Service of an internal management API, each provider only reads its own.
+var internalLoadBalancerAnnotations = map[string]string{
+	"service.beta.kubernetes.io/aws-load-balancer-internal":   "true",
+	"cloud.google.com/load-balancer-type":                     "Internal",
+	"service.beta.kubernetes.io/azure-load-balancer-internal": "true",
+}
+
 var (
 	log = logf.Log.WithName("controller_apischeme")
 	// for testing to set it to something else
@@ -63,8 +87,9 @@ var _ reconcile.Reconciler = &APISchemeReconciler{}
 
 // APISchemeReconciler reconciles a APIScheme object
 type APISchemeReconciler struct {
-	Client client.Client
-	Scheme *runtime.Scheme
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
 }
 
 // LoadBalancer contains the relevant information to create a Load Balancer
@@ -109,26 +134,39 @@ func (r *APISchemeReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 		reqLogger.Error(err, "Error reading APIScheme object")
 		return reconcile.Result{}, err
 	}
+	// Events recorded by the cloud client are about this APIScheme
+	ctx = cioevents.IntoContext(ctx, r.Recorder, instance)
 
 	// If the management API isn't enabled, we have nothing to do!
-	if !instance.Spec.ManagementAPIServerIngress.Enabled {
+	// An APIScheme disabled after its endpoint was created still has to clean it up when deleted.
+	if !instance.Spec.ManagementAPIServerIngress.Enabled && instance.DeletionTimestamp.IsZero() {
 		reqLogger.Info("Not enabled", "instance", instance)
 		return reconcile.Result{}, nil
 	}
 
+	// The cloud clients are cached, a new one is only created when the credentials change
+	cloudClient := cloudClient
 	if cloudClient == nil {
 		cloudPlatform, err := baseutils.GetPlatformType(r.Client)
 		if err != nil {
-			r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Couldn't create a Cloud Client", cloudingressv1alpha1.ConditionError)
+			r.SetAPISchemeStatus(instance,
+				apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionTrue, cloudingressv1alpha1.ReasonCloudClientError, "Couldn't create a Cloud Client"))
 			r.SetAPISchemeStatusMetric(instance)
 			return reconcile.Result{}, err
 		}
-		cloudClient = cloudclient.GetClientFor(r.Client, *cloudPlatform)
-	}
-
-	serviceNamespacedName := types.NamespacedName{
-		Name:      instance.Spec.ManagementAPIServerIngress.DNSName,
-		Namespace: "openshift-kube-apiserver",
+		cli, err := cloudclient.GetClientFor(r.Client, *cloudPlatform)
+		if err != nil {
+			reqLogger.Error(err, "Couldn't create a Cloud Client")
+			r.SetAPISchemeStatus(instance,
+				apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionTrue, cloudingressv1alpha1.ReasonCloudClientError, err.Error()))
+			r.SetAPISchemeStatusMetric(instance)
+			if _, ok := err.(*cioerrors.UnsupportedPlatformError); ok {
+				// Retrying won't make the platform supported
+				return reconcile.Result{}, nil
+			}
+			return reconcile.Result{}, err
+		}
+		cloudClient = cli
 	}
 
 	// Check for a deletion timestamp.
@@ -143,41 +181,66 @@ func (r *APISchemeReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 	} else {
 		// Request object is being deleted.
 		if controllerutil.ContainsFinalizer(instance, reconcileFinalizerDNS) {
-			found := &corev1.Service{}
-			if err = r.Client.Get(ctx, serviceNamespacedName, found); err != nil {
-				if errors.IsNotFound(err) {
-					// Service was not found!
-					//
-					// Skip the DeleteAdminAPIDNS call and remove the
-					// finalizer anyway so the CR deletion can proceed.
-					// This could leave DNS entries behind!
-					//
-					// TODO As a future enhancement, the CloudClient
-					//      provider should handle this scenario and
-					//      look up the necessary information itself
-					//      to proceed with the DNS deletion.
-					found = nil
-				} else {
-					reqLogger.Error(err, "Couldn't get the Service")
-					return reconcile.Result{}, err
+			services, err := baseutils.AdminAPIServices(ctx, r.Client, instance.Spec.ManagementAPIServerIngress.DNSName)
+			if err != nil {
+				reqLogger.Error(err, "Couldn't get the Services")
+				return reconcile.Result{}, err
+			}
+			// The records and the Services are left to the APIScheme which holds the DNS name, if another one does
+			conflict, err := r.dnsNameConflict(ctx, instance, services)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			switch {
+			case conflict != "":
+				reqLogger.Info("Not deleting the management API endpoint", "reason", conflict)
+			case len(services) == 0:
+				// The Service, and its load balancer, are already gone so the
+				// CloudClient has to find the DNS records on its own.
+				reqLogger.Info("Couldn't find the Service, deleting the DNS records by name")
+				err = cloudClient.DeleteAdminAPIDNSWithoutService(ctx, r.Client, instance)
+			case len(services) > 1:
+				// Moving to another load balancer, the records lead to either of them
+				reqLogger.Info("Found several Services, deleting the DNS records by name")
+				err = cloudClient.DeleteAdminAPIDNSWithoutService(ctx, r.Client, instance)
+			default:
+				err = cloudClient.DeleteAdminAPIDNS(ctx, r.Client, instance, &services[0])
+				if _, ok := err.(*cioerrors.LoadBalancerNotReadyError); ok {
+					// The load balancer of the Service is gone, or was never created, so
+					// the records can only be found by name. Waiting for it would stall the finalizer.
+					reqLogger.Info("Couldn't find the load balancer of the Service, deleting the DNS records by name")
+					err = cloudClient.DeleteAdminAPIDNSWithoutService(ctx, r.Client, instance)
 				}
 			}
+			if after, ok := retry.RequeueAfter(err); ok {
+				reqLogger.Info("Couldn't delete the DNS record yet, retrying", "requeueAfter", after, "error", err.Error())
+				r.SetAPISchemeStatus(instance,
+					apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonCloudAPIRetrying, "Retrying the DNS record deletion: "+err.Error()))
+				r.SetAPISchemeStatusMetric(instance)
+				return reconcile.Result{RequeueAfter: after}, nil
+			}
+			switch err := err.(type) {
+			case nil:
+				// all good
+			default:
+				reqLogger.Error(err, "Failed to delete the DNS record")
+				cioevents.Warning(ctx, cioevents.ReasonDNSDeleteFailed, cioevents.ActionDelete, "Failed to delete the DNS record for %s: %v", instance.Spec.ManagementAPIServerIngress.DNSName, err)
+				r.SetAPISchemeStatus(instance,
+					apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonDNSDeleteFailed, "Failed to delete the DNS record"),
+					apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionTrue, cloudingressv1alpha1.ReasonDNSDeleteFailed, "Failed to delete the DNS record: "+err.Error()))
+				r.SetAPISchemeStatusMetric(instance)
+				return reconcile.Result{}, err
+			}
 
-			if found != nil {
-				err = cloudClient.DeleteAdminAPIDNS(ctx, r.Client, instance, found)
-				switch err := err.(type) {
-				case nil:
-					// all good
-				case *cioerrors.LoadBalancerNotReadyError:
-					// couldn't find the load balancer - it's likely still queued for creation
-					r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Load balancer isn't ready", cloudingressv1alpha1.ConditionError)
-					r.SetAPISchemeStatusMetric(instance)
-					return reconcile.Result{Requeue: true, RequeueAfter: shortwait * time.Second}, nil
-				default:
-					reqLogger.Error(err, "Failed to delete the DNS record")
-					r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Failed to delete the DNS record", cloudingressv1alpha1.ConditionError)
-					r.SetAPISchemeStatusMetric(instance)
-					return reconcile.Result{}, err
+			// The load balancers go with the Services, each APIScheme has its own
+			if conflict == "" {
+				for i := range services {
+					found := &services[i]
+					if err = r.Client.Delete(ctx, found); err != nil && !errors.IsNotFound(err) {
+						reqLogger.Error(err, "Failed to delete the Service")
+						return reconcile.Result{}, err
+					}
+					cioevents.Normal(ctx, cioevents.ReasonServiceDeleted, cioevents.ActionDelete, "Deleted Service %s/%s of the deleted APIScheme", found.GetNamespace(), found.GetName())
 				}
 			}
 
@@ -186,6 +249,7 @@ func (r *APISchemeReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 			if err = r.Client.Update(ctx, instance); err != nil {
 				return reconcile.Result{}, err
 			}
+			localmetrics.DeleteAPISchemeReady(instance.Name)
 
 			// Requeue once more after updating.  Without a finalizer,
 			// the next pass should delete the request object.
@@ -197,26 +261,78 @@ func (r *APISchemeReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 	}
 
 	// Does the Service exist already?
-	found := &corev1.Service{}
-	err = r.Client.Get(ctx, serviceNamespacedName, found)
+	services, err := baseutils.AdminAPIServices(ctx, r.Client, instance.Spec.ManagementAPIServerIngress.DNSName)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			// need to create it
-			dep := r.newServiceFor(instance)
-			reqLogger.Info("Service not found. Creating", "service", dep)
-			err = r.Client.Create(ctx, dep)
-			if err != nil {
-				reqLogger.Error(err, "Failure to create new Service")
-				return reconcile.Result{}, err
-			}
-			// Reconcile again to get the new Service and give cloud provider time to create the LB
-			reqLogger.Info("Service was just created, so let's try to requeue to set it up")
-			return reconcile.Result{Requeue: true, RequeueAfter: longwait * time.Second}, nil
-		} else if err != nil {
-			reqLogger.Error(err, "Couldn't get the Service")
+		reqLogger.Error(err, "Couldn't get the Services")
+		return reconcile.Result{}, err
+	}
+	conflict, err := r.dnsNameConflict(ctx, instance, services)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if conflict != "" {
+		reqLogger.Info("Not creating the management API endpoint", "reason", conflict)
+		return r.reportDNSNameConflict(ctx, instance, conflict), nil
+	}
+	if len(services) == 0 {
+		// need to create it
+		dep := r.newServiceFor(instance, instance.Spec.ManagementAPIServerIngress.DNSName)
+		reqLogger.Info("Service not found. Creating", "service", dep)
+		err = r.Client.Create(ctx, dep)
+		if err != nil {
+			reqLogger.Error(err, "Failure to create new Service")
 			return reconcile.Result{}, err
 		}
+		cioevents.Normal(ctx, cioevents.ReasonServiceCreated, cioevents.ActionCreate, "Created Service %s/%s", dep.GetNamespace(), dep.GetName())
+		// Reconcile again to get the new Service and give cloud provider time to create the LB
+		reqLogger.Info("Service was just created, so let's try to requeue to set it up")
+		return reconcile.Result{Requeue: true, RequeueAfter: operatorconfig.Get().LongRequeueInterval}, nil
 	}
+	// Adopt the Services left behind by a deleted APIScheme with the same DNS name
+	for i := range services {
+		found := &services[i]
+		if found.Labels[apiSchemeLabel] == instance.GetName() {
+			continue
+		}
+		metav1.SetMetaDataLabel(&found.ObjectMeta, apiSchemeLabel, instance.GetName())
+		if err = r.Client.Update(ctx, found); err != nil {
+			reqLogger.Error(err, "Failed to adopt the Service")
+			return reconcile.Result{}, err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonServiceUpdated, cioevents.ActionUpdate, "Adopted Service %s/%s", found.GetNamespace(), found.GetName())
+	}
+	// The scope, the type and the IP families of an existing load balancer can't be changed. The management API is
+	// moved to a new Service instead, named after the generation of the APIScheme. The records keep leading to the
+	// load balancer of the old Service until the new one is ready, they are then published in the zones of the new
+	// scope and removed from the others. Only then is the old Service deleted.
+	var found *corev1.Service
+	for i := len(services) - 1; i >= 0 && found == nil; i-- {
+		if reason, _ := loadBalancerChange(instance, &services[i]); reason == "" {
+			found = &services[i]
+		}
+	}
+	if found == nil {
+		reason, destination := loadBalancerChange(instance, &services[len(services)-1])
+		reqLogger.Info("Moving the management API to another load balancer", "destination", destination)
+		dep := r.newServiceFor(instance, replacementServiceName(instance))
+		if err = r.Client.Create(ctx, dep); err != nil {
+			reqLogger.Error(err, "Failure to create new Service")
+			return reconcile.Result{}, err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonServiceCreated, cioevents.ActionCreate, "Created Service %s/%s to move the management API to %s", dep.GetNamespace(), dep.GetName(), destination)
+		message := "Moving the management API to " + destination
+		r.SetAPISchemeStatus(instance,
+			apiSchemeCondition(cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionFalse, reason, message),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, reason, message),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionFalse, cloudingressv1alpha1.ReasonAsExpected, ""))
+		r.SetAPISchemeStatusMetric(instance)
+		// Give the cloud provider time to create the load balancer
+		return reconcile.Result{Requeue: true, RequeueAfter: operatorconfig.Get().ShortRequeueInterval}, nil
+	}
+	if len(services) > 1 {
+		return r.moveToService(ctx, reqLogger, cloudClient, instance, services, found)
+	}
+
 	// Reconcile the access list in the Service
 	if !sliceEquals(found.Spec.LoadBalancerSourceRanges, instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks) {
 		reqLogger.Info(fmt.Sprintf("Mismatch svc %s != %s\n", found.Spec.LoadBalancerSourceRanges, instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks))
@@ -227,9 +343,10 @@ func (r *APISchemeReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 			reqLogger.Error(err, fmt.Sprintf("Failed to update the %s/service/%s LoadBalancerSourceRanges", found.GetNamespace(), found.GetName()))
 			return reconcile.Result{}, err
 		}
+		cioevents.Normal(ctx, cioevents.ReasonServiceUpdated, cioevents.ActionUpdate, "Updated the LoadBalancerSourceRanges of Service %s/%s", found.GetNamespace(), found.GetName())
 		// let's re-queue just in case
 		reqLogger.Info("Requeuing after svc update")
-		return reconcile.Result{Requeue: true, RequeueAfter: shortwait * time.Second}, nil
+		return reconcile.Result{Requeue: true, RequeueAfter: operatorconfig.Get().ShortRequeueInterval}, nil
 	}
 
 	// Set external traffic policy if it's not properly set
@@ -240,20 +357,48 @@ func (r *APISchemeReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 			reqLogger.Error(err, fmt.Sprintf("Failed to update the %s/service/%s ExternalTrafficPolicy", found.GetNamespace(), found.GetName()))
 			return reconcile.Result{}, err
 		}
+		cioevents.Normal(ctx, cioevents.ReasonServiceUpdated, cioevents.ActionUpdate, "Set the ExternalTrafficPolicy of Service %s/%s to Local", found.GetNamespace(), found.GetName())
 		// let's re-queue just in case
 		reqLogger.Info("Requeuing after svc update")
-		return reconcile.Result{Requeue: true, RequeueAfter: shortwait * time.Second}, nil
+		return reconcile.Result{Requeue: true, RequeueAfter: operatorconfig.Get().ShortRequeueInterval}, nil
+	}
+
+	// The listener port follows the operator configuration, the cloud provider updates the load balancer in place
+	if port := operatorconfig.Get().AdminAPIListenerPort; len(found.Spec.Ports) > 0 && found.Spec.Ports[0].Port != port {
+		found.Spec.Ports[0].Port = port
+		err = r.Client.Update(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, fmt.Sprintf("Failed to update the %s/service/%s port", found.GetNamespace(), found.GetName()))
+			return reconcile.Result{}, err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonServiceUpdated, cioevents.ActionUpdate, "Set the port of Service %s/%s to %d", found.GetNamespace(), found.GetName(), port)
+		reqLogger.Info("Requeuing after svc update")
+		return reconcile.Result{Requeue: true, RequeueAfter: operatorconfig.Get().ShortRequeueInterval}, nil
+	}
+
+	// PreferDualStack and RequireDualStack can be swapped in place
+	if policy := found.Spec.IPFamilyPolicy; apiSchemeDualStack(instance) && (policy == nil || *policy != instance.Spec.ManagementAPIServerIngress.IPFamilyPolicy) {
+		found.Spec.IPFamilyPolicy = ptr.To(instance.Spec.ManagementAPIServerIngress.IPFamilyPolicy)
+		err = r.Client.Update(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, fmt.Sprintf("Failed to update the %s/service/%s IPFamilyPolicy", found.GetNamespace(), found.GetName()))
+			return reconcile.Result{}, err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonServiceUpdated, cioevents.ActionUpdate, "Set the IPFamilyPolicy of Service %s/%s to %s", found.GetNamespace(), found.GetName(), instance.Spec.ManagementAPIServerIngress.IPFamilyPolicy)
 	}
 
-	if !metav1.HasAnnotation(found.ObjectMeta, elbAnnotationIdleTimeoutKey) ||
-		found.Annotations[elbAnnotationIdleTimeoutKey] != elbAnnotationIdleTimeoutValue {
-		metav1.SetMetaDataAnnotation(&found.ObjectMeta, elbAnnotationIdleTimeoutKey, elbAnnotationIdleTimeoutValue)
+	// An NLB has a fixed idle timeout
+	idleTimeout := elbAnnotationIdleTimeoutValue()
+	if apiSchemeLoadBalancerType(instance) == "Classic" && (!metav1.HasAnnotation(found.ObjectMeta, elbAnnotationIdleTimeoutKey) ||
+		found.Annotations[elbAnnotationIdleTimeoutKey] != idleTimeout) {
+		metav1.SetMetaDataAnnotation(&found.ObjectMeta, elbAnnotationIdleTimeoutKey, idleTimeout)
 		err = r.Client.Update(ctx, found)
 		if err != nil {
 			reqLogger.Error(err, "Error updating service annotation")
 			return reconcile.Result{}, err
 		}
-		reqLogger.Info(fmt.Sprintf("Updated %s svc idle timeout to %s", found.Name, elbAnnotationIdleTimeoutValue))
+		reqLogger.Info(fmt.Sprintf("Updated %s svc idle timeout to %s", found.Name, idleTimeout))
+		cioevents.Normal(ctx, cioevents.ReasonServiceUpdated, cioevents.ActionUpdate, "Set the idle timeout of Service %s/%s to %s", found.GetNamespace(), found.GetName(), idleTimeout)
 	}
 
 	// Add the annotation to the svc to make sure the ELB has the tag for owner reference
@@ -266,24 +411,43 @@ func (r *APISchemeReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 			return reconcile.Result{}, err
 		}
 		reqLogger.Info(fmt.Sprintf("Updated %s svc with annotation %s = %s", found.Name, elbAnnotationResourceTagKey, elbAnnotationResourceTagValue))
+		cioevents.Normal(ctx, cioevents.ReasonServiceUpdated, cioevents.ActionUpdate, "Set the resource tags of Service %s/%s to %s", found.GetNamespace(), found.GetName(), elbAnnotationResourceTagValue)
 	}
 
 	err = cloudClient.EnsureAdminAPIDNS(ctx, r.Client, instance, found)
+	if after, ok := retry.RequeueAfter(err); ok {
+		// Throttled or transient, try again from the next reconcile instead of blocking this one
+		reqLogger.Info("Couldn't ensure the admin API endpoint yet, retrying", "requeueAfter", after, "error", err.Error())
+		r.SetAPISchemeStatus(instance,
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonCloudAPIRetrying, "Retrying the admin API endpoint update: "+err.Error()))
+		r.SetAPISchemeStatusMetric(instance)
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
 	// Check for error types that this operator knows about
 	switch err := err.(type) {
 	case nil:
 		// no problems
-		r.SetAPISchemeStatus(instance, "Success", "Admin API Endpoint created", cloudingressv1alpha1.ConditionReady)
+		r.SetAPISchemeStatus(instance,
+			apiSchemeCondition(cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionTrue, cloudingressv1alpha1.ReasonAsExpected, "Load balancer is ready"),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionTrue, cloudingressv1alpha1.ReasonAsExpected, "Admin API Endpoint created"),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionFalse, cloudingressv1alpha1.ReasonAsExpected, ""))
 		r.SetAPISchemeStatusMetric(instance)
-		return reconcile.Result{RequeueAfter: longwait * time.Second}, nil
+		return reconcile.Result{RequeueAfter: operatorconfig.Get().LongRequeueInterval}, nil
 	case *cioerrors.DnsUpdateError:
 		// couldn't update DNS
-		r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Couldn't ensure the admin API endpoint: "+err.Error(), cloudingressv1alpha1.ConditionError)
+		cioevents.Warning(ctx, cioevents.ReasonDNSUpdateFailed, cioevents.ActionUpdate, "Couldn't ensure the admin API endpoint: %v", err)
+		r.SetAPISchemeStatus(instance,
+			apiSchemeCondition(cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionTrue, cloudingressv1alpha1.ReasonAsExpected, "Load balancer is ready"),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonDNSUpdateFailed, "Couldn't ensure the admin API endpoint: "+err.Error()),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionTrue, cloudingressv1alpha1.ReasonDNSUpdateFailed, "Couldn't ensure the admin API endpoint: "+err.Error()))
 		r.SetAPISchemeStatusMetric(instance)
 		return reconcile.Result{}, err
 	case *cioerrors.ForwardingRuleNotFoundError:
 		// This error handles the missing/deleted forwarding rule/LB in cloud provider
-		r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Forwarding rule was deleted on cloud provider", cloudingressv1alpha1.ConditionError)
+		r.SetAPISchemeStatus(instance,
+			apiSchemeCondition(cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonForwardingRuleNotFound, "Forwarding rule was deleted on cloud provider"),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionFalse, cloudingressv1alpha1.ReasonAsExpected, ""))
+		r.SetAPISchemeStatusMetric(instance)
 
 		// To recover from this case we will need to delete the lb service.
 		// It will be recreated  at the next reconcile.
@@ -291,58 +455,203 @@ func (r *APISchemeReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 		deleteSvcErr := r.Client.Delete(ctx, found)
 		if deleteSvcErr != nil {
 			if instance.DeletionTimestamp.IsZero() {
-				reqLogger.Error(err, fmt.Sprintf("Failed to delete the %s/service/%s service. It could already be deleted. Waiting %d seconds to complete possible deletion.", found.GetNamespace(), found.GetName(), longwait))
+				reqLogger.Error(err, fmt.Sprintf("Failed to delete the %s/service/%s service. It could already be deleted. Waiting %s to complete possible deletion.", found.GetNamespace(), found.GetName(), operatorconfig.Get().LongRequeueInterval))
 			} else {
-				reqLogger.Error(err, fmt.Sprintf("Service %s/service/%s already deleted. Waiting %d seconds to complete deletion.", found.GetNamespace(), found.GetName(), longwait))
+				reqLogger.Error(err, fmt.Sprintf("Service %s/service/%s already deleted. Waiting %s to complete deletion.", found.GetNamespace(), found.GetName(), operatorconfig.Get().LongRequeueInterval))
 			}
+		} else {
+			cioevents.Normal(ctx, cioevents.ReasonServiceDeleted, cioevents.ActionDelete, "Deleted Service %s/%s to recreate the forwarding rule deleted on the cloud provider", found.GetNamespace(), found.GetName())
 		}
 		// Need to wait till deletion is completely finished to avoid race condition.
-		return reconcile.Result{Requeue: true, RequeueAfter: longwait * time.Second}, nil
+		return reconcile.Result{Requeue: true, RequeueAfter: operatorconfig.Get().LongRequeueInterval}, nil
 	case *cioerrors.LoadBalancerNotReadyError:
-		r.SetAPISchemeStatusMetric(instance)
-		if localctlutils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionReady) == nil {
-			// The APIscheme was never ready. The Load Balancer is likely still creating
-			r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Load balancer isn't ready", cloudingressv1alpha1.ConditionError)
+		if !meta.IsStatusConditionTrue(instance.Status.Conditions, string(cloudingressv1alpha1.ConditionLoadBalancerReady)) {
+			// The load balancer wasn't ready at the last reconcile. It is likely still creating
+			r.SetAPISchemeStatus(instance,
+				apiSchemeCondition(cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonLoadBalancerNotReady, "Load balancer isn't ready"),
+				apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionFalse, cloudingressv1alpha1.ReasonAsExpected, ""))
 			reqLogger.Info("LoadBalancer isn't ready yet")
 		} else {
 			// The APIScheme had been ready previously. The Load Balancer has likely been deleted
-			r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Load balancer was deleted", cloudingressv1alpha1.ConditionError)
+			r.SetAPISchemeStatus(instance,
+				apiSchemeCondition(cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonLoadBalancerDeleted, "Load balancer was deleted"),
+				apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionFalse, cloudingressv1alpha1.ReasonAsExpected, ""))
 
 			// To recover from this case we will need to delete the service. It will be recreated  at the next reconcile
 			reqLogger.Info(fmt.Sprintf("LoadBalancer was deleted, deleting service %s/service/%s to recover", found.GetNamespace(), found.GetName()))
 			err := r.Client.Delete(ctx, found)
 			if err != nil {
 				reqLogger.Error(err, fmt.Sprintf("Failed to delete the %s/service/%s service, it could already be deleted. Waiting to complete possible deletion.", found.GetNamespace(), found.GetName()))
+			} else {
+				cioevents.Normal(ctx, cioevents.ReasonServiceDeleted, cioevents.ActionDelete, "Deleted Service %s/%s to recreate the load balancer deleted on the cloud provider", found.GetNamespace(), found.GetName())
 			}
 		}
+		r.SetAPISchemeStatusMetric(instance)
 
-		return reconcile.Result{Requeue: true, RequeueAfter: longwait * time.Second}, nil
+		return reconcile.Result{Requeue: true, RequeueAfter: operatorconfig.Get().LongRequeueInterval}, nil
 	default:
 		// not one of ours
 		log.Error(err, "Error ensuring Admin API", "instance", instance, "Service", found)
+		r.SetAPISchemeStatus(instance,
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionTrue, cloudingressv1alpha1.ReasonReconcileFailed, "Error ensuring Admin API: "+err.Error()))
+		r.SetAPISchemeStatusMetric(instance)
 		return reconcile.Result{}, err
 	}
 }
 
-func (r *APISchemeReconciler) newServiceFor(instance *cloudingressv1alpha1.APIScheme) *corev1.Service {
+// moveToService moves the records of the management API to the load balancer of target, the Service it is moving
+// to, once it is ready. The other Services are deleted afterwards, their load balancers keep serving the management
+// API until then.
+func (r *APISchemeReconciler) moveToService(ctx context.Context, reqLogger logr.Logger, cloudClient cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme, services []corev1.Service, target *corev1.Service) (reconcile.Result, error) {
+	var others []*corev1.Service
+	for i := range services {
+		if services[i].Name != target.Name {
+			others = append(others, &services[i])
+		}
+	}
+	reason, destination := loadBalancerChange(instance, others[0])
+	if reason == "" {
+		// Left by an earlier move
+		reason, destination = cloudingressv1alpha1.ReasonLoadBalancerTypeChanging, fmt.Sprintf("a %s load balancer", apiSchemeLoadBalancerType(instance))
+	}
+	message := "Moving the management API to " + destination
+
+	err := cloudClient.EnsureAdminAPIDNS(ctx, r.Client, instance, target)
+	if after, ok := retry.RequeueAfter(err); ok {
+		reqLogger.Info("Couldn't move the admin API endpoint yet, retrying", "requeueAfter", after, "error", err.Error())
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+	switch err := err.(type) {
+	case nil:
+		// the records lead to the new load balancer
+	case *cioerrors.LoadBalancerNotReadyError, *cioerrors.ForwardingRuleNotFoundError:
+		reqLogger.Info("Waiting for the new load balancer", "service", target.GetName())
+		r.SetAPISchemeStatus(instance,
+			apiSchemeCondition(cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionFalse, reason, message),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, reason, message),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionFalse, cloudingressv1alpha1.ReasonAsExpected, ""))
+		r.SetAPISchemeStatusMetric(instance)
+		return reconcile.Result{Requeue: true, RequeueAfter: operatorconfig.Get().ShortRequeueInterval}, nil
+	default:
+		reqLogger.Error(err, "Failed to move the admin API endpoint")
+		cioevents.Warning(ctx, cioevents.ReasonDNSUpdateFailed, cioevents.ActionUpdate, "Couldn't move the admin API endpoint to Service %s/%s: %v", target.GetNamespace(), target.GetName(), err)
+		r.SetAPISchemeStatus(instance,
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonDNSUpdateFailed, "Couldn't move the admin API endpoint: "+err.Error()),
+			apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionTrue, cloudingressv1alpha1.ReasonDNSUpdateFailed, "Couldn't move the admin API endpoint: "+err.Error()))
+		r.SetAPISchemeStatusMetric(instance)
+		return reconcile.Result{}, err
+	}
+
+	for _, svc := range others {
+		if err := r.Client.Delete(ctx, svc); err != nil && !errors.IsNotFound(err) {
+			reqLogger.Error(err, "Failed to delete the Service")
+			return reconcile.Result{}, err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonServiceDeleted, cioevents.ActionDelete, "Deleted Service %s/%s, the management API moved to %s", svc.GetNamespace(), svc.GetName(), destination)
+	}
+	return reconcile.Result{Requeue: true, RequeueAfter: operatorconfig.Get().ShortRequeueInterval}, nil
+}
+
+// replacementServiceName names the Service the management API moves to when its load balancer is replaced. The
+// generation of the APIScheme keeps it apart from the Service it replaces, and from those of earlier changes.
+func replacementServiceName(instance *cloudingressv1alpha1.APIScheme) string {
+	suffix := "-" + strconv.FormatInt(instance.Generation, 10)
+	name := instance.Spec.ManagementAPIServerIngress.DNSName
+	if len(name)+len(suffix) > validation.DNS1035LabelMaxLength {
+		name = strings.TrimRight(name[:validation.DNS1035LabelMaxLength-len(suffix)], "-")
+	}
+	return name + suffix
+}
+
+// dnsNameConflict returns why the APIScheme can't use its DNS name, empty when it holds it. The name is taken
+// when it is reserved for the cluster API, used by an older APIScheme, or when one of services, the Services of the
+// name, is managed by another APIScheme or by something else. The Services of a deleted APIScheme can be adopted.
+func (r *APISchemeReconciler) dnsNameConflict(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, services []corev1.Service) (string, error) {
+	dnsName := instance.Spec.ManagementAPIServerIngress.DNSName
+	if slices.Contains(baseutils.ReservedAdminAPINames, dnsName) {
+		return fmt.Sprintf("%s is the DNS name of the cluster API", dnsName), nil
+	}
+	other, err := baseutils.ConflictingAPIScheme(ctx, r.Client, instance)
+	if err != nil {
+		return "", err
+	}
+	if other != nil {
+		return fmt.Sprintf("%s is already used by APIScheme %s/%s", dnsName, other.GetNamespace(), other.GetName()), nil
+	}
+	for _, svc := range services {
+		owner, ok := svc.Labels[apiSchemeLabel]
+		if !ok {
+			return fmt.Sprintf("Service %s/%s already exists and isn't managed by an APIScheme", svc.GetNamespace(), svc.GetName()), nil
+		}
+		if owner == instance.GetName() {
+			continue
+		}
+		err = r.Client.Get(ctx, types.NamespacedName{Name: owner, Namespace: instance.GetNamespace()}, &cloudingressv1alpha1.APIScheme{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Service %s/%s is managed by APIScheme %s", svc.GetNamespace(), svc.GetName(), owner), nil
+	}
+	return "", nil
+}
+
+// reportDNSNameConflict marks the APIScheme as degraded and checks again later, the name may be released.
+// The conflict is recorded as an Event once.
+func (r *APISchemeReconciler) reportDNSNameConflict(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, conflict string) reconcile.Result {
+	degraded := meta.FindStatusCondition(instance.Status.Conditions, string(cloudingressv1alpha1.ConditionDegraded))
+	if degraded == nil || degraded.Reason != cloudingressv1alpha1.ReasonDNSNameConflict || degraded.Message != conflict {
+		cioevents.Warning(ctx, cioevents.ReasonDNSNameConflict, cioevents.ActionCheck, "Not creating the management API endpoint, %s", conflict)
+	}
+	r.SetAPISchemeStatus(instance,
+		apiSchemeCondition(cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonDNSNameConflict, conflict),
+		apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonDNSNameConflict, conflict),
+		apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionTrue, cloudingressv1alpha1.ReasonDNSNameConflict, conflict))
+	r.SetAPISchemeStatusMetric(instance)
+	return reconcile.Result{RequeueAfter: operatorconfig.Get().LongRequeueInterval}
+}
+
+// elbAnnotationIdleTimeoutValue is the idle timeout of the admin API load balancer, in seconds
+func elbAnnotationIdleTimeoutValue() string {
+	return strconv.Itoa(int(operatorconfig.Get().ELBIdleTimeoutSeconds))
+}
+
+// newServiceFor returns the Service of the management API, named name
+func (r *APISchemeReconciler) newServiceFor(instance *cloudingressv1alpha1.APIScheme, name string) *corev1.Service {
 	labels := map[string]string{
-		"app":          "cloud-ingress-operator-" + instance.Spec.ManagementAPIServerIngress.DNSName,
-		"apischeme_cr": instance.GetName(),
+		"app":          baseutils.AdminAPIServiceAppLabel(instance.Spec.ManagementAPIServerIngress.DNSName),
+		apiSchemeLabel: instance.GetName(),
 	}
 	selector := map[string]string{
 		"apiserver": "true",
 		"app":       "openshift-kube-apiserver",
 	}
 	annotations := map[string]string{
-		elbAnnotationIdleTimeoutKey: elbAnnotationIdleTimeoutValue,
 		elbAnnotationResourceTagKey: elbAnnotationResourceTagValue,
 	}
+	if apiSchemeLoadBalancerType(instance) == "NLB" {
+		annotations[elbAnnotationTypeKey] = elbAnnotationTypeValue
+	} else {
+		annotations[elbAnnotationIdleTimeoutKey] = elbAnnotationIdleTimeoutValue()
+	}
+	var ipFamilyPolicy *corev1.IPFamilyPolicy
+	var ipFamilies []corev1.IPFamily
+	if apiSchemeDualStack(instance) {
+		annotations[elbAnnotationIPAddressTypeKey] = elbAnnotationIPAddressTypeValue
+		ipFamilyPolicy = ptr.To(instance.Spec.ManagementAPIServerIngress.IPFamilyPolicy)
+		ipFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+	}
+	if apiSchemeListening(instance) == cloudingressv1alpha1.Internal {
+		maps.Copy(annotations, internalLoadBalancerAnnotations)
+	}
 	// Note: This owner reference should nbnot be expected to work
 	//ref := metav1.NewControllerRef(instance, instance.GetObjectKind().GroupVersionKind())
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        instance.Spec.ManagementAPIServerIngress.DNSName,
-			Namespace:   "openshift-kube-apiserver",
+			Name:        name,
+			Namespace:   baseutils.AdminAPIServiceNamespace,
 			Labels:      labels,
 			Annotations: annotations,
 			//OwnerReferences: []metav1.OwnerReference{*ref},
@@ -351,7 +660,7 @@ func (r *APISchemeReconciler) newServiceFor(instance *cloudingressv1alpha1.APISc
 			Ports: []corev1.ServicePort{
 				{
 					Protocol:   "TCP",
-					Port:       6443,
+					Port:       operatorconfig.Get().AdminAPIListenerPort,
 					TargetPort: intstr.FromInt(6443),
 				},
 			},
@@ -359,20 +668,88 @@ func (r *APISchemeReconciler) newServiceFor(instance *cloudingressv1alpha1.APISc
 			Type:                     corev1.ServiceTypeLoadBalancer,
 			LoadBalancerSourceRanges: instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks,
 			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyTypeLocal,
+			IPFamilyPolicy:           ipFamilyPolicy,
+			IPFamilies:               ipFamilies,
 		},
 	}
 }
 
-// SetAPISchemeStatus will set the status on the APISscheme object with a human message, as in an error situation
-func (r *APISchemeReconciler) SetAPISchemeStatus(crObject *cloudingressv1alpha1.APIScheme, reason, message string, ctype cloudingressv1alpha1.APISchemeConditionType) {
-	crObject.Status.Conditions = localctlutils.SetAPISchemeCondition(
-		crObject.Status.Conditions,
-		ctype,
-		corev1.ConditionTrue,
-		reason,
-		message,
-		localctlutils.UpdateConditionIfReasonOrMessageChange)
-	crObject.Status.State = ctype
+// apiSchemeListening returns the scope of the management API, external when it isn't set
+func apiSchemeListening(instance *cloudingressv1alpha1.APIScheme) cloudingressv1alpha1.Listening {
+	if instance.Spec.ManagementAPIServerIngress.Listening == cloudingressv1alpha1.Internal {
+		return cloudingressv1alpha1.Internal
+	}
+	return cloudingressv1alpha1.External
+}
+
+// serviceListening returns the scope of the load balancer of the Service
+func serviceListening(svc *corev1.Service) cloudingressv1alpha1.Listening {
+	for key, value := range internalLoadBalancerAnnotations {
+		if strings.EqualFold(svc.Annotations[key], value) {
+			return cloudingressv1alpha1.Internal
+		}
+	}
+	return cloudingressv1alpha1.External
+}
+
+// apiSchemeLoadBalancerType returns the type of the AWS load balancer of the management API, Classic when it isn't set
+func apiSchemeLoadBalancerType(instance *cloudingressv1alpha1.APIScheme) cloudingressv1alpha1.Type {
+	if instance.Spec.ManagementAPIServerIngress.Type == "NLB" {
+		return "NLB"
+	}
+	return "Classic"
+}
+
+// serviceLoadBalancerType returns the type of the AWS load balancer of the Service
+func serviceLoadBalancerType(svc *corev1.Service) cloudingressv1alpha1.Type {
+	if strings.EqualFold(svc.Annotations[elbAnnotationTypeKey], elbAnnotationTypeValue) {
+		return "NLB"
+	}
+	return "Classic"
+}
+
+// apiSchemeDualStack returns whether the management API has IPv4 and IPv6 addresses
+func apiSchemeDualStack(instance *cloudingressv1alpha1.APIScheme) bool {
+	policy := instance.Spec.ManagementAPIServerIngress.IPFamilyPolicy
+	return policy == corev1.IPFamilyPolicyPreferDualStack || policy == corev1.IPFamilyPolicyRequireDualStack
+}
+
+// serviceDualStack returns whether the Service asks for IPv4 and IPv6 addresses
+func serviceDualStack(svc *corev1.Service) bool {
+	return svc.Spec.IPFamilyPolicy != nil && *svc.Spec.IPFamilyPolicy != corev1.IPFamilyPolicySingleStack
+}
+
+// loadBalancerChange returns the reason the Service has to be recreated and where the management API moves to,
+// an empty reason when it already has the scope, the type and the IP families of the APIScheme
+func loadBalancerChange(instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (string, string) {
+	if listening := apiSchemeListening(instance); serviceListening(svc) != listening {
+		return cloudingressv1alpha1.ReasonScopeChanging, fmt.Sprintf("the %s scope", listening)
+	}
+	if lbType := apiSchemeLoadBalancerType(instance); serviceLoadBalancerType(svc) != lbType {
+		return cloudingressv1alpha1.ReasonLoadBalancerTypeChanging, fmt.Sprintf("a %s load balancer", lbType)
+	}
+	if dualStack := apiSchemeDualStack(instance); serviceDualStack(svc) != dualStack {
+		if dualStack {
+			return cloudingressv1alpha1.ReasonIPFamilyPolicyChanging, "a dual-stack load balancer"
+		}
+		return cloudingressv1alpha1.ReasonIPFamilyPolicyChanging, "a single-stack load balancer"
+	}
+	return "", ""
+}
+
+// SetAPISchemeStatus will set the given conditions on the APISscheme object, derive the Ready condition and
+// the state from them, then update the status
+func (r *APISchemeReconciler) SetAPISchemeStatus(crObject *cloudingressv1alpha1.APIScheme, conditions ...metav1.Condition) {
+	// Objects written by older versions of the operator hold a list of transitions instead of keyed conditions
+	localctlutils.MigrateAPISchemeConditions(&crObject.Status)
+
+	for _, condition := range conditions {
+		localctlutils.SetAPISchemeCondition(&crObject.Status, crObject.Generation,
+			cloudingressv1alpha1.APISchemeConditionType(condition.Type), condition.Status, condition.Reason, condition.Message)
+	}
+	setAPISchemeReady(crObject)
+	crObject.Status.ObservedGeneration = crObject.Generation
+
 	err := r.Client.Status().Update(context.TODO(), crObject)
 	// TODO: Should we return an error here if this update fails?
 	if err != nil {
@@ -380,13 +757,49 @@ func (r *APISchemeReconciler) SetAPISchemeStatus(crObject *cloudingressv1alpha1.
 	}
 }
 
-// SetAPISchemeStatusMetric updates a gauge in localmetrics
-func (r *APISchemeReconciler) SetAPISchemeStatusMetric(crObject *cloudingressv1alpha1.APIScheme) {
-	if crObject.Status.State == "Ready" {
-		localmetrics.MetricAPISchemeConditionStatus.Set(float64(1))
-		return
+// setAPISchemeReady sets the Ready condition, the APIScheme is ready when it isn't degraded and
+// both the load balancer and the DNS record are ready
+func setAPISchemeReady(crObject *cloudingressv1alpha1.APIScheme) {
+	ready := apiSchemeCondition(cloudingressv1alpha1.ConditionReady, metav1.ConditionTrue, cloudingressv1alpha1.ReasonAsExpected, "Admin API Endpoint created")
+
+	degraded := meta.FindStatusCondition(crObject.Status.Conditions, string(cloudingressv1alpha1.ConditionDegraded))
+	loadBalancerReady := meta.FindStatusCondition(crObject.Status.Conditions, string(cloudingressv1alpha1.ConditionLoadBalancerReady))
+	dnsReady := meta.FindStatusCondition(crObject.Status.Conditions, string(cloudingressv1alpha1.ConditionDNSReady))
+	switch {
+	case degraded != nil && degraded.Status == metav1.ConditionTrue:
+		ready = apiSchemeCondition(cloudingressv1alpha1.ConditionReady, metav1.ConditionFalse, degraded.Reason, degraded.Message)
+	case loadBalancerReady == nil:
+		ready = apiSchemeCondition(cloudingressv1alpha1.ConditionReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonLoadBalancerNotReady, "Load balancer isn't ready")
+	case loadBalancerReady.Status != metav1.ConditionTrue:
+		ready = apiSchemeCondition(cloudingressv1alpha1.ConditionReady, metav1.ConditionFalse, loadBalancerReady.Reason, loadBalancerReady.Message)
+	case dnsReady == nil || dnsReady.Status != metav1.ConditionTrue:
+		ready = apiSchemeCondition(cloudingressv1alpha1.ConditionReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonDNSUpdateFailed, "Admin API Endpoint isn't created")
+		if dnsReady != nil {
+			ready.Reason, ready.Message = dnsReady.Reason, dnsReady.Message
+		}
 	}
-	localmetrics.MetricAPISchemeConditionStatus.Set(float64(0))
+	localctlutils.SetAPISchemeCondition(&crObject.Status, crObject.Generation,
+		cloudingressv1alpha1.ConditionReady, ready.Status, ready.Reason, ready.Message)
+
+	// State is kept for the consumers of the previous status format
+	crObject.Status.State = cloudingressv1alpha1.ConditionError
+	if ready.Status == metav1.ConditionTrue {
+		crObject.Status.State = cloudingressv1alpha1.ConditionReady
+	}
+}
+
+func apiSchemeCondition(ctype cloudingressv1alpha1.APISchemeConditionType, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    string(ctype),
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// SetAPISchemeStatusMetric updates the gauge of the APIScheme in localmetrics
+func (r *APISchemeReconciler) SetAPISchemeStatusMetric(crObject *cloudingressv1alpha1.APIScheme) {
+	localmetrics.SetAPISchemeReady(crObject.GetName(), crObject.Status.State == "Ready")
 }
 
 func sliceEquals(left, right []string) bool {
@@ -1,13 +1,34 @@
 package apischeme
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/mock_cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/retry"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
 	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	"go.uber.org/mock/gomock"
 
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func TestClusterBaseDomain(t *testing.T) {
@@ -29,3 +50,619 @@ func TestClusterBaseDomain(t *testing.T) {
 		t.Fatalf("Base domain mismatch. Expected %s, got %s", "unit.test", base)
 	}
 }
+
+func TestSetAPISchemeStatus(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	aObj.Generation = 4
+	// Status as written by previous versions of the operator
+	aObj.Status.Conditions = []metav1.Condition{
+		{Type: string(cloudingressv1alpha1.ConditionError), Status: metav1.ConditionTrue, Reason: "Couldn't reconcile", Message: "Load balancer isn't ready"},
+		{Type: string(cloudingressv1alpha1.ConditionReady), Status: metav1.ConditionTrue, Reason: "Success", Message: "Admin API Endpoint created"},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	kclient := fake.NewClientBuilder().WithScheme(mocks.Scheme).WithObjects(aObj).WithStatusSubresource(aObj).Build()
+	r := &APISchemeReconciler{Client: kclient, Scheme: mocks.Scheme}
+
+	r.SetAPISchemeStatus(aObj,
+		apiSchemeCondition(cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionTrue, cloudingressv1alpha1.ReasonAsExpected, "Load balancer is ready"),
+		apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonDNSUpdateFailed, "Couldn't ensure the admin API endpoint"),
+		apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionTrue, cloudingressv1alpha1.ReasonDNSUpdateFailed, "Couldn't ensure the admin API endpoint"))
+
+	updated := &cloudingressv1alpha1.APIScheme{}
+	if err := kclient.Get(context.TODO(), types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}, updated); err != nil {
+		t.Fatalf("Couldn't get the APIScheme: %v", err)
+	}
+	if updated.Status.ObservedGeneration != 4 {
+		t.Fatalf("Expected observedGeneration 4, got %d", updated.Status.ObservedGeneration)
+	}
+	if len(updated.Status.Conditions) != 4 {
+		t.Fatalf("Expected 4 keyed conditions, got %+v", updated.Status.Conditions)
+	}
+	ready := meta.FindStatusCondition(updated.Status.Conditions, string(cloudingressv1alpha1.ConditionReady))
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != cloudingressv1alpha1.ReasonDNSUpdateFailed {
+		t.Fatalf("Expected Ready to be False because of the DNS update, got %+v", ready)
+	}
+	if updated.Status.State != cloudingressv1alpha1.ConditionError {
+		t.Fatalf("Expected state %v, got %v", cloudingressv1alpha1.ConditionError, updated.Status.State)
+	}
+	// The 2 legacy conditions, followed by the 4 new transitions
+	if len(updated.Status.History) != 6 || updated.Status.History[0].Reason != "Couldn't reconcile" {
+		t.Fatalf("Expected the legacy conditions to be kept in the history, got %+v", updated.Status.History)
+	}
+
+	r.SetAPISchemeStatus(updated,
+		apiSchemeCondition(cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionTrue, cloudingressv1alpha1.ReasonAsExpected, "Admin API Endpoint created"),
+		apiSchemeCondition(cloudingressv1alpha1.ConditionDegraded, metav1.ConditionFalse, cloudingressv1alpha1.ReasonAsExpected, ""))
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, string(cloudingressv1alpha1.ConditionReady)) || updated.Status.State != cloudingressv1alpha1.ConditionReady {
+		t.Fatalf("Expected the APIScheme to be ready, got %+v", updated.Status)
+	}
+}
+
+func TestReconcileDeletionWithoutService(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	aObj.Finalizers = []string{reconcileFinalizerDNS}
+	now := metav1.Now()
+	aObj.DeletionTimestamp = &now
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj})
+	mockCloudClient := mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	// The Service is gone, so the records have to be found without it
+	mockCloudClient.EXPECT().DeleteAdminAPIDNSWithoutService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	cloudClient = mockCloudClient
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	updated := &cloudingressv1alpha1.APIScheme{}
+	err = mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}, updated)
+	if err == nil && controllerutil.ContainsFinalizer(updated, reconcileFinalizerDNS) {
+		t.Fatalf("Expected the DNS finalizer to be removed")
+	}
+}
+
+func TestReconcileDeletionRetriesThrottledDNS(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	aObj.Finalizers = []string{reconcileFinalizerDNS}
+	now := metav1.Now()
+	aObj.DeletionTimestamp = &now
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj})
+	mockCloudClient := mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	throttled := &retry.Error{Operation: "route53:DeleteRecord", Class: retry.Throttled, Attempt: 1, After: 5 * time.Second, Err: fmt.Errorf("Throttling")}
+	mockCloudClient.EXPECT().DeleteAdminAPIDNSWithoutService(gomock.Any(), gomock.Any(), gomock.Any()).Return(throttled).Times(1)
+	cloudClient = mockCloudClient
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if result.RequeueAfter != 5*time.Second {
+		t.Fatalf("Expected the deletion to be retried after 5s, got %+v", result)
+	}
+
+	updated := &cloudingressv1alpha1.APIScheme{}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}, updated); err != nil {
+		t.Fatalf("Couldn't get the APIScheme: %v", err)
+	}
+	if !controllerutil.ContainsFinalizer(updated, reconcileFinalizerDNS) {
+		t.Fatalf("Expected the DNS finalizer to be kept until the records are deleted")
+	}
+}
+
+func TestReconcileRecordsServiceCreation(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj})
+	cloudClient = mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	defer func() { cloudClient = nil }()
+
+	recorder := events.NewFakeRecorder(10)
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme, Recorder: recorder}
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		expected := "Normal ServiceCreated Created Service openshift-kube-apiserver/rh-api"
+		if event != expected {
+			t.Fatalf("Expected event %q, got %q", expected, event)
+		}
+	default:
+		t.Fatalf("Expected an event for the Service creation")
+	}
+}
+
+func TestReconcileDNSNameConflict(t *testing.T) {
+	older := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	older.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"10.0.0.0/16"})
+	aObj.Name = "partner"
+	aObj.CreationTimestamp = metav1.NewTime(time.Now().Truncate(time.Second))
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	kclient := fake.NewClientBuilder().WithScheme(mocks.Scheme).WithObjects(older, aObj).WithStatusSubresource(aObj).Build()
+	// No cloud call is expected
+	cloudClient = mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	defer func() { cloudClient = nil }()
+
+	recorder := events.NewFakeRecorder(10)
+	r := &APISchemeReconciler{Client: kclient, Scheme: mocks.Scheme, Recorder: recorder}
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Fatalf("Expected the conflict to be checked again later, got %+v", result)
+	}
+
+	updated := &cloudingressv1alpha1.APIScheme{}
+	if err := kclient.Get(context.TODO(), types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}, updated); err != nil {
+		t.Fatalf("Couldn't get the APIScheme: %v", err)
+	}
+	degraded := meta.FindStatusCondition(updated.Status.Conditions, string(cloudingressv1alpha1.ConditionDegraded))
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != cloudingressv1alpha1.ReasonDNSNameConflict {
+		t.Fatalf("Expected the APIScheme to be degraded by the conflict, got %+v", degraded)
+	}
+	svcs := &corev1.ServiceList{}
+	if err := kclient.List(context.TODO(), svcs); err != nil || len(svcs.Items) != 0 {
+		t.Fatalf("Expected no Service to be created, got %v %v", svcs.Items, err)
+	}
+	select {
+	case event := <-recorder.Events:
+		expected := "Warning DNSNameConflict Not creating the management API endpoint, rh-api is already used by APIScheme openshift-cloud-ingress-operator/rh-api"
+		if event != expected {
+			t.Fatalf("Expected event %q, got %q", expected, event)
+		}
+	default:
+		t.Fatalf("Expected an event for the conflict")
+	}
+
+	// The conflict is only reported once
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	select {
+	case event := <-recorder.Events:
+		t.Fatalf("Expected no other event, got %q", event)
+	default:
+	}
+}
+
+func TestReconcileDeletionDeletesService(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("partner-api", true, []string{"0.0.0.0/0"})
+	aObj.Name = "partner"
+	aObj.Finalizers = []string{reconcileFinalizerDNS}
+	now := metav1.Now()
+	aObj.DeletionTimestamp = &now
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "partner-api", Namespace: "openshift-kube-apiserver", Labels: map[string]string{apiSchemeLabel: "partner"}}}
+	// Another management API, which must be left alone
+	other := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	otherSvc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver", Labels: map[string]string{apiSchemeLabel: "rh-api"}}}
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj, svc, other, otherSvc})
+	mockCloudClient := mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	mockCloudClient.EXPECT().DeleteAdminAPIDNS(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	cloudClient = mockCloudClient
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, &corev1.Service{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected the Service to be deleted, got %v", err)
+	}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: otherSvc.Name, Namespace: otherSvc.Namespace}, &corev1.Service{}); err != nil {
+		t.Fatalf("Expected the Service of the other APIScheme to be kept, got %v", err)
+	}
+}
+
+func TestReconcileDeletionWithoutLoadBalancer(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	aObj.Finalizers = []string{reconcileFinalizerDNS}
+	now := metav1.Now()
+	aObj.DeletionTimestamp = &now
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver", Labels: map[string]string{apiSchemeLabel: "rh-api"}}}
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj, svc})
+	mockCloudClient := mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	// The Service is there but its load balancer is gone
+	gomock.InOrder(
+		mockCloudClient.EXPECT().DeleteAdminAPIDNS(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cioerrors.NewLoadBalancerNotReadyError()).Times(1),
+		mockCloudClient.EXPECT().DeleteAdminAPIDNSWithoutService(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1),
+	)
+	cloudClient = mockCloudClient
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, &corev1.Service{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected the Service to be deleted, got %v", err)
+	}
+	found := &cloudingressv1alpha1.APIScheme{}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}, found); err == nil && controllerutil.ContainsFinalizer(found, reconcileFinalizerDNS) {
+		t.Fatalf("Expected the finalizer to be removed")
+	}
+}
+
+func TestReconcileDeletionHandsDNSNameOver(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	aObj.Finalizers = []string{reconcileFinalizerDNS}
+	now := metav1.Now()
+	aObj.DeletionTimestamp = &now
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver", Labels: map[string]string{apiSchemeLabel: "rh-api"}}}
+	// Waiting for the DNS name, it takes the records and the Service over
+	waiting := testutils.CreateAPISchemeObject("rh-api", true, []string{"10.0.0.0/16"})
+	waiting.Name = "partner"
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj, svc, waiting})
+	// No cloud call is expected
+	cloudClient = mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, &corev1.Service{}); err != nil {
+		t.Fatalf("Expected the Service to be kept, got %v", err)
+	}
+	updated := &cloudingressv1alpha1.APIScheme{}
+	err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}, updated)
+	if err == nil && controllerutil.ContainsFinalizer(updated, reconcileFinalizerDNS) {
+		t.Fatalf("Expected the DNS finalizer to be removed")
+	}
+}
+
+func TestReconcileInternalService(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"10.0.0.0/16"})
+	aObj.Spec.ManagementAPIServerIngress.Listening = cloudingressv1alpha1.Internal
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj})
+	cloudClient = mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	svc := &corev1.Service{}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: "rh-api", Namespace: "openshift-kube-apiserver"}, svc); err != nil {
+		t.Fatalf("Couldn't get the Service: %v", err)
+	}
+	for key, value := range internalLoadBalancerAnnotations {
+		if svc.Annotations[key] != value {
+			t.Errorf("Expected the annotation %s=%s, got %q", key, value, svc.Annotations[key])
+		}
+	}
+}
+
+func TestReconcileScopeChange(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"10.0.0.0/16"})
+	aObj.Spec.ManagementAPIServerIngress.Listening = cloudingressv1alpha1.Internal
+	aObj.Generation = 2
+	// The Service of the external management API
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver", Labels: map[string]string{apiSchemeLabel: aObj.Name}}}
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	kclient := fake.NewClientBuilder().WithScheme(mocks.Scheme).WithObjects(aObj, svc).WithStatusSubresource(aObj).Build()
+	// records are the Services the records of the management API lead to, by zone
+	records := map[string]string{"private": "rh-api", "public": "rh-api"}
+	mockCloudClient := mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	// The records are only moved once the internal load balancer is ready, the public one is removed then
+	isNewService := gomock.Cond(func(x any) bool { return x.(*corev1.Service).Name == "rh-api-2" })
+	gomock.InOrder(
+		mockCloudClient.EXPECT().EnsureAdminAPIDNS(gomock.Any(), gomock.Any(), gomock.Any(), isNewService).Return(cioerrors.NewLoadBalancerNotReadyError()).Times(1),
+		mockCloudClient.EXPECT().EnsureAdminAPIDNS(gomock.Any(), gomock.Any(), gomock.Any(), isNewService).DoAndReturn(
+			func(_ context.Context, _ client.Client, _ *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+				records["private"] = svc.Name
+				delete(records, "public")
+				return nil
+			}).Times(1),
+	)
+	cloudClient = mockCloudClient
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: kclient, Scheme: mocks.Scheme}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}
+	// The private record must lead to a Service at every step
+	expectResolvable := func(step string) {
+		if _, ok := records["private"]; !ok {
+			t.Fatalf("Expected the private record to be kept %s", step)
+		}
+		for zone, name := range records {
+			if err := kclient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: svc.Namespace}, &corev1.Service{}); err != nil {
+				t.Fatalf("Expected the %s record to lead to an existing Service %s, got %v", zone, step, err)
+			}
+		}
+	}
+
+	// The Service of the internal load balancer is created next to the external one
+	result, err := r.Reconcile(context.TODO(), request)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Fatalf("Expected the internal load balancer to be waited for, got %+v", result)
+	}
+	expectResolvable("once the internal load balancer is created")
+	updated := &corev1.Service{}
+	if err := kclient.Get(context.TODO(), types.NamespacedName{Name: "rh-api-2", Namespace: svc.Namespace}, updated); err != nil {
+		t.Fatalf("Couldn't get the new Service: %v", err)
+	}
+	if serviceListening(updated) != cloudingressv1alpha1.Internal {
+		t.Errorf("Expected the new Service to ask for an internal load balancer, got %v", updated.Annotations)
+	}
+	instance := &cloudingressv1alpha1.APIScheme{}
+	if err := kclient.Get(context.TODO(), request.NamespacedName, instance); err != nil {
+		t.Fatalf("Couldn't get the APIScheme: %v", err)
+	}
+	dnsReady := meta.FindStatusCondition(instance.Status.Conditions, string(cloudingressv1alpha1.ConditionDNSReady))
+	if dnsReady == nil || dnsReady.Status != metav1.ConditionFalse || dnsReady.Reason != cloudingressv1alpha1.ReasonScopeChanging {
+		t.Fatalf("Expected the DNS not to be ready while the scope changes, got %+v", dnsReady)
+	}
+
+	// The external load balancer keeps serving until the internal one is ready
+	if _, err := r.Reconcile(context.TODO(), request); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expectResolvable("while the internal load balancer isn't ready")
+
+	// Then the records are moved and the external load balancer deleted
+	if _, err := r.Reconcile(context.TODO(), request); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expectResolvable("once the records are moved")
+	if err := kclient.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, &corev1.Service{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected the Service of the external load balancer to be deleted, got %v", err)
+	}
+	if _, ok := records["public"]; ok {
+		t.Errorf("Expected the public record to be removed, got %v", records)
+	}
+}
+
+func TestReconcileNLBService(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	aObj.Spec.ManagementAPIServerIngress.Type = "NLB"
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj})
+	cloudClient = mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	svc := &corev1.Service{}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: "rh-api", Namespace: "openshift-kube-apiserver"}, svc); err != nil {
+		t.Fatalf("Couldn't get the Service: %v", err)
+	}
+	if svc.Annotations[elbAnnotationTypeKey] != elbAnnotationTypeValue {
+		t.Errorf("Expected the Service to ask for an NLB, got %v", svc.Annotations)
+	}
+	if metav1.HasAnnotation(svc.ObjectMeta, elbAnnotationIdleTimeoutKey) {
+		t.Errorf("Expected no idle timeout on an NLB, got %v", svc.Annotations)
+	}
+}
+
+func TestReconcileListenerPort(t *testing.T) {
+	cfg := operatorconfig.Default()
+	cfg.AdminAPIListenerPort = 443
+	operatorconfig.Set(cfg)
+	defer operatorconfig.Reset()
+
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver", Labels: map[string]string{apiSchemeLabel: aObj.Name}},
+		Spec: corev1.ServiceSpec{
+			Ports:                    []corev1.ServicePort{{Protocol: "TCP", Port: 6443, NodePort: 30443}},
+			LoadBalancerSourceRanges: []string{"0.0.0.0/0"},
+			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyTypeLocal,
+		},
+	}
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj, svc})
+	cloudClient = mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	updated := &corev1.Service{}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, updated); err != nil {
+		t.Fatalf("Couldn't get the Service: %v", err)
+	}
+	if updated.Spec.Ports[0].Port != 443 || updated.Spec.Ports[0].NodePort != 30443 {
+		t.Errorf("Expected the port to be changed to the configured one, got %+v", updated.Spec.Ports)
+	}
+}
+
+func TestReconcileLoadBalancerTypeChange(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	aObj.Spec.ManagementAPIServerIngress.Type = "NLB"
+	aObj.Generation = 2
+	// The Service of the Classic ELB
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver", Labels: map[string]string{apiSchemeLabel: aObj.Name}}}
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	kclient := fake.NewClientBuilder().WithScheme(mocks.Scheme).WithObjects(aObj, svc).WithStatusSubresource(aObj).Build()
+	mockCloudClient := mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	// The records are only moved once the NLB is ready
+	isNewService := gomock.Cond(func(x any) bool { return x.(*corev1.Service).Name == "rh-api-2" })
+	gomock.InOrder(
+		mockCloudClient.EXPECT().EnsureAdminAPIDNS(gomock.Any(), gomock.Any(), gomock.Any(), isNewService).Return(cioerrors.NewLoadBalancerNotReadyError()).Times(1),
+		mockCloudClient.EXPECT().EnsureAdminAPIDNS(gomock.Any(), gomock.Any(), gomock.Any(), isNewService).Return(nil).Times(1),
+	)
+	cloudClient = mockCloudClient
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: kclient, Scheme: mocks.Scheme}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}
+	expectServices := func(step string, expected ...string) {
+		services := &corev1.ServiceList{}
+		if err := kclient.List(context.TODO(), services); err != nil {
+			t.Fatalf("Couldn't list the Services: %v", err)
+		}
+		var names []string
+		for _, item := range services.Items {
+			names = append(names, item.Name)
+		}
+		if !slices.Equal(names, expected) {
+			t.Fatalf("Expected the Services %v %s, got %v", expected, step, names)
+		}
+	}
+
+	// The Service of the NLB is created next to the Classic ELB one
+	result, err := r.Reconcile(context.TODO(), request)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Fatalf("Expected the NLB to be waited for, got %+v", result)
+	}
+	expectServices("once the NLB is created", "rh-api", "rh-api-2")
+	updated := &corev1.Service{}
+	if err := kclient.Get(context.TODO(), types.NamespacedName{Name: "rh-api-2", Namespace: svc.Namespace}, updated); err != nil {
+		t.Fatalf("Couldn't get the new Service: %v", err)
+	}
+	if updated.Annotations[elbAnnotationTypeKey] != elbAnnotationTypeValue || updated.Labels["app"] != "cloud-ingress-operator-rh-api" {
+		t.Errorf("Expected the new Service to ask for an NLB for rh-api, got %v %v", updated.Annotations, updated.Labels)
+	}
+	instance := &cloudingressv1alpha1.APIScheme{}
+	if err := kclient.Get(context.TODO(), request.NamespacedName, instance); err != nil {
+		t.Fatalf("Couldn't get the APIScheme: %v", err)
+	}
+	lbReady := meta.FindStatusCondition(instance.Status.Conditions, string(cloudingressv1alpha1.ConditionLoadBalancerReady))
+	if lbReady == nil || lbReady.Status != metav1.ConditionFalse || lbReady.Reason != cloudingressv1alpha1.ReasonLoadBalancerTypeChanging {
+		t.Fatalf("Expected the load balancer not to be ready while its type changes, got %+v", lbReady)
+	}
+
+	// The Classic ELB keeps serving until the NLB is ready
+	if _, err := r.Reconcile(context.TODO(), request); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expectServices("while the NLB isn't ready", "rh-api", "rh-api-2")
+
+	// Then the records are moved and the Classic ELB deleted
+	if _, err := r.Reconcile(context.TODO(), request); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expectServices("once the records are moved", "rh-api-2")
+}
+
+func TestReplacementServiceName(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject(strings.Repeat("a", 62)+"-b", true, []string{"0.0.0.0/0"})
+	aObj.Generation = 12
+	if name := replacementServiceName(aObj); name != strings.Repeat("a", 60)+"-12" {
+		t.Errorf("Expected the name to fit in a DNS label, got %q", name)
+	}
+}
+
+func TestReconcileDualStackService(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0", "::/0"})
+	aObj.Spec.ManagementAPIServerIngress.Type = "NLB"
+	aObj.Spec.ManagementAPIServerIngress.IPFamilyPolicy = corev1.IPFamilyPolicyPreferDualStack
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj})
+	cloudClient = mock_cloudclient.NewMockCloudClient(mocks.MockCtrl)
+	defer func() { cloudClient = nil }()
+
+	r := &APISchemeReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: aObj.Name, Namespace: aObj.Namespace}}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	svc := &corev1.Service{}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: "rh-api", Namespace: "openshift-kube-apiserver"}, svc); err != nil {
+		t.Fatalf("Couldn't get the Service: %v", err)
+	}
+	if svc.Spec.IPFamilyPolicy == nil || *svc.Spec.IPFamilyPolicy != corev1.IPFamilyPolicyPreferDualStack {
+		t.Errorf("Expected the Service to prefer dual-stack, got %v", svc.Spec.IPFamilyPolicy)
+	}
+	if len(svc.Spec.IPFamilies) != 2 {
+		t.Errorf("Expected the Service to have both IP families, got %v", svc.Spec.IPFamilies)
+	}
+	if svc.Annotations[elbAnnotationIPAddressTypeKey] != elbAnnotationIPAddressTypeValue {
+		t.Errorf("Expected the Service to ask for a dual-stack NLB, got %v", svc.Annotations)
+	}
+}
+
+func TestLoadBalancerChange(t *testing.T) {
+	nlb := map[string]string{elbAnnotationTypeKey: elbAnnotationTypeValue}
+	tests := []struct {
+		Name           string
+		Listening      cloudingressv1alpha1.Listening
+		Type           cloudingressv1alpha1.Type
+		IPFamilyPolicy corev1.IPFamilyPolicy
+		Annotations    map[string]string
+		ServicePolicy  *corev1.IPFamilyPolicy
+		ExpectedReason string
+	}{
+		{
+			Name: "Should keep a Classic ELB",
+		},
+		{
+			Name:           "Should move an external load balancer to the internal scope",
+			Listening:      cloudingressv1alpha1.Internal,
+			ExpectedReason: cloudingressv1alpha1.ReasonScopeChanging,
+		},
+		{
+			Name:           "Should move a Classic ELB to an NLB",
+			Type:           "NLB",
+			ExpectedReason: cloudingressv1alpha1.ReasonLoadBalancerTypeChanging,
+		},
+		{
+			Name:           "Should move a single-stack NLB to a dual-stack one",
+			Type:           "NLB",
+			IPFamilyPolicy: corev1.IPFamilyPolicyRequireDualStack,
+			Annotations:    nlb,
+			ServicePolicy:  ptr.To(corev1.IPFamilyPolicySingleStack),
+			ExpectedReason: cloudingressv1alpha1.ReasonIPFamilyPolicyChanging,
+		},
+		{
+			Name:           "Should move a dual-stack NLB to a single-stack one",
+			Type:           "NLB",
+			Annotations:    nlb,
+			ServicePolicy:  ptr.To(corev1.IPFamilyPolicyPreferDualStack),
+			ExpectedReason: cloudingressv1alpha1.ReasonIPFamilyPolicyChanging,
+		},
+		{
+			Name:           "Should keep a dual-stack NLB when its policy is swapped",
+			Type:           "NLB",
+			IPFamilyPolicy: corev1.IPFamilyPolicyRequireDualStack,
+			Annotations:    nlb,
+			ServicePolicy:  ptr.To(corev1.IPFamilyPolicyPreferDualStack),
+		},
+	}
+
+	for _, test := range tests {
+		aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+		aObj.Spec.ManagementAPIServerIngress.Listening = test.Listening
+		aObj.Spec.ManagementAPIServerIngress.Type = test.Type
+		aObj.Spec.ManagementAPIServerIngress.IPFamilyPolicy = test.IPFamilyPolicy
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Annotations: test.Annotations},
+			Spec:       corev1.ServiceSpec{IPFamilyPolicy: test.ServicePolicy},
+		}
+		if reason, _ := loadBalancerChange(aObj, svc); reason != test.ExpectedReason {
+			t.Errorf("Test [%v] FAILED: expected reason %q, got %q", test.Name, test.ExpectedReason, reason)
+		}
+	}
+}
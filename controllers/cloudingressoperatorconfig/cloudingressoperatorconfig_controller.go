@@ -0,0 +1,107 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudingressoperatorconfig
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+)
+
+var log = logf.Log.WithName("controller_cloudingressoperatorconfig")
+
+var _ reconcile.Reconciler = &CloudIngressOperatorConfigReconciler{}
+
+// CloudIngressOperatorConfigReconciler loads the CloudIngressOperatorConfig into the configuration the operator runs with
+type CloudIngressOperatorConfigReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+// Reconcile validates the CloudIngressOperatorConfig and, when it is valid, makes it the configuration in effect.
+// An invalid spec is reported in the status and the previous configuration is kept.
+// Without a CloudIngressOperatorConfig the operator goes back to its defaults.
+func (r *CloudIngressOperatorConfigReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Request.Name", request.Name)
+
+	instance := &v1alpha1.CloudIngressOperatorConfig{}
+	err := r.Client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			reqLogger.Info("CloudIngressOperatorConfig not found, using the default configuration")
+			operatorconfig.Reset()
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	originalStatus := instance.Status.DeepCopy()
+	config, err := operatorconfig.FromSpec(instance.Spec)
+	if err != nil {
+		reqLogger.Error(err, "Invalid CloudIngressOperatorConfig, keeping the current configuration")
+		setAppliedCondition(instance, metav1.ConditionFalse, v1alpha1.ReasonInvalidConfig, err.Error())
+	} else {
+		operatorconfig.Set(config)
+		reqLogger.Info("Applied the CloudIngressOperatorConfig", "config", config)
+		setAppliedCondition(instance, metav1.ConditionTrue, v1alpha1.ReasonAsExpected, "The operator runs with this configuration")
+	}
+	instance.Status.ObservedGeneration = instance.Generation
+
+	if !reflect.DeepEqual(*originalStatus, instance.Status) {
+		if statusErr := r.Client.Status().Update(ctx, instance); statusErr != nil {
+			reqLogger.Error(statusErr, "Error updating CloudIngressOperatorConfig status")
+		}
+	}
+	// an invalid spec won't fix itself, the next change to it triggers a new reconcile
+	return reconcile.Result{}, nil
+}
+
+func setAppliedCondition(instance *v1alpha1.CloudIngressOperatorConfig, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               string(v1alpha1.CloudIngressOperatorConfigApplied),
+		Status:             status,
+		ObservedGeneration: instance.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Only the CloudIngressOperatorConfig named cluster is reconciled, and status updates are ignored.
+func (r *CloudIngressOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.CloudIngressOperatorConfig{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(func(object client.Object) bool {
+				return object.GetName() == v1alpha1.CloudIngressOperatorConfigName
+			}),
+			predicate.GenerationChangedPredicate{},
+		)).
+		Complete(r)
+}
@@ -0,0 +1,86 @@
+package cloudingressoperatorconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+)
+
+func TestReconcile(t *testing.T) {
+	custom := operatorconfig.Default()
+	custom.LongRequeueInterval = 5 * time.Minute
+
+	tests := []struct {
+		Name            string
+		Spec            *v1alpha1.CloudIngressOperatorConfigSpec
+		ExpectedConfig  operatorconfig.Config
+		ExpectedApplied metav1.ConditionStatus
+	}{
+		{
+			Name:            "Should apply a valid config",
+			Spec:            &v1alpha1.CloudIngressOperatorConfigSpec{RequeueIntervals: v1alpha1.RequeueIntervals{Long: &metav1.Duration{Duration: 5 * time.Minute}}},
+			ExpectedConfig:  custom,
+			ExpectedApplied: metav1.ConditionTrue,
+		},
+		{
+			Name:            "Should keep the current config when the spec is invalid",
+			Spec:            &v1alpha1.CloudIngressOperatorConfigSpec{MaxAPIRetries: 1000},
+			ExpectedConfig:  custom,
+			ExpectedApplied: metav1.ConditionFalse,
+		},
+		{
+			Name:           "Should go back to the defaults when the config is deleted",
+			ExpectedConfig: operatorconfig.Default(),
+		},
+	}
+	defer operatorconfig.Reset()
+
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("Couldn't add cloudingressv1alpha1 scheme: (%v)", err)
+	}
+	for _, test := range tests {
+		var objs []client.Object
+		if test.Spec != nil {
+			objs = append(objs, &v1alpha1.CloudIngressOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.CloudIngressOperatorConfigName, Generation: 1},
+				Spec:       *test.Spec,
+			})
+		}
+		testClient := fake.NewClientBuilder().
+			WithScheme(s).
+			WithObjects(objs...).
+			WithStatusSubresource(&v1alpha1.CloudIngressOperatorConfig{}).
+			Build()
+		r := &CloudIngressOperatorConfigReconciler{Client: testClient, Scheme: s}
+		namespacedName := types.NamespacedName{Name: v1alpha1.CloudIngressOperatorConfigName}
+
+		if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: namespacedName}); err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		if operatorconfig.Get() != test.ExpectedConfig {
+			t.Fatalf("Test [%v] FAILED. Expected config %+v. Got %+v", test.Name, test.ExpectedConfig, operatorconfig.Get())
+		}
+		if test.Spec == nil {
+			continue
+		}
+		updated := &v1alpha1.CloudIngressOperatorConfig{}
+		if err := testClient.Get(context.TODO(), namespacedName, updated); err != nil {
+			t.Fatalf("Test [%v] FAILED. Couldn't get CloudIngressOperatorConfig: %v", test.Name, err)
+		}
+		if !meta.IsStatusConditionPresentAndEqual(updated.Status.Conditions, string(v1alpha1.CloudIngressOperatorConfigApplied), test.ExpectedApplied) {
+			t.Fatalf("Test [%v] FAILED. Expected Applied %v, got %+v", test.Name, test.ExpectedApplied, updated.Status.Conditions)
+		}
+	}
+}
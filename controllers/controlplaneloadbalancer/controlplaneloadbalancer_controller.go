@@ -0,0 +1,129 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplaneloadbalancer
+
+import (
+	"context"
+	"errors"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var log = logf.Log.WithName("controller_controlplaneloadbalancer")
+
+// ControlPlaneLoadBalancerReconciler keeps the load balancers in the providerSpec of the control plane Machines
+// and of the ControlPlaneMachineSet in line with the default API scope of the PublishingStrategy
+type ControlPlaneLoadBalancerReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+}
+
+// Reconcile adds the external API load balancer to the control plane Machines and the ControlPlaneMachineSet
+// when the default API is external, and removes it when it is internal.
+// Nothing is changed while the PublishingStrategy controller is still moving the default API to another
+// scope, as the load balancer may not exist yet, or while the ControlPlaneMachineSet is being reactivated.
+func (r *ControlPlaneLoadBalancerReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	instance := &v1alpha1.PublishingStrategy{}
+	err := r.Client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	listening := instance.Spec.DefaultAPIServerIngress.Listening
+	if listening == "" || instance.Status.DefaultAPIServerIngress.Listening != listening {
+		reqLogger.Info("Waiting for the default API scope to be changed", "listening", listening)
+		return reconcile.Result{}, nil
+	}
+	if instance.Status.ControlPlaneMachineSetReactivation != nil {
+		reqLogger.Info("Waiting for the ControlPlaneMachineSet to be reactivated")
+		return reconcile.Result{}, nil
+	}
+
+	cloudPlatform, err := baseutils.GetPlatformType(r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	cloudClient, err := cloudclient.GetClientFor(r.Client, *cloudPlatform)
+	var unsupported *cioerrors.UnsupportedPlatformError
+	if errors.As(err, &unsupported) {
+		// There are no control plane load balancers to manage
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ctx = cioevents.IntoContext(ctx, r.Recorder, instance)
+	if err := cloudClient.EnsureControlPlaneLoadBalancers(ctx, r.Client, listening); err != nil {
+		reqLogger.Error(err, "Error updating the load balancers of the control plane machines", "listening", listening)
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// publishingStrategiesForObject maps a control plane Machine or the ControlPlaneMachineSet to every PublishingStrategy
+func (r *ControlPlaneLoadBalancerReconciler) publishingStrategiesForObject(ctx context.Context, _ client.Object) []reconcile.Request {
+	publishingStrategies := &v1alpha1.PublishingStrategyList{}
+	if err := r.Client.List(ctx, publishingStrategies); err != nil {
+		log.Error(err, "Cannot list the PublishingStrategies")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(publishingStrategies.Items))
+	for _, ps := range publishingStrategies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ps.Namespace, Name: ps.Name}})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// The PublishingStrategies are reconciled again whenever a control plane Machine or the ControlPlaneMachineSet
+// changes, so a load balancer added back by someone else is removed again.
+func (r *ControlPlaneLoadBalancerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("controlplaneloadbalancer").
+		For(&v1alpha1.PublishingStrategy{}).
+		Watches(&machineapi.Machine{},
+			handler.EnqueueRequestsFromMapFunc(r.publishingStrategiesForObject),
+			builder.WithPredicates(predicate.NewPredicateFuncs(baseutils.IsMasterMachine), predicate.GenerationChangedPredicate{})).
+		Watches(&machinev1.ControlPlaneMachineSet{},
+			handler.EnqueueRequestsFromMapFunc(r.publishingStrategiesForObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
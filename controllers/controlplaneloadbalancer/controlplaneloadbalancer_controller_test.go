@@ -0,0 +1,87 @@
+package controlplaneloadbalancer
+
+import (
+	"context"
+	"testing"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/gcp"
+	. "github.com/openshift/cloud-ingress-operator/pkg/cloudclient/mock_cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		Name            string
+		SpecListening   cloudingressv1alpha1.Listening
+		StatusListening cloudingressv1alpha1.Listening
+		Reactivation    *cloudingressv1alpha1.ControlPlaneMachineSetReactivation
+		ExpectedCall    bool
+	}{
+		{
+			Name:            "Should update the load balancers of a private API",
+			SpecListening:   cloudingressv1alpha1.Internal,
+			StatusListening: cloudingressv1alpha1.Internal,
+			ExpectedCall:    true,
+		},
+		{
+			Name:            "Should update the load balancers of a public API",
+			SpecListening:   cloudingressv1alpha1.External,
+			StatusListening: cloudingressv1alpha1.External,
+			ExpectedCall:    true,
+		},
+		{
+			Name:            "Should wait for the API scope to be changed",
+			SpecListening:   cloudingressv1alpha1.External,
+			StatusListening: cloudingressv1alpha1.Internal,
+		},
+		{
+			Name:            "Should wait for the CPMS to be reactivated",
+			SpecListening:   cloudingressv1alpha1.Internal,
+			StatusListening: cloudingressv1alpha1.Internal,
+			Reactivation: &cloudingressv1alpha1.ControlPlaneMachineSetReactivation{
+				Phase:         cloudingressv1alpha1.CPMSReactivationRemovingLoadBalancers,
+				LoadBalancers: []string{"basename-api"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: "publishingstrategy", Namespace: "openshift-cloud-ingress-operator"},
+			Spec: cloudingressv1alpha1.PublishingStrategySpec{
+				DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: test.SpecListening},
+			},
+			Status: cloudingressv1alpha1.PublishingStrategyStatus{
+				DefaultAPIServerIngress:            cloudingressv1alpha1.DefaultAPIServerIngress{Listening: test.StatusListening},
+				ControlPlaneMachineSetReactivation: test.Reactivation,
+			},
+		}
+		infraObj := testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		mocks := testutils.NewTestMock(t, []runtime.Object{publishingStrategy, infraObj})
+
+		mockcloudclient := NewMockCloudClient(gomock.NewController(t))
+		cloudclient.Register(gcp.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
+		if test.ExpectedCall {
+			mockcloudclient.EXPECT().EnsureControlPlaneLoadBalancers(gomock.Any(), gomock.Any(), test.SpecListening).Return(nil)
+		}
+
+		r := &ControlPlaneLoadBalancerReconciler{Client: mocks.FakeKubeClient, Scheme: mocks.Scheme}
+		result, err := r.Reconcile(context.TODO(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "publishingstrategy", Namespace: "openshift-cloud-ingress-operator"},
+		})
+		if err != nil {
+			t.Errorf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if result != (reconcile.Result{}) {
+			t.Errorf("Test [%v] FAILED: unexpected result %+v", test.Name, result)
+		}
+	}
+}
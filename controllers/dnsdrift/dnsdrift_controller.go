@@ -0,0 +1,241 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsdrift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var log = logf.Log.WithName("controller_dnsdrift")
+
+const (
+	kindPublishingStrategy = "PublishingStrategy"
+	kindAPIScheme          = "APIScheme"
+)
+
+// DNSDriftReconciler periodically compares the api records and the management API records of the APISchemes
+// with the load balancers they should point to. The records which have drifted are reported in the status of
+// the PublishingStrategy and of the APIScheme, and set back when the DNS drift policy is AutoHeal.
+type DNSDriftReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+}
+
+// Reconcile checks the DNS records and comes back after the DNS drift check interval.
+// The records aren't checked while the PublishingStrategy controller is moving the default API to another
+// scope or reactivating the ControlPlaneMachineSet, nor while an APIScheme isn't ready, as they are expected
+// to differ until it is done.
+func (r *DNSDriftReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	instance := &v1alpha1.PublishingStrategy{}
+	err := r.Client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	cfg := operatorconfig.Get()
+	result := reconcile.Result{RequeueAfter: cfg.DNSDriftCheckInterval}
+	repair := cfg.DNSDriftPolicy == v1alpha1.DNSDriftAutoHeal
+
+	listening := instance.Spec.DefaultAPIServerIngress.Listening
+	if listening == "" || instance.Status.DefaultAPIServerIngress.Listening != listening {
+		reqLogger.Info("Waiting for the default API scope to be changed before checking the DNS records", "listening", listening)
+		return result, nil
+	}
+	if instance.Status.ControlPlaneMachineSetReactivation != nil {
+		reqLogger.Info("Waiting for the ControlPlaneMachineSet to be reactivated before checking the DNS records")
+		return result, nil
+	}
+
+	cloudPlatform, err := baseutils.GetPlatformType(r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	cloudClient, err := cloudclient.GetClientFor(r.Client, *cloudPlatform)
+	var unsupported *cioerrors.UnsupportedPlatformError
+	if errors.As(err, &unsupported) {
+		// There are no DNS records to check
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	psCtx := cioevents.IntoContext(ctx, r.Recorder, instance)
+	drifts, checkErr := cloudClient.CheckDefaultAPIDNS(psCtx, r.Client, listening, repair)
+	if checkErr != nil {
+		reqLogger.Error(checkErr, "Error checking the api DNS records", "listening", listening)
+	}
+	status := reportDNSDrift(psCtx, kindPublishingStrategy, instance.Status.DNSDrift, drifts, checkErr)
+	localmetrics.MetricDNSRecordsDrifted.WithLabelValues(kindPublishingStrategy).Set(float64(countDrifted(drifts)))
+	if dnsDriftChanged(instance.Status.DNSDrift, status) {
+		patch := client.MergeFrom(instance.DeepCopy())
+		instance.Status.DNSDrift = status
+		if err := r.Client.Status().Patch(ctx, instance, patch); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	drifted, err := r.checkAPISchemes(ctx, cloudClient, repair)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	localmetrics.MetricDNSRecordsDrifted.WithLabelValues(kindAPIScheme).Set(float64(drifted))
+	return result, nil
+}
+
+// checkAPISchemes checks the management API records of the ready APISchemes and returns the number of records
+// which are still drifted
+func (r *DNSDriftReconciler) checkAPISchemes(ctx context.Context, cloudClient cloudclient.CloudClient, repair bool) (int, error) {
+	apiSchemes := &v1alpha1.APISchemeList{}
+	if err := r.Client.List(ctx, apiSchemes); err != nil {
+		return 0, err
+	}
+	drifted := 0
+	for i := range apiSchemes.Items {
+		instance := &apiSchemes.Items[i]
+		if !instance.Spec.ManagementAPIServerIngress.Enabled || !instance.DeletionTimestamp.IsZero() ||
+			instance.Status.ObservedGeneration != instance.Generation ||
+			!meta.IsStatusConditionTrue(instance.Status.Conditions, string(v1alpha1.ConditionDNSReady)) {
+			continue
+		}
+		services, err := baseutils.AdminAPIServices(ctx, r.Client, instance.Spec.ManagementAPIServerIngress.DNSName)
+		if err != nil {
+			return drifted, err
+		}
+		if len(services) != 1 {
+			// The APIScheme controller creates it again, or is moving the records to another Service
+			continue
+		}
+		svc := &services[0]
+
+		asCtx := cioevents.IntoContext(ctx, r.Recorder, instance)
+		drifts, checkErr := cloudClient.CheckAdminAPIDNS(asCtx, r.Client, instance, svc, repair)
+		if checkErr != nil {
+			log.Error(checkErr, "Error checking the management API DNS records", "APIScheme", instance.Name)
+		}
+		status := reportDNSDrift(asCtx, kindAPIScheme, instance.Status.DNSDrift, drifts, checkErr)
+		drifted += countDrifted(drifts)
+		if !dnsDriftChanged(instance.Status.DNSDrift, status) {
+			continue
+		}
+		patch := client.MergeFrom(instance.DeepCopy())
+		instance.Status.DNSDrift = status
+		if err := r.Client.Status().Patch(ctx, instance, patch); err != nil {
+			return drifted, err
+		}
+	}
+	return drifted, nil
+}
+
+// reportDNSDrift returns the status of a check. The records which weren't reported by the previous check, and
+// the repaired ones, are recorded as Events on the object stored in the context.
+func reportDNSDrift(ctx context.Context, kind string, previous *v1alpha1.DNSDriftStatus, drifts []v1alpha1.DNSRecordDrift, err error) *v1alpha1.DNSDriftStatus {
+	status := &v1alpha1.DNSDriftStatus{
+		LastCheckTime: metav1.Now(),
+		Records:       drifts,
+	}
+	if err != nil {
+		status.LastError = err.Error()
+		localmetrics.MetricDNSDriftCheckFailures.WithLabelValues(kind).Inc()
+		if previous == nil || previous.LastError != status.LastError {
+			cioevents.Warning(ctx, cioevents.ReasonDNSDriftCheckFailed, cioevents.ActionCheck, "Couldn't check the DNS records: %v", err)
+		}
+	}
+	for _, drift := range drifts {
+		if drift.Repaired {
+			localmetrics.MetricDNSDriftRepairs.WithLabelValues(kind).Inc()
+			cioevents.Warning(ctx, cioevents.ReasonDNSRecordDrifted, cioevents.ActionUpdate, "Set %s in the %s zone back to %s, it %s", drift.Name, drift.Zone, drift.Expected, describeActual(drift))
+			continue
+		}
+		if previous != nil && reported(previous.Records, drift) {
+			continue
+		}
+		cioevents.Warning(ctx, cioevents.ReasonDNSRecordDrifted, cioevents.ActionCheck, "%s in the %s zone %s, expected %s", drift.Name, drift.Zone, describeActual(drift), drift.Expected)
+	}
+	return status
+}
+
+// dnsDriftChanged tells whether a check found something else than the previous one. The status is only written
+// then, as the PublishingStrategy and APIScheme controllers reconcile on every update of their objects.
+func dnsDriftChanged(previous, current *v1alpha1.DNSDriftStatus) bool {
+	return previous == nil || previous.LastError != current.LastError || !equality.Semantic.DeepEqual(previous.Records, current.Records)
+}
+
+// reported tells whether the same drift was already reported
+func reported(records []v1alpha1.DNSRecordDrift, drift v1alpha1.DNSRecordDrift) bool {
+	for _, record := range records {
+		if !record.Repaired && record.Name == drift.Name && record.Zone == drift.Zone && record.Actual == drift.Actual && record.Expected == drift.Expected {
+			return true
+		}
+	}
+	return false
+}
+
+func describeActual(drift v1alpha1.DNSRecordDrift) string {
+	if drift.Actual == "" {
+		return "was missing"
+	}
+	return fmt.Sprintf("pointed to %s", drift.Actual)
+}
+
+// countDrifted returns the number of records which are still drifted
+func countDrifted(drifts []v1alpha1.DNSRecordDrift) int {
+	count := 0
+	for _, drift := range drifts {
+		if !drift.Repaired {
+			count++
+		}
+	}
+	return count
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Only changes to the spec trigger a check, the status written after every check would trigger the next one.
+func (r *DNSDriftReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("dnsdrift").
+		For(&v1alpha1.PublishingStrategy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
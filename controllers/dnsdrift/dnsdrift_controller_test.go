@@ -0,0 +1,274 @@
+package dnsdrift
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/gcp"
+	. "github.com/openshift/cloud-ingress-operator/pkg/cloudclient/mock_cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+)
+
+var publishingStrategyName = types.NamespacedName{Name: "publishingstrategy", Namespace: "openshift-cloud-ingress-operator"}
+
+func apiScheme(dnsReady metav1.ConditionStatus) *cloudingressv1alpha1.APIScheme {
+	return &cloudingressv1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-cloud-ingress-operator", Generation: 1},
+		Spec: cloudingressv1alpha1.APISchemeSpec{
+			ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{Enabled: true, DNSName: "rh-api"},
+		},
+		Status: cloudingressv1alpha1.APISchemeStatus{
+			ObservedGeneration: 1,
+			Conditions: []metav1.Condition{{
+				Type:               string(cloudingressv1alpha1.ConditionDNSReady),
+				Status:             dnsReady,
+				Reason:             "Test",
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	drift := cloudingressv1alpha1.DNSRecordDrift{
+		Name:     "api.sut.example.com.",
+		Zone:     cloudingressv1alpha1.DNSRecordZonePublic,
+		Expected: "A 10.0.0.1 TTL 60",
+		Actual:   "A 192.0.2.1 TTL 60",
+	}
+	repaired := drift
+	repaired.Repaired = true
+
+	tests := []struct {
+		Name              string
+		Policy            cloudingressv1alpha1.DNSDriftPolicy
+		StatusListening   cloudingressv1alpha1.Listening
+		APIScheme         *cloudingressv1alpha1.APIScheme
+		Drifts            []cloudingressv1alpha1.DNSRecordDrift
+		CheckErr          error
+		ExpectedCheck     bool
+		ExpectedRepair    bool
+		ExpectedAPIScheme bool
+		ExpectedDrifted   float64
+	}{
+		{
+			Name:            "Should only report the drifted records",
+			Policy:          cloudingressv1alpha1.DNSDriftReportOnly,
+			StatusListening: cloudingressv1alpha1.Internal,
+			Drifts:          []cloudingressv1alpha1.DNSRecordDrift{drift},
+			ExpectedCheck:   true,
+			ExpectedDrifted: 1,
+		},
+		{
+			Name:            "Should repair the drifted records",
+			Policy:          cloudingressv1alpha1.DNSDriftAutoHeal,
+			StatusListening: cloudingressv1alpha1.Internal,
+			Drifts:          []cloudingressv1alpha1.DNSRecordDrift{repaired},
+			ExpectedCheck:   true,
+			ExpectedRepair:  true,
+		},
+		{
+			Name:            "Should record the error of the check",
+			Policy:          cloudingressv1alpha1.DNSDriftReportOnly,
+			StatusListening: cloudingressv1alpha1.Internal,
+			CheckErr:        errors.New("could not find internal API NLB"),
+			ExpectedCheck:   true,
+		},
+		{
+			Name:            "Should wait for the API scope to be changed",
+			Policy:          cloudingressv1alpha1.DNSDriftAutoHeal,
+			StatusListening: cloudingressv1alpha1.External,
+		},
+		{
+			Name:              "Should check the records of a ready APIScheme",
+			Policy:            cloudingressv1alpha1.DNSDriftReportOnly,
+			StatusListening:   cloudingressv1alpha1.Internal,
+			APIScheme:         apiScheme(metav1.ConditionTrue),
+			ExpectedCheck:     true,
+			ExpectedAPIScheme: true,
+		},
+		{
+			Name:            "Should skip the APIScheme while its DNS isn't ready",
+			Policy:          cloudingressv1alpha1.DNSDriftReportOnly,
+			StatusListening: cloudingressv1alpha1.Internal,
+			APIScheme:       apiScheme(metav1.ConditionFalse),
+			ExpectedCheck:   true,
+		},
+	}
+
+	for _, test := range tests {
+		cfg := operatorconfig.Default()
+		cfg.DNSDriftPolicy = test.Policy
+		operatorconfig.Set(cfg)
+
+		publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: publishingStrategyName.Name, Namespace: publishingStrategyName.Namespace},
+			Spec: cloudingressv1alpha1.PublishingStrategySpec{
+				DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.Internal},
+			},
+			Status: cloudingressv1alpha1.PublishingStrategyStatus{
+				DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: test.StatusListening},
+			},
+		}
+		infraObj := testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		objs := []client.Object{publishingStrategy, infraObj}
+		statusObjs := []client.Object{publishingStrategy}
+		if test.APIScheme != nil {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver"}}
+			objs = append(objs, test.APIScheme, svc)
+			statusObjs = append(statusObjs, test.APIScheme)
+		}
+		mocks := testutils.NewTestMock(t, []runtime.Object{})
+		kclient := fake.NewClientBuilder().WithScheme(mocks.Scheme).WithObjects(objs...).WithStatusSubresource(statusObjs...).Build()
+
+		mockcloudclient := NewMockCloudClient(gomock.NewController(t))
+		cloudclient.Register(gcp.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
+		if test.ExpectedCheck {
+			mockcloudclient.EXPECT().CheckDefaultAPIDNS(gomock.Any(), gomock.Any(), cloudingressv1alpha1.Internal, test.ExpectedRepair).Return(test.Drifts, test.CheckErr)
+		}
+		if test.ExpectedAPIScheme {
+			mockcloudclient.EXPECT().CheckAdminAPIDNS(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), false).Return([]cloudingressv1alpha1.DNSRecordDrift{drift}, nil)
+		}
+
+		r := &DNSDriftReconciler{Client: kclient, Scheme: mocks.Scheme}
+		result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: publishingStrategyName})
+		if err != nil {
+			t.Errorf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if result.RequeueAfter != 10*time.Minute {
+			t.Errorf("Test [%v] FAILED: expected to check again after the interval, got %+v", test.Name, result)
+		}
+
+		updated := &cloudingressv1alpha1.PublishingStrategy{}
+		if err := kclient.Get(context.TODO(), publishingStrategyName, updated); err != nil {
+			t.Fatalf("Test [%v] FAILED: %v", test.Name, err)
+		}
+		status := updated.Status.DNSDrift
+		if !test.ExpectedCheck {
+			if status != nil {
+				t.Errorf("Test [%v] FAILED: expected no check, got %+v", test.Name, status)
+			}
+			continue
+		}
+		if status == nil || status.LastCheckTime.IsZero() {
+			t.Fatalf("Test [%v] FAILED: the check wasn't recorded", test.Name)
+		}
+		if len(status.Records) != len(test.Drifts) {
+			t.Errorf("Test [%v] FAILED: expected %v, got %v", test.Name, test.Drifts, status.Records)
+		}
+		if (status.LastError != "") != (test.CheckErr != nil) {
+			t.Errorf("Test [%v] FAILED: unexpected error in the status %q", test.Name, status.LastError)
+		}
+		if got := testutil.ToFloat64(localmetrics.MetricDNSRecordsDrifted.WithLabelValues(kindPublishingStrategy)); got != test.ExpectedDrifted {
+			t.Errorf("Test [%v] FAILED: expected %v drifted records, got %v", test.Name, test.ExpectedDrifted, got)
+		}
+
+		if test.APIScheme != nil {
+			updatedAPIScheme := &cloudingressv1alpha1.APIScheme{}
+			if err := kclient.Get(context.TODO(), client.ObjectKeyFromObject(test.APIScheme), updatedAPIScheme); err != nil {
+				t.Fatalf("Test [%v] FAILED: %v", test.Name, err)
+			}
+			if (updatedAPIScheme.Status.DNSDrift != nil) != test.ExpectedAPIScheme {
+				t.Errorf("Test [%v] FAILED: unexpected APIScheme status %+v", test.Name, updatedAPIScheme.Status.DNSDrift)
+			}
+		}
+	}
+	operatorconfig.Reset()
+}
+
+func TestReportDNSDrift(t *testing.T) {
+	drift := cloudingressv1alpha1.DNSRecordDrift{
+		Name:     "rh-api.sut.example.com.",
+		Zone:     cloudingressv1alpha1.DNSRecordZonePrivate,
+		Expected: "ALIAS lb.elb.amazonaws.com.",
+	}
+	repaired := drift
+	repaired.Repaired = true
+	previous := &cloudingressv1alpha1.DNSDriftStatus{Records: []cloudingressv1alpha1.DNSRecordDrift{drift}}
+
+	repairs := testutil.ToFloat64(localmetrics.MetricDNSDriftRepairs.WithLabelValues(kindAPIScheme))
+	failures := testutil.ToFloat64(localmetrics.MetricDNSDriftCheckFailures.WithLabelValues(kindAPIScheme))
+
+	status := reportDNSDrift(context.TODO(), kindAPIScheme, previous, []cloudingressv1alpha1.DNSRecordDrift{repaired}, errors.New("throttled"))
+	if len(status.Records) != 1 || !status.Records[0].Repaired || status.LastError != "throttled" {
+		t.Errorf("Unexpected status %+v", status)
+	}
+	if got := testutil.ToFloat64(localmetrics.MetricDNSDriftRepairs.WithLabelValues(kindAPIScheme)) - repairs; got != 1 {
+		t.Errorf("Expected 1 repair, got %v", got)
+	}
+	if got := testutil.ToFloat64(localmetrics.MetricDNSDriftCheckFailures.WithLabelValues(kindAPIScheme)) - failures; got != 1 {
+		t.Errorf("Expected 1 failure, got %v", got)
+	}
+
+	if !reported(previous.Records, drift) {
+		t.Error("Expected the drift to be already reported")
+	}
+	moved := drift
+	moved.Actual = "ALIAS other.elb.amazonaws.com."
+	if reported(previous.Records, moved) {
+		t.Error("Expected a record pointing somewhere else to be reported again")
+	}
+	if countDrifted([]cloudingressv1alpha1.DNSRecordDrift{drift, repaired}) != 1 {
+		t.Error("Expected only the record which wasn't repaired to be counted")
+	}
+}
+
+func TestReconcileKeepsUnchangedStatus(t *testing.T) {
+	drift := cloudingressv1alpha1.DNSRecordDrift{
+		Name:     "api.sut.example.com.",
+		Zone:     cloudingressv1alpha1.DNSRecordZonePublic,
+		Expected: "A 10.0.0.1 TTL 60",
+		Actual:   "A 192.0.2.1 TTL 60",
+	}
+	checked := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: publishingStrategyName.Name, Namespace: publishingStrategyName.Namespace},
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.Internal},
+		},
+		Status: cloudingressv1alpha1.PublishingStrategyStatus{
+			DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.Internal},
+			DNSDrift:                &cloudingressv1alpha1.DNSDriftStatus{LastCheckTime: checked, Records: []cloudingressv1alpha1.DNSRecordDrift{drift}},
+		},
+	}
+	infraObj := testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	kclient := fake.NewClientBuilder().WithScheme(mocks.Scheme).WithObjects(publishingStrategy, infraObj).WithStatusSubresource(publishingStrategy).Build()
+	before := &cloudingressv1alpha1.PublishingStrategy{}
+	if err := kclient.Get(context.TODO(), publishingStrategyName, before); err != nil {
+		t.Fatal(err)
+	}
+
+	mockcloudclient := NewMockCloudClient(gomock.NewController(t))
+	cloudclient.Register(gcp.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
+	mockcloudclient.EXPECT().CheckDefaultAPIDNS(gomock.Any(), gomock.Any(), cloudingressv1alpha1.Internal, false).Return([]cloudingressv1alpha1.DNSRecordDrift{drift}, nil)
+
+	r := &DNSDriftReconciler{Client: kclient, Scheme: mocks.Scheme}
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: publishingStrategyName}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	updated := &cloudingressv1alpha1.PublishingStrategy{}
+	if err := kclient.Get(context.TODO(), publishingStrategyName, updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.ResourceVersion != before.ResourceVersion {
+		t.Errorf("Expected the status to be left alone when the check finds the same records, got %+v", updated.Status.DNSDrift)
+	}
+}
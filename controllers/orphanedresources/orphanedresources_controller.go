@@ -0,0 +1,192 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphanedresources
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var log = logf.Log.WithName("controller_orphanedresources")
+
+// OrphanedResourcesReconciler periodically searches for the cloud resources of the cluster which nothing uses
+// anymore, such as load balancers left behind by a failed scope change or a deleted Service. They are reported
+// in the status of the PublishingStrategy, and deleted when the orphaned resources policy is Delete.
+type OrphanedResourcesReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+}
+
+// Reconcile searches for the orphaned resources and comes back after the check interval.
+// Nothing is searched while the PublishingStrategy controller is moving the default API to another scope or
+// reactivating the ControlPlaneMachineSet, as the resources it is creating or removing would be reported.
+func (r *OrphanedResourcesReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	instance := &v1alpha1.PublishingStrategy{}
+	err := r.Client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	cfg := operatorconfig.Get()
+	result := reconcile.Result{RequeueAfter: cfg.OrphanedResourcesCheckInterval}
+
+	listening := instance.Spec.DefaultAPIServerIngress.Listening
+	if listening == "" || instance.Status.DefaultAPIServerIngress.Listening != listening {
+		reqLogger.Info("Waiting for the default API scope to be changed before searching for orphaned resources", "listening", listening)
+		return result, nil
+	}
+	if instance.Status.ControlPlaneMachineSetReactivation != nil {
+		reqLogger.Info("Waiting for the ControlPlaneMachineSet to be reactivated before searching for orphaned resources")
+		return result, nil
+	}
+
+	cloudPlatform, err := baseutils.GetPlatformType(r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	cloudClient, err := cloudclient.GetClientFor(r.Client, *cloudPlatform)
+	var unsupported *cioerrors.UnsupportedPlatformError
+	if errors.As(err, &unsupported) {
+		// There are no cloud resources to search
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ctx = cioevents.IntoContext(ctx, r.Recorder, instance)
+	previous := instance.Status.OrphanedResources
+	status := &v1alpha1.OrphanedResourcesStatus{LastCheckTime: metav1.Now()}
+	orphans, err := cloudClient.FindOrphanedResources(ctx, r.Client, listening)
+	if err != nil {
+		reqLogger.Error(err, "Error searching for orphaned resources")
+		localmetrics.MetricOrphanedResourcesCheckFailures.Inc()
+		if previous == nil || previous.LastError != err.Error() {
+			cioevents.Warning(ctx, cioevents.ReasonOrphanedResourcesCheckFailed, cioevents.ActionCheck, "Couldn't search for orphaned resources: %v", err)
+		}
+		status.LastError = err.Error()
+		if previous != nil {
+			// Keep when they were first found for the next search
+			status.Resources = slices.DeleteFunc(slices.Clone(previous.Resources), func(orphan v1alpha1.OrphanedResource) bool { return orphan.Deleted })
+		}
+	} else {
+		deleteErr := r.collect(ctx, cloudClient, previous, orphans, cfg)
+		if deleteErr != nil {
+			status.LastError = deleteErr.Error()
+		}
+		status.Resources = orphans
+		setOrphanedResourcesMetric(orphans)
+	}
+
+	patch := client.MergeFrom(instance.DeepCopy())
+	instance.Status.OrphanedResources = status
+	if err := r.Client.Status().Patch(ctx, instance, patch); err != nil {
+		return reconcile.Result{}, err
+	}
+	return result, nil
+}
+
+// collect records when the orphans were first found, and deletes those which have been orphaned for the grace
+// period when the policy is Delete. It returns the errors of the deletions.
+func (r *OrphanedResourcesReconciler) collect(ctx context.Context, cloudClient cloudclient.CloudClient, previous *v1alpha1.OrphanedResourcesStatus, orphans []v1alpha1.OrphanedResource, cfg operatorconfig.Config) error {
+	slices.SortFunc(orphans, func(a, b v1alpha1.OrphanedResource) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Zone, b.Zone), cmp.Compare(a.Name, b.Name))
+	})
+	now := metav1.Now()
+	var errs []error
+	for i := range orphans {
+		orphan := &orphans[i]
+		orphan.FirstSeenTime = now
+		if found := previouslyFound(previous, *orphan); found != nil {
+			orphan.FirstSeenTime = found.FirstSeenTime
+		} else {
+			cioevents.Warning(ctx, cioevents.ReasonOrphanedResourceFound, cioevents.ActionCheck, "Found the orphaned %s %s, %s", orphan.Kind, orphan.Name, orphan.Reason)
+		}
+		if cfg.OrphanedResourcesPolicy != v1alpha1.OrphanedResourcesDelete || now.Sub(orphan.FirstSeenTime.Time) < cfg.OrphanedResourcesGracePeriod {
+			continue
+		}
+		if err := cloudClient.DeleteOrphanedResource(ctx, r.Client, *orphan); err != nil {
+			log.Error(err, "Error deleting an orphaned resource", "kind", orphan.Kind, "name", orphan.Name)
+			cioevents.Warning(ctx, cioevents.ReasonOrphanedResourceDeleteFailed, cioevents.ActionDelete, "Couldn't delete the orphaned %s %s: %v", orphan.Kind, orphan.Name, err)
+			errs = append(errs, fmt.Errorf("could not delete %s %s: %w", orphan.Kind, orphan.Name, err))
+			continue
+		}
+		orphan.Deleted = true
+		localmetrics.MetricOrphanedResourcesDeleted.WithLabelValues(string(orphan.Kind)).Inc()
+		cioevents.Normal(ctx, cioevents.ReasonOrphanedResourceDeleted, cioevents.ActionDelete, "Deleted the orphaned %s %s, %s", orphan.Kind, orphan.Name, orphan.Reason)
+	}
+	return errors.Join(errs...)
+}
+
+// previouslyFound returns the orphan as found by the previous search, nil when it wasn't orphaned then
+func previouslyFound(previous *v1alpha1.OrphanedResourcesStatus, orphan v1alpha1.OrphanedResource) *v1alpha1.OrphanedResource {
+	if previous == nil {
+		return nil
+	}
+	for i, found := range previous.Resources {
+		if !found.Deleted && found.Kind == orphan.Kind && found.Name == orphan.Name && found.Zone == orphan.Zone {
+			return &previous.Resources[i]
+		}
+	}
+	return nil
+}
+
+// setOrphanedResourcesMetric counts the orphans which weren't deleted by kind
+func setOrphanedResourcesMetric(orphans []v1alpha1.OrphanedResource) {
+	localmetrics.MetricOrphanedResources.Reset()
+	for _, orphan := range orphans {
+		if !orphan.Deleted {
+			localmetrics.MetricOrphanedResources.WithLabelValues(string(orphan.Kind)).Inc()
+		}
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Only changes to the spec trigger a search, the status written after every search would trigger the next one.
+func (r *OrphanedResourcesReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("orphanedresources").
+		For(&v1alpha1.PublishingStrategy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
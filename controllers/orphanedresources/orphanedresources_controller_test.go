@@ -0,0 +1,185 @@
+package orphanedresources
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/gcp"
+	. "github.com/openshift/cloud-ingress-operator/pkg/cloudclient/mock_cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+)
+
+var publishingStrategyName = types.NamespacedName{Name: "publishingstrategy", Namespace: "openshift-cloud-ingress-operator"}
+
+func TestReconcile(t *testing.T) {
+	orphan := cloudingressv1alpha1.OrphanedResource{
+		Kind:   cloudingressv1alpha1.OrphanedForwardingRule,
+		Name:   "sut-api",
+		Reason: "the default API is internal",
+	}
+	seenBefore := orphan
+	seenBefore.FirstSeenTime = metav1.NewTime(time.Now().Add(-2 * time.Hour).Truncate(time.Second))
+
+	tests := []struct {
+		Name            string
+		Policy          cloudingressv1alpha1.OrphanedResourcesPolicy
+		StatusListening cloudingressv1alpha1.Listening
+		Previous        []cloudingressv1alpha1.OrphanedResource
+		FindErr         error
+		DeleteErr       error
+		ExpectedFind    bool
+		ExpectedDelete  bool
+		ExpectedDeleted bool
+		ExpectedError   bool
+		ExpectedOrphans float64
+	}{
+		{
+			Name:            "Should only report the orphaned resources",
+			Policy:          cloudingressv1alpha1.OrphanedResourcesReportOnly,
+			StatusListening: cloudingressv1alpha1.Internal,
+			Previous:        []cloudingressv1alpha1.OrphanedResource{seenBefore},
+			ExpectedFind:    true,
+			ExpectedOrphans: 1,
+		},
+		{
+			Name:            "Should not delete the resources orphaned for less than the grace period",
+			Policy:          cloudingressv1alpha1.OrphanedResourcesDelete,
+			StatusListening: cloudingressv1alpha1.Internal,
+			ExpectedFind:    true,
+			ExpectedOrphans: 1,
+		},
+		{
+			Name:            "Should delete the resources orphaned for the grace period",
+			Policy:          cloudingressv1alpha1.OrphanedResourcesDelete,
+			StatusListening: cloudingressv1alpha1.Internal,
+			Previous:        []cloudingressv1alpha1.OrphanedResource{seenBefore},
+			ExpectedFind:    true,
+			ExpectedDelete:  true,
+			ExpectedDeleted: true,
+		},
+		{
+			Name:            "Should record the error of the deletion",
+			Policy:          cloudingressv1alpha1.OrphanedResourcesDelete,
+			StatusListening: cloudingressv1alpha1.Internal,
+			Previous:        []cloudingressv1alpha1.OrphanedResource{seenBefore},
+			DeleteErr:       errors.New("resourceInUseByAnotherResource"),
+			ExpectedFind:    true,
+			ExpectedDelete:  true,
+			ExpectedError:   true,
+			ExpectedOrphans: 1,
+		},
+		{
+			Name:            "Should keep the previous resources when the search fails",
+			Policy:          cloudingressv1alpha1.OrphanedResourcesDelete,
+			StatusListening: cloudingressv1alpha1.Internal,
+			Previous:        []cloudingressv1alpha1.OrphanedResource{seenBefore},
+			FindErr:         errors.New("rateLimitExceeded"),
+			ExpectedFind:    true,
+			ExpectedError:   true,
+		},
+		{
+			Name:            "Should wait for the API scope to be changed",
+			Policy:          cloudingressv1alpha1.OrphanedResourcesDelete,
+			StatusListening: cloudingressv1alpha1.External,
+		},
+	}
+
+	for _, test := range tests {
+		cfg := operatorconfig.Default()
+		cfg.OrphanedResourcesPolicy = test.Policy
+		operatorconfig.Set(cfg)
+		localmetrics.MetricOrphanedResources.Reset()
+
+		publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: publishingStrategyName.Name, Namespace: publishingStrategyName.Namespace},
+			Spec: cloudingressv1alpha1.PublishingStrategySpec{
+				DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.Internal},
+			},
+			Status: cloudingressv1alpha1.PublishingStrategyStatus{
+				DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: test.StatusListening},
+			},
+		}
+		if test.Previous != nil {
+			publishingStrategy.Status.OrphanedResources = &cloudingressv1alpha1.OrphanedResourcesStatus{Resources: test.Previous}
+		}
+		infraObj := testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		mocks := testutils.NewTestMock(t, []runtime.Object{})
+		kclient := fake.NewClientBuilder().WithScheme(mocks.Scheme).WithObjects(publishingStrategy, infraObj).WithStatusSubresource(publishingStrategy).Build()
+
+		mockcloudclient := NewMockCloudClient(gomock.NewController(t))
+		cloudclient.Register(gcp.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
+		if test.ExpectedFind {
+			var found []cloudingressv1alpha1.OrphanedResource
+			if test.FindErr == nil {
+				found = []cloudingressv1alpha1.OrphanedResource{orphan}
+			}
+			mockcloudclient.EXPECT().FindOrphanedResources(gomock.Any(), gomock.Any(), cloudingressv1alpha1.Internal).Return(found, test.FindErr)
+		}
+		deleted := testutil.ToFloat64(localmetrics.MetricOrphanedResourcesDeleted.WithLabelValues(string(orphan.Kind)))
+		if test.ExpectedDelete {
+			mockcloudclient.EXPECT().DeleteOrphanedResource(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.DeleteErr)
+		}
+
+		r := &OrphanedResourcesReconciler{Client: kclient, Scheme: mocks.Scheme}
+		result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: publishingStrategyName})
+		if err != nil {
+			t.Errorf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if result.RequeueAfter != time.Hour {
+			t.Errorf("Test [%v] FAILED: expected to search again after the interval, got %+v", test.Name, result)
+		}
+
+		updated := &cloudingressv1alpha1.PublishingStrategy{}
+		if err := kclient.Get(context.TODO(), publishingStrategyName, updated); err != nil {
+			t.Fatalf("Test [%v] FAILED: %v", test.Name, err)
+		}
+		status := updated.Status.OrphanedResources
+		if !test.ExpectedFind {
+			if status != nil && !status.LastCheckTime.IsZero() {
+				t.Errorf("Test [%v] FAILED: expected no search, got %+v", test.Name, status)
+			}
+			continue
+		}
+		if status == nil || status.LastCheckTime.IsZero() {
+			t.Fatalf("Test [%v] FAILED: the search wasn't recorded", test.Name)
+		}
+		if len(status.Resources) != 1 {
+			t.Fatalf("Test [%v] FAILED: expected %v, got %v", test.Name, orphan, status.Resources)
+		}
+		if status.Resources[0].Deleted != test.ExpectedDeleted {
+			t.Errorf("Test [%v] FAILED: expected deleted to be %v", test.Name, test.ExpectedDeleted)
+		}
+		if test.Previous != nil && !status.Resources[0].FirstSeenTime.Equal(&seenBefore.FirstSeenTime) {
+			t.Errorf("Test [%v] FAILED: expected to be first seen at %v, got %v", test.Name, seenBefore.FirstSeenTime, status.Resources[0].FirstSeenTime)
+		}
+		if (status.LastError != "") != test.ExpectedError {
+			t.Errorf("Test [%v] FAILED: unexpected error in the status %q", test.Name, status.LastError)
+		}
+		if test.FindErr == nil {
+			if got := testutil.ToFloat64(localmetrics.MetricOrphanedResources.WithLabelValues(string(orphan.Kind))); got != test.ExpectedOrphans {
+				t.Errorf("Test [%v] FAILED: expected %v orphaned resources, got %v", test.Name, test.ExpectedOrphans, got)
+			}
+		}
+		if test.ExpectedDeleted {
+			if got := testutil.ToFloat64(localmetrics.MetricOrphanedResourcesDeleted.WithLabelValues(string(orphan.Kind))) - deleted; got != 1 {
+				t.Errorf("Test [%v] FAILED: expected 1 deletion, got %v", test.Name, got)
+			}
+		}
+	}
+	operatorconfig.Reset()
+}
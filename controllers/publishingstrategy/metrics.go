@@ -0,0 +1,69 @@
+package publishingstrategy
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	localctlutils "github.com/openshift/cloud-ingress-operator/pkg/controllerutils"
+	"github.com/openshift/cloud-ingress-operator/pkg/ingresscontroller"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+)
+
+// recordPublishingStrategyMetrics reports the state of the PublishingStrategy and of its IngressControllers
+// once it has been reconciled. err is the outcome of the reconcile.
+func (r *PublishingStrategyReconciler) recordPublishingStrategyMetrics(ctx context.Context, instance *v1alpha1.PublishingStrategy, err error) {
+	setListeningMetric("desired", instance.Spec.DefaultAPIServerIngress.Listening)
+	setListeningMetric("observed", instance.Status.DefaultAPIServerIngress.Listening)
+	localmetrics.MetricPublishingStrategyApplicationIngresses.Set(float64(len(instance.Spec.ApplicationIngress)))
+	if err == nil {
+		localmetrics.MetricPublishingStrategyLastSuccessfulReconcile.SetToCurrentTime()
+	}
+
+	ingressControllerList := &ingresscontroller.IngressControllerList{}
+	if listErr := r.Client.List(ctx, ingressControllerList, client.InNamespace(ingressControllerNamespace)); listErr != nil {
+		log.Error(listErr, "Cannot get list of ingresscontroller for the metrics")
+		return
+	}
+	owned := getIngressWithCloudIngressOpreatorOwnerAnnotation(*ingressControllerList)
+	localmetrics.MetricPublishingStrategyOwnedIngressControllers.Set(float64(len(owned.Items)))
+	localmetrics.MetricPublishingStrategyIngressControllersDeleting.Set(float64(countIngressControllersDeleting(instance, ingressControllerList)))
+}
+
+// setListeningMetric sets the scope of the default API, the metric is removed when the scope isn't known
+func setListeningMetric(state string, listening v1alpha1.Listening) {
+	switch listening {
+	case v1alpha1.External:
+		localmetrics.MetricPublishingStrategyDefaultAPIExternal.WithLabelValues(state).Set(1)
+	case v1alpha1.Internal:
+		localmetrics.MetricPublishingStrategyDefaultAPIExternal.WithLabelValues(state).Set(0)
+	default:
+		localmetrics.MetricPublishingStrategyDefaultAPIExternal.DeleteLabelValues(state)
+	}
+}
+
+// countIngressControllersDeleting counts the IngressControllers of the PublishingStrategy which are being
+// deleted and still wait on cluster-ingress-operator, they are recreated once it's done
+func countIngressControllersDeleting(instance *v1alpha1.PublishingStrategy, ingressControllerList *ingresscontroller.IngressControllerList) int {
+	names := map[string]bool{}
+	for _, ingressDefinition := range instance.Spec.ApplicationIngress {
+		if ingressDefinition.Default {
+			names["default"] = true
+		} else {
+			names[getIngressName(ingressDefinition.DNSName)] = true
+		}
+	}
+	for _, ingressController := range getIngressWithCloudIngressOpreatorOwnerAnnotation(*ingressControllerList).Items {
+		names[ingressController.Name] = true
+	}
+
+	deleting := 0
+	for _, ingressController := range ingressControllerList.Items {
+		if names[ingressController.Name] && !ingressController.DeletionTimestamp.IsZero() &&
+			localctlutils.Contains(ingressController.GetFinalizers(), ClusterIngressFinalizer) {
+			deleting++
+		}
+	}
+	return deleting
+}
@@ -0,0 +1,64 @@
+package publishingstrategy
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/ingresscontroller"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+)
+
+func TestCountIngressControllersDeleting(t *testing.T) {
+	now := metav1.Now()
+	deleting := func(name string, finalizers []string, annotations map[string]string) ingresscontroller.IngressController {
+		return ingresscontroller.IngressController{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         ingressControllerNamespace,
+			DeletionTimestamp: &now,
+			Finalizers:        finalizers,
+			Annotations:       annotations,
+		}}
+	}
+	instance := &cloudingressv1alpha1.PublishingStrategy{
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{
+				{Default: true, DNSName: "apps.unit.test"},
+				{DNSName: "apps2.unit.test"},
+			},
+		},
+	}
+	ingressControllerList := &ingresscontroller.IngressControllerList{Items: []ingresscontroller.IngressController{
+		// Waiting on cluster-ingress-operator
+		deleting("default", []string{ClusterIngressFinalizer, CloudIngressFinalizer}, nil),
+		// cluster-ingress-operator is done, it is about to be recreated
+		deleting("apps2", []string{CloudIngressFinalizer}, nil),
+		// Removed from the spec but still owned
+		deleting("apps3", []string{ClusterIngressFinalizer}, map[string]string{"Owner": "cloud-ingress-operator"}),
+		// Not ours
+		deleting("other", []string{ClusterIngressFinalizer}, nil),
+		{ObjectMeta: metav1.ObjectMeta{Name: "apps4", Finalizers: []string{ClusterIngressFinalizer}}},
+	}}
+
+	if count := countIngressControllersDeleting(instance, ingressControllerList); count != 2 {
+		t.Errorf("Expected 2 IngressControllers waiting on cluster-ingress-operator, got %d", count)
+	}
+}
+
+func TestSetListeningMetric(t *testing.T) {
+	setListeningMetric("desired", cloudingressv1alpha1.External)
+	setListeningMetric("observed", cloudingressv1alpha1.Internal)
+	if value := testutil.ToFloat64(localmetrics.MetricPublishingStrategyDefaultAPIExternal.WithLabelValues("desired")); value != 1 {
+		t.Errorf("Expected the desired scope to be external, got %v", value)
+	}
+	if value := testutil.ToFloat64(localmetrics.MetricPublishingStrategyDefaultAPIExternal.WithLabelValues("observed")); value != 0 {
+		t.Errorf("Expected the observed scope to be internal, got %v", value)
+	}
+
+	setListeningMetric("observed", "")
+	if count := testutil.CollectAndCount(localmetrics.MetricPublishingStrategyDefaultAPIExternal); count != 1 {
+		t.Errorf("Expected the unknown observed scope to be removed, got %d series", count)
+	}
+}
@@ -24,18 +24,27 @@ import (
 	"strings"
 
 	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
 
 	"time"
 
 	"github.com/go-logr/logr"
+	machineapi "github.com/openshift/api/machine/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
@@ -49,9 +58,12 @@ const (
 	infraNodeLabelKey          = "node-role.kubernetes.io/infra"
 	CloudIngressFinalizer      = "cloudingress.managed.openshift.io/finalizer-cloud-ingress-controller"
 	ClusterIngressFinalizer    = "ingresscontroller.operator.openshift.io/finalizer-ingresscontroller"
-	ELBIdleTimeoutDuration     = 1800
 )
 
+// cpmsReactivationInterval is how often the reactivation of the ControlPlaneMachineSet is retried,
+// it takes about a minute for the ControlPlaneMachineSet to be recreated once deleted
+const cpmsReactivationInterval = 60 * time.Second
+
 var log = logf.Log.WithName("controller_publishingstrategy")
 
 type patchField string
@@ -61,14 +73,21 @@ var IngressControllerCertificate patchField = "IngressControllerCertificate"
 var IngressControllerNodePlacement patchField = "IngressControllerNodePlacement"
 var IngressControllerEndPoint patchField = "IngressControllerEndpoint"
 var IngressControllerDeleteLBAnnotation string = "ingress.operator.openshift.io/auto-delete-load-balancer"
-var IngressControllerELBIdleTimeout metav1.Duration = metav1.Duration{Duration: ELBIdleTimeoutDuration * time.Second}
+
+// IngressControllerELBIdleTimeout is the idle timeout of the Classic load balancers of the IngressControllers
+func IngressControllerELBIdleTimeout() metav1.Duration {
+	return metav1.Duration{Duration: time.Duration(operatorconfig.Get().ELBIdleTimeoutSeconds) * time.Second}
+}
 
 var _ reconcile.Reconciler = &PublishingStrategyReconciler{}
 
 // PublishingStrategyReconciler reconciles a PublishingStrategy object
 type PublishingStrategyReconciler struct {
-	Client client.Client
-	Scheme *runtime.Scheme
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+	// CloudClient is used instead of the client for the cluster platform when set, for testing
+	CloudClient cloudclient.CloudClient
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -101,17 +120,51 @@ func (r *PublishingStrategyReconciler) Reconcile(ctx context.Context, request ct
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
+	// Events recorded while reconciling, including by the cloud client, are about this PublishingStrategy
+	ctx = cioevents.IntoContext(ctx, r.Recorder, instance)
+
+	// Keep a copy of the status so it's only written back when something changed
+	originalStatus := instance.Status.DeepCopy()
+
+	result, err := r.reconcilePublishingStrategy(ctx, reqLogger, instance)
+	if err == nil && instance.Status.ControlPlaneMachineSetReactivation != nil && result.RequeueAfter == 0 {
+		result.RequeueAfter = cpmsReactivationInterval
+	}
+
+	setPublishingStrategyConditions(instance, result, err)
+	if !reflect.DeepEqual(*originalStatus, instance.Status) {
+		if statusErr := r.Client.Status().Update(context.TODO(), instance); statusErr != nil {
+			// TODO: Should we return an error here if this update fails?
+			reqLogger.Error(statusErr, "Error updating PublishingStrategy status")
+		}
+	}
+
+	// Retrying won't make the platform supported, the IngressControllers are
+	// reconciled again when the PublishingStrategy changes
+	var unsupported *cioerrors.UnsupportedPlatformError
+	if errors.As(err, &unsupported) {
+		err = nil
+	}
+	r.recordPublishingStrategyMetrics(ctx, instance, err)
+	return result, err
+}
 
+// reconcilePublishingStrategy brings the IngressControllers and the default API scope in line with the
+// PublishingStrategy. The observed state of each ApplicationIngress is recorded in instance.Status as it goes.
+func (r *PublishingStrategyReconciler) reconcilePublishingStrategy(ctx context.Context, reqLogger logr.Logger, instance *v1alpha1.PublishingStrategy) (reconcile.Result, error) {
 	// Retrieve the cluster base domain. Discard the error since it's just for logging messages.
 	// In case of failure, clusterBaseDomain is an empty string.
 	clusterBaseDomain, _ := baseutils.GetClusterBaseDomain(r.Client)
 
+	// Drop the status of ApplicationIngresses which have been removed from the spec
+	pruneApplicationIngressStatus(instance)
+
 	// Get all IngressControllers on cluster with an annotation that indicates cloud-ingress-operator owns it
 	ingressControllerList := &ingresscontroller.IngressControllerList{}
 	listOptions := []client.ListOption{
 		client.InNamespace("openshift-ingress-operator"),
 	}
-	err = r.Client.List(context.TODO(), ingressControllerList, listOptions...)
+	err := r.Client.List(ctx, ingressControllerList, listOptions...)
 	if err != nil {
 		log.Error(err, "Cannot get list of ingresscontroller")
 		return reconcile.Result{}, err
@@ -171,7 +224,9 @@ func (r *PublishingStrategyReconciler) Reconcile(ctx context.Context, request ct
 			// Safety check, to ensure that the default ingress controller DNS name matches the cluster's base domain
 			// This protects against malformed publishing strategies
 			if !strings.HasSuffix(ingressDefinition.DNSName, clusterBaseDomain) {
-				return reconcile.Result{}, fmt.Errorf("default ingress DNS doesn't match cluster's base domain: got %v, expected to end in %v", ingressDefinition.DNSName, clusterBaseDomain)
+				err := fmt.Errorf("default ingress DNS doesn't match cluster's base domain: got %v, expected to end in %v", ingressDefinition.DNSName, clusterBaseDomain)
+				setApplicationIngressStatus(instance, ingressDefinition.DNSName, ingressName, "", reconcile.Result{}, err)
+				return reconcile.Result{}, err
 			}
 		}
 
@@ -212,7 +267,7 @@ func (r *PublishingStrategyReconciler) Reconcile(ctx context.Context, request ct
 			// For Classic LB in v4.11+, set the ELB idle connection timeout on the IngressController
 			if ingressDefinition.Type == "Classic" && baseutils.IsVersionHigherThan("4.11") {
 				desiredIngressController.Spec.EndpointPublishingStrategy.LoadBalancer.ProviderParameters.AWS.ClassicLoadBalancerParameters = &ingresscontroller.AWSClassicLoadBalancerParameters{
-					ConnectionIdleTimeout: IngressControllerELBIdleTimeout,
+					ConnectionIdleTimeout: IngressControllerELBIdleTimeout(),
 				}
 			}
 		}
@@ -220,18 +275,23 @@ func (r *PublishingStrategyReconciler) Reconcile(ctx context.Context, request ct
 		// Attempt to find the IngressController referenced by the ApplicationIngress
 		// by doing a GET of the namespaced name object build above against the k8s api.
 		ingressController := &ingresscontroller.IngressController{}
-		err = r.Client.Get(context.TODO(), namespacedName, ingressController)
+		err = r.Client.Get(ctx, namespacedName, ingressController)
 		if err != nil {
 			// Attempt to create the CR if not found
 			if k8serr.IsNotFound(err) {
 				reqLogger.Info(fmt.Sprintf("ApplicationIngress %s not found, attempting to create", ingressName))
-				err = r.Client.Create(context.TODO(), desiredIngressController)
+				err = r.Client.Create(ctx, desiredIngressController)
 				if err != nil {
+					cioevents.Warning(ctx, cioevents.ReasonIngressControllerFailed, cioevents.ActionCreate, "Couldn't create IngressController %s: %v", ingressName, err)
+					setApplicationIngressStatus(instance, ingressDefinition.DNSName, ingressName, "", reconcile.Result{}, err)
 					return reconcile.Result{}, err
 				}
+				cioevents.Normal(ctx, cioevents.ReasonIngressControllerCreated, cioevents.ActionCreate, "Created IngressController %s", ingressName)
 				// If the CR was created, requeue PublishingStrategy
+				setApplicationIngressStatus(instance, ingressDefinition.DNSName, ingressName, v1alpha1.IngressControllerCreated, reconcile.Result{Requeue: true}, nil)
 				return reconcile.Result{Requeue: true}, nil
 			}
+			setApplicationIngressStatus(instance, ingressDefinition.DNSName, ingressName, "", reconcile.Result{}, err)
 			return reconcile.Result{}, err
 		}
 
@@ -241,28 +301,41 @@ func (r *PublishingStrategyReconciler) Reconcile(ctx context.Context, request ct
 		// When an ingresscontroller is being deleted, it takes time as it needs to delete several
 		// services (ie the load balancer service has finalizers for the cloud provider resource cleanup)
 		if !ingressController.DeletionTimestamp.IsZero() {
-			return r.ensureIngressController(reqLogger, ingressController, desiredIngressController)
+			result, err := r.ensureIngressController(ctx, reqLogger, ingressController, desiredIngressController)
+			// A delayed requeue means we're still waiting on the finalizers, otherwise the IngressController was recreated
+			action := v1alpha1.IngressControllerRecreated
+			if result.RequeueAfter > 0 {
+				action = v1alpha1.IngressControllerDeleting
+			}
+			setApplicationIngressStatus(instance, ingressDefinition.DNSName, ingressName, action, result, err)
+			return result, err
 		}
 
 		// For AWS, ensure the LB type matches between the IngressController and PublishingStrategy
 		if isAWS {
 			reqLogger.Info("Cluster is AWS, checking load balancers")
-			result, err := r.ensureAWSLoadBalancerType(reqLogger, ingressController, ingressDefinition)
+			result, err := r.ensureAWSLoadBalancerType(ctx, reqLogger, ingressController, ingressDefinition)
 			if err != nil || result.Requeue {
+				setApplicationIngressStatus(instance, ingressDefinition.DNSName, ingressName, v1alpha1.IngressControllerRecreated, result, err)
 				return result, err
 			}
 
 		}
 
-		result, err := r.ensureStaticSpec(reqLogger, ingressController, desiredIngressController)
+		result, err := r.ensureStaticSpec(ctx, reqLogger, ingressController, desiredIngressController)
 		if err != nil || result.Requeue {
+			setApplicationIngressStatus(instance, ingressDefinition.DNSName, ingressName, v1alpha1.IngressControllerRecreated, result, err)
 			return result, err
 		}
 
-		result, err = r.ensurePatchableSpec(reqLogger, ingressController, desiredIngressController)
+		result, err = r.ensurePatchableSpec(ctx, reqLogger, ingressController, desiredIngressController)
 		if err != nil || result.Requeue {
+			setApplicationIngressStatus(instance, ingressDefinition.DNSName, ingressName, v1alpha1.IngressControllerPatched, result, err)
 			return result, err
 		}
+
+		// The IngressController matches the ApplicationIngress, clear any previous error
+		setApplicationIngressStatus(instance, ingressDefinition.DNSName, ingressName, "", reconcile.Result{}, nil)
 	}
 
 	result, err = r.ensureAnnotationsDefined(reqLogger, ownedIngressExistingMap)
@@ -270,8 +343,13 @@ func (r *PublishingStrategyReconciler) Reconcile(ctx context.Context, request ct
 		return result, err
 	}
 
-	result, err = r.ensureAliasScope(reqLogger, instance, clusterBaseDomain)
-	if err != nil || result.Requeue {
+	result, err = r.ensureAliasScope(ctx, reqLogger, instance, clusterBaseDomain)
+	// The IngressControllers don't need the cloud client, so keep going without
+	// it and report the error once they're reconciled
+	var cloudClientErr error
+	if isCloudClientError(err) {
+		cloudClientErr = err
+	} else if err != nil || result.Requeue {
 		return result, err
 	}
 
@@ -285,14 +363,22 @@ func (r *PublishingStrategyReconciler) Reconcile(ctx context.Context, request ct
 			return result, err
 		}
 
-		return result, nil
+		return result, cloudClientErr
 	}
 
-	result, err = r.deleteUnpublishedIngressControllers(ownedIngressExistingMap)
+	result, err = r.deleteUnpublishedIngressControllers(ctx, ownedIngressExistingMap)
 	if err != nil || result.Requeue {
 		return result, err
 	}
-	return reconcile.Result{}, nil
+	return reconcile.Result{}, cloudClientErr
+}
+
+// isCloudClientError tells if err is about the cloud client being unavailable,
+// as opposed to an error returned by the cloud provider
+func isCloudClientError(err error) bool {
+	var unsupported *cioerrors.UnsupportedPlatformError
+	var clientErr *cioerrors.CloudClientError
+	return errors.As(err, &unsupported) || errors.As(err, &clientErr)
 }
 
 // getIngressName takes the domain name and returns the name of the IngressController CR
@@ -437,11 +523,13 @@ func ensureNoNewSecondIngressCreated(reqLogger logr.Logger, ai []v1alpha1.Applic
 	return reconcile.Result{}, nil
 }
 
-func (r *PublishingStrategyReconciler) ensureAWSLoadBalancerType(reqLogger logr.Logger, ic *ingresscontroller.IngressController, ai v1alpha1.ApplicationIngress) (result reconcile.Result, err error) {
+func (r *PublishingStrategyReconciler) ensureAWSLoadBalancerType(ctx context.Context, reqLogger logr.Logger, ic *ingresscontroller.IngressController, ai v1alpha1.ApplicationIngress) (result reconcile.Result, err error) {
 
 	if !validateAWSLoadBalancerType(*ic, ai) {
-		if err := r.Client.Delete(context.TODO(), ic); err != nil {
+		if err := r.Client.Delete(ctx, ic); err != nil {
 			reqLogger.Error(err, "Error deleting IngressController")
+		} else {
+			cioevents.Normal(ctx, cioevents.ReasonIngressControllerDeleted, cioevents.ActionDelete, "Deleted IngressController %s to change its load balancer type to %s", ic.Name, ai.Type)
 		}
 		return reconcile.Result{Requeue: true}, nil
 	}
@@ -580,27 +668,28 @@ func getIngressWithCloudIngressOpreatorOwnerAnnotation(ingressList ingresscontro
 }
 
 // deleteUnpublishedIngressControllers deletes all IngressControllers owned by cloud-ingress-controller which are not in the publishingstategy
-func (r *PublishingStrategyReconciler) deleteUnpublishedIngressControllers(ownedIngressExistingMap map[string]bool) (result reconcile.Result, err error) {
+func (r *PublishingStrategyReconciler) deleteUnpublishedIngressControllers(ctx context.Context, ownedIngressExistingMap map[string]bool) (result reconcile.Result, err error) {
 	// Delete all IngressControllers that are owned by cloud-ingress-operator but not in PublishingStrategy
 	for ingress, inPublishingStrategy := range ownedIngressExistingMap {
 		if !inPublishingStrategy {
 			// Delete requires an object referece, so we must get it first
 			ingressToDelete := &ingresscontroller.IngressController{}
-			err = r.Client.Get(context.TODO(), types.NamespacedName{Name: ingress, Namespace: ingressControllerNamespace}, ingressToDelete)
+			err = r.Client.Get(ctx, types.NamespacedName{Name: ingress, Namespace: ingressControllerNamespace}, ingressToDelete)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
-			err = r.Client.Delete(context.TODO(), ingressToDelete)
+			err = r.Client.Delete(ctx, ingressToDelete)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
+			cioevents.Normal(ctx, cioevents.ReasonIngressControllerDeleted, cioevents.ActionDelete, "Deleted IngressController %s which isn't in the PublishingStrategy anymore", ingress)
 		}
 	}
 	return result, err
 }
 
 // ensureStaticSpec deletes or marks an IngressController for deletion when a static spec has been changed in the publishing strategy
-func (r *PublishingStrategyReconciler) ensureStaticSpec(reqLogger logr.Logger, ingressController, desiredIngressController *ingresscontroller.IngressController) (result reconcile.Result, err error) {
+func (r *PublishingStrategyReconciler) ensureStaticSpec(ctx context.Context, reqLogger logr.Logger, ingressController, desiredIngressController *ingresscontroller.IngressController) (result reconcile.Result, err error) {
 	reqLogger.Info(fmt.Sprintf("Checking Static Spec for IngressController %s ", desiredIngressController.Name))
 	// Compare the Spec fields that cannot be patched in the desired IngressController and the actual IngressController
 	if !validateStaticSpec(*ingressController, desiredIngressController.Spec) {
@@ -624,8 +713,10 @@ func (r *PublishingStrategyReconciler) ensureStaticSpec(reqLogger logr.Logger, i
 					}
 				}
 				// initiate the delete => asking cluster-ingress-operator to delete the dependencies
-				if err := r.Client.Delete(context.TODO(), ingressController); err != nil {
+				if err := r.Client.Delete(ctx, ingressController); err != nil {
 					reqLogger.Error(err, "Error deleting IngressController")
+				} else {
+					cioevents.Normal(ctx, cioevents.ReasonIngressControllerDeleted, cioevents.ActionDelete, "Deleted IngressController %s to change an immutable field, it will be recreated", ingressController.Name)
 				}
 				return reconcile.Result{Requeue: true}, nil
 			}
@@ -635,8 +726,10 @@ func (r *PublishingStrategyReconciler) ensureStaticSpec(reqLogger logr.Logger, i
 			// the IngressController must be deleted
 			reqLogger.Info(fmt.Sprintf("Static Spec does not match for for IngressController %s, deleting", desiredIngressController.Name))
 			// TODO: Should we return an error here if this delete fails?
-			if err := r.Client.Delete(context.TODO(), ingressController); err != nil {
+			if err := r.Client.Delete(ctx, ingressController); err != nil {
 				reqLogger.Error(err, "Error deleting IngressController")
+			} else {
+				cioevents.Normal(ctx, cioevents.ReasonIngressControllerDeleted, cioevents.ActionDelete, "Deleted IngressController %s to change an immutable field, it will be recreated", ingressController.Name)
 			}
 			return reconcile.Result{Requeue: true}, nil
 		}
@@ -646,7 +739,7 @@ func (r *PublishingStrategyReconciler) ensureStaticSpec(reqLogger logr.Logger, i
 }
 
 // ensurePatchableSpec patches an IngressController when a patchable field as been changed in the publishingstrategy
-func (r *PublishingStrategyReconciler) ensurePatchableSpec(reqLogger logr.Logger, ingressController, desiredIngressController *ingresscontroller.IngressController) (result reconcile.Result, err error) {
+func (r *PublishingStrategyReconciler) ensurePatchableSpec(ctx context.Context, reqLogger logr.Logger, ingressController, desiredIngressController *ingresscontroller.IngressController) (result reconcile.Result, err error) {
 	reqLogger.Info(fmt.Sprintf("Checking Patchable Spec for IngressController %s ", desiredIngressController.Name))
 	// All the remaining fields are mutable and don't require a deletion of the IngresscController
 	// If any of the fields are differet, that field will be patched
@@ -664,10 +757,11 @@ func (r *PublishingStrategyReconciler) ensurePatchableSpec(reqLogger logr.Logger
 				ingressController.Spec.RouteSelector = desiredIngressController.Spec.RouteSelector
 				// Perform the patch on the existing IngressController using the base to patch against and the
 				// changes added to bring the exsting CR to the desired state
-				err = r.Client.Patch(context.TODO(), ingressController, baseToPatch)
+				err = r.Client.Patch(ctx, ingressController, baseToPatch)
 				if err != nil {
 					return reconcile.Result{}, err
 				}
+				cioevents.Normal(ctx, cioevents.ReasonIngressControllerPatched, cioevents.ActionUpdate, "Patched the RouteSelector of IngressController %s", ingressController.Name)
 				return reconcile.Result{Requeue: true}, nil
 			}
 		} else {
@@ -691,10 +785,11 @@ func (r *PublishingStrategyReconciler) ensurePatchableSpec(reqLogger logr.Logger
 
 			// Perform the patch on the existing IngressController using the base to patch against and the
 			// changes added to bring the exsting CR to the desired state
-			err = r.Client.Patch(context.TODO(), ingressController, baseToPatch)
+			err = r.Client.Patch(ctx, ingressController, baseToPatch)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
+			cioevents.Normal(ctx, cioevents.ReasonIngressControllerPatched, cioevents.ActionUpdate, "Patched the %s of IngressController %s", field, ingressController.Name)
 			return reconcile.Result{Requeue: true}, nil
 		}
 	}
@@ -773,19 +868,42 @@ func (r *PublishingStrategyReconciler) ensureAnnotationsDefined(reqLogger logr.L
 }
 
 // ensureAliasScope updates the loadbalancer to match the scope of the ingress in the publishingstrategy
-func (r *PublishingStrategyReconciler) ensureAliasScope(reqLogger logr.Logger, instance *v1alpha1.PublishingStrategy, clusterBaseDomain string) (result reconcile.Result, err error) {
+func (r *PublishingStrategyReconciler) ensureAliasScope(ctx context.Context, reqLogger logr.Logger, instance *v1alpha1.PublishingStrategy, clusterBaseDomain string) (result reconcile.Result, err error) {
 
-	cloudPlatform, err := baseutils.GetPlatformType(r.Client)
-	if err != nil {
-		log.Error(err, "Failed to create a Cloud Client")
-		return reconcile.Result{}, err
+	cloudClient := r.CloudClient
+	if cloudClient == nil {
+		cloudPlatform, err := baseutils.GetPlatformType(r.Client)
+		if err != nil {
+			log.Error(err, "Failed to create a Cloud Client")
+			return reconcile.Result{}, err
+		}
+		cloudClient, err = cloudclient.GetClientFor(r.Client, *cloudPlatform)
+		if err != nil {
+			log.Error(err, "Failed to create a Cloud Client")
+			return reconcile.Result{}, err
+		}
 	}
-	cloudClient := cloudclient.GetClientFor(r.Client, *cloudPlatform)
+
+	// Carry on setting the ControlPlaneMachineSet back to active, including
+	// when it was deleted before the operator restarted
+	if instance.Status.ControlPlaneMachineSetReactivation != nil {
+		if err := cloudClient.ReactivateControlPlaneMachineSet(ctx, r.Client, instance); err != nil {
+			reqLogger.Error(err, "Couldn't set the ControlPlaneMachineSet back to active, will retry")
+		}
+	}
+
+	// in dry-run mode, only record what changing the scope would do
+	if instance.Annotations[v1alpha1.APIScopeDryRunAnnotation] == "true" {
+		return r.planAliasScope(ctx, reqLogger, cloudClient, instance)
+	}
+	instance.Status.DefaultAPIServerIngressPlan = nil
 
 	if instance.Spec.DefaultAPIServerIngress.Listening == v1alpha1.Internal {
-		err := cloudClient.SetDefaultAPIPrivate(context.TODO(), r.Client, instance)
+		err := cloudClient.SetDefaultAPIPrivate(ctx, r.Client, instance)
+		setDefaultAPIServerIngressStatus(reqLogger, r.Client, cloudClient, instance)
 		if err != nil {
 			log.Error(err, fmt.Sprintf("Error updating api.%s alias to internal NLB", clusterBaseDomain))
+			cioevents.Warning(ctx, cioevents.ReasonAPIScopeChangeFailed, cioevents.ActionUpdate, "Couldn't make the default API internal: %v", err)
 			return reconcile.Result{}, err
 		}
 		log.Info(fmt.Sprintf("Update api.%s alias to internal NLB successful", clusterBaseDomain))
@@ -795,9 +913,11 @@ func (r *PublishingStrategyReconciler) ensureAliasScope(reqLogger logr.Logger, i
 	// if CR is wanted the default server API to be internet-facing, we
 	// create the external NLB for port 6443/TCP and add api.<cluster-name> DNS record to point to external NLB
 	if instance.Spec.DefaultAPIServerIngress.Listening == v1alpha1.External {
-		err = cloudClient.SetDefaultAPIPublic(context.TODO(), r.Client, instance)
+		err = cloudClient.SetDefaultAPIPublic(ctx, r.Client, instance)
+		setDefaultAPIServerIngressStatus(reqLogger, r.Client, cloudClient, instance)
 		if err != nil {
 			log.Error(err, fmt.Sprintf("Error updating api.%s alias to external NLB", clusterBaseDomain))
+			cioevents.Warning(ctx, cioevents.ReasonAPIScopeChangeFailed, cioevents.ActionUpdate, "Couldn't make the default API external: %v", err)
 			return reconcile.Result{}, err
 		}
 		log.Info(fmt.Sprintf("Update api.%s alias to external NLB successful", clusterBaseDomain))
@@ -806,8 +926,30 @@ func (r *PublishingStrategyReconciler) ensureAliasScope(reqLogger logr.Logger, i
 	return result, err
 }
 
+// planAliasScope records in the status the changes ensureAliasScope would make, without making them
+func (r *PublishingStrategyReconciler) planAliasScope(ctx context.Context, reqLogger logr.Logger, cloudClient cloudclient.CloudClient, instance *v1alpha1.PublishingStrategy) (reconcile.Result, error) {
+	var actions []v1alpha1.PlannedAction
+	var err error
+	switch instance.Spec.DefaultAPIServerIngress.Listening {
+	case v1alpha1.Internal:
+		actions, err = cloudClient.PlanDefaultAPIPrivate(ctx, r.Client, instance)
+	case v1alpha1.External:
+		actions, err = cloudClient.PlanDefaultAPIPublic(ctx, r.Client, instance)
+	default:
+		return reconcile.Result{}, nil
+	}
+	setDefaultAPIServerIngressStatus(reqLogger, r.Client, cloudClient, instance)
+	if err != nil {
+		log.Error(err, "Error planning the default API scope change")
+		return reconcile.Result{}, err
+	}
+	setDefaultAPIServerIngressPlan(instance, actions)
+	log.Info("Planned the default API scope change", "listening", instance.Spec.DefaultAPIServerIngress.Listening, "actions", len(actions))
+	return reconcile.Result{}, nil
+}
+
 // ensureIngressController makes sure that an IngressController being deleted, gets recreated by cloud-ingress-operator, instead of cluster-ingress-operator
-func (r *PublishingStrategyReconciler) ensureIngressController(reqLogger logr.Logger, ingressController, desiredIngressController *ingresscontroller.IngressController) (reconcile.Result, error) {
+func (r *PublishingStrategyReconciler) ensureIngressController(ctx context.Context, reqLogger logr.Logger, ingressController, desiredIngressController *ingresscontroller.IngressController) (reconcile.Result, error) {
 	// If ingresscontroller still has the ClusterIngressFinalizer, there is no point continuing.
 	// Cluster-ingress-operator typically needs a few minutes to delete all dependencies
 	if localctlutils.Contains(ingressController.GetFinalizers(), ClusterIngressFinalizer) {
@@ -832,12 +974,13 @@ func (r *PublishingStrategyReconciler) ensureIngressController(reqLogger logr.Lo
 
 	// At this point, if the IngressController still exists, and has no Finalizer
 	// Therefore, it is ready to be deleted
-	if err := r.Client.Delete(context.TODO(), ingressController); err != nil {
+	if err := r.Client.Delete(ctx, ingressController); err != nil {
 		if k8serr.IsNotFound(err) {
 			// It is possible that cluster-ingress-operator might be faster to delete the IngressController
 			// If that's the case, we proceed
 			reqLogger.Info("IngressController already deleted")
 		} else {
+			cioevents.Warning(ctx, cioevents.ReasonIngressControllerFailed, cioevents.ActionDelete, "Couldn't delete IngressController %s: %v", ingressController.Name, err)
 			reqLogger.Error(err, "Error deleting IngressController")
 			return reconcile.Result{Requeue: true}, err
 		}
@@ -846,18 +989,46 @@ func (r *PublishingStrategyReconciler) ensureIngressController(reqLogger logr.Lo
 	// At this point, the IngressController doesn't exist anymore
 	// Create the desiredIngressController (hopefully before cluster-ingress-operator did)
 	reqLogger.Info(fmt.Sprintf("Create IngressController %s", ingressController.Name))
-	if err := r.Client.Create(context.TODO(), desiredIngressController); err != nil {
+	if err := r.Client.Create(ctx, desiredIngressController); err != nil {
 		reqLogger.Error(err, "Error creating the IngressController")
+		cioevents.Warning(ctx, cioevents.ReasonIngressControllerFailed, cioevents.ActionCreate, "Couldn't recreate IngressController %s: %v", desiredIngressController.Name, err)
 		return reconcile.Result{Requeue: true}, err
 	}
+	cioevents.Normal(ctx, cioevents.ReasonIngressControllerCreated, cioevents.ActionCreate, "Recreated IngressController %s", desiredIngressController.Name)
 	// If the CR was created, requeue PublishingStrategy
 	return reconcile.Result{Requeue: true}, nil
 
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// The PublishingStrategies are also reconciled when a control plane machine is
+// created, deleted or gets its instance, so the load balancers follow the masters.
 func (r *PublishingStrategyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.PublishingStrategy{}).
+		Watches(&machineapi.Machine{},
+			handler.EnqueueRequestsFromMapFunc(r.publishingStrategiesForMachine),
+			builder.WithPredicates(
+				predicate.NewPredicateFuncs(baseutils.IsMasterMachine),
+				predicate.Funcs{
+					UpdateFunc: func(e event.UpdateEvent) bool {
+						return !reflect.DeepEqual(e.ObjectOld.(*machineapi.Machine).Spec.ProviderID, e.ObjectNew.(*machineapi.Machine).Spec.ProviderID)
+					},
+				},
+			)).
 		Complete(r)
 }
+
+// publishingStrategiesForMachine maps a control plane machine to every PublishingStrategy
+func (r *PublishingStrategyReconciler) publishingStrategiesForMachine(ctx context.Context, _ client.Object) []reconcile.Request {
+	publishingStrategies := &v1alpha1.PublishingStrategyList{}
+	if err := r.Client.List(ctx, publishingStrategies); err != nil {
+		log.Error(err, "Cannot list the PublishingStrategies")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(publishingStrategies.Items))
+	for _, ps := range publishingStrategies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ps.Namespace, Name: ps.Name}})
+	}
+	return requests
+}
@@ -453,7 +453,7 @@ func TestEnsureIngressController(t *testing.T) {
 	for _, test := range tests {
 		testClient, testScheme := setUpTestClient([]client.Object{test.IngressController}, []runtime.Object{}, test.ClientErr["on"], test.ClientErr["type"], test.ClientErr["target"])
 		r := &PublishingStrategyReconciler{Client: testClient, Scheme: testScheme}
-		result, err := r.ensureIngressController(log, test.IngressController, desiredIngressController)
+		result, err := r.ensureIngressController(context.TODO(), log, test.IngressController, desiredIngressController)
 
 		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
 			t.Fatalf("Test [%v] return mismatch. Expect error? %t: Return %+v", test.Name, test.ErrorExpected, err)
@@ -593,7 +593,7 @@ func TestDeleteUnpublishedIngressControllers(t *testing.T) {
 	for _, test := range tests {
 		testClient, testScheme := setUpTestClient([]client.Object{test.IngressController}, []runtime.Object{}, test.ClientErr["on"], test.ClientErr["type"], test.ClientErr["target"])
 		r := &PublishingStrategyReconciler{Client: testClient, Scheme: testScheme}
-		result, err := r.deleteUnpublishedIngressControllers(test.Map)
+		result, err := r.deleteUnpublishedIngressControllers(context.TODO(), test.Map)
 
 		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
 			t.Fatalf("Test [%v] return mismatch. Expect error? %t: Return %+v", test.Name, test.ErrorExpected, err)
@@ -653,7 +653,7 @@ func TestEnsureStaticSpec(t *testing.T) {
 	for _, test := range tests {
 		testClient, testScheme := setUpTestClient([]client.Object{test.IngressController}, []runtime.Object{}, test.ClientErr["on"], test.ClientErr["type"], test.ClientErr["target"])
 		r := &PublishingStrategyReconciler{Client: testClient, Scheme: testScheme}
-		result, err := r.ensureStaticSpec(log, test.IngressController, test.DesiredIngressController)
+		result, err := r.ensureStaticSpec(context.TODO(), log, test.IngressController, test.DesiredIngressController)
 
 		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
 			t.Fatalf("Test [%v] return mismatch. Expect error? %t: Return %+v", test.Name, test.ErrorExpected, err)
@@ -736,7 +736,7 @@ func TestEnsurePatchableSpec(t *testing.T) {
 	for _, test := range tests {
 		testClient, testScheme := setUpTestClient([]client.Object{test.IngressController}, []runtime.Object{}, test.ClientErr["on"], test.ClientErr["type"], test.ClientErr["target"])
 		r := &PublishingStrategyReconciler{Client: testClient, Scheme: testScheme}
-		result, err := r.ensurePatchableSpec(log, test.IngressController, test.DesiredIngressController)
+		result, err := r.ensurePatchableSpec(context.TODO(), log, test.IngressController, test.DesiredIngressController)
 
 		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
 			t.Fatalf("Test [%v] return mismatch. Expect error? %t: Return %+v", test.Name, test.ErrorExpected, err)
@@ -828,6 +828,59 @@ func TestEnsureDefaultICOwnedByClusterIngressOperator(t *testing.T) {
 	}
 }
 
+func TestEnsureAliasScopeDryRun(t *testing.T) {
+	planned := []cloudingressv1alpha1.PlannedAction{
+		{Action: cloudingressv1alpha1.PlannedActionCreate, Kind: cloudingressv1alpha1.PlannedResourceLoadBalancer, Name: "test-ext"},
+	}
+	tests := []struct {
+		Name         string
+		Annotations  map[string]string
+		Mocks        func(mockclient *MockCloudClient)
+		ExpectedPlan bool
+	}{
+		{
+			Name:        "Should only plan the change in dry-run mode",
+			Annotations: map[string]string{cloudingressv1alpha1.APIScopeDryRunAnnotation: "true"},
+			Mocks: func(mockclient *MockCloudClient) {
+				mockclient.EXPECT().PlanDefaultAPIPublic(gomock.Any(), gomock.Any(), gomock.Any()).Return(planned, nil)
+				mockclient.EXPECT().GetDefaultAPIListening(gomock.Any(), gomock.Any()).Return(cloudingressv1alpha1.Internal, nil)
+			},
+			ExpectedPlan: true,
+		},
+		{
+			Name: "Should apply the change and clear the plan otherwise",
+			Mocks: func(mockclient *MockCloudClient) {
+				mockclient.EXPECT().SetDefaultAPIPublic(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockclient.EXPECT().GetDefaultAPIListening(gomock.Any(), gomock.Any()).Return(cloudingressv1alpha1.External, nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		instance := &cloudingressv1alpha1.PublishingStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: "publishingstrategy", Annotations: test.Annotations},
+			Spec: cloudingressv1alpha1.PublishingStrategySpec{
+				DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.External},
+			},
+			Status: cloudingressv1alpha1.PublishingStrategyStatus{
+				DefaultAPIServerIngressPlan: &cloudingressv1alpha1.APIScopeChangePlan{Listening: cloudingressv1alpha1.External, Actions: planned},
+			},
+		}
+		mockcloudclient := NewMockCloudClient(gomock.NewController(t))
+		test.Mocks(mockcloudclient)
+
+		testClient, testScheme := setUpTestClient([]client.Object{}, []runtime.Object{}, "", "", "")
+		r := &PublishingStrategyReconciler{Client: testClient, Scheme: testScheme, CloudClient: mockcloudclient}
+		_, err := r.ensureAliasScope(context.TODO(), log, instance, "unit.test")
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		if (instance.Status.DefaultAPIServerIngressPlan != nil) != test.ExpectedPlan {
+			t.Fatalf("Test [%v] FAILED. Expected a plan? %t. Got %+v", test.Name, test.ExpectedPlan, instance.Status.DefaultAPIServerIngressPlan)
+		}
+	}
+}
+
 func TestReconcileGCP(t *testing.T) {
 	defaultPublishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
 		ObjectMeta: metav1.ObjectMeta{
@@ -965,6 +1018,7 @@ func TestReconcileGCP(t *testing.T) {
 			RuntimeObj: []runtime.Object{&ingresscontroller.IngressControllerList{}},
 			Mocks: func(mockclient *MockCloudClient) {
 				mockclient.EXPECT().SetDefaultAPIPublic(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				mockclient.EXPECT().GetDefaultAPIListening(gomock.Any(), gomock.Any()).Return(cloudingressv1alpha1.External, nil).AnyTimes()
 			},
 		},
 	}
@@ -984,7 +1038,7 @@ func TestReconcileGCP(t *testing.T) {
 		testClient := &customClient{fakeClient, test.ClientErr["on"], test.ClientErr["type"], test.ClientErr["target"]}
 
 		mockcloudclient := NewMockCloudClient(gomock.NewController(t))
-		cloudclient.Register(gcp.ClientIdentifier, func(kclient client.Client) cloudclient.CloudClient { return mockcloudclient })
+		cloudclient.Register(gcp.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
 		test.Mocks(mockcloudclient)
 
 		r := &PublishingStrategyReconciler{Client: testClient, Scheme: testScheme}
@@ -1048,6 +1102,7 @@ func TestReconcileUserManagedIngressFeature(t *testing.T) {
 			ErrorReason:    "InternalError",
 			Mocks: func(mockclient *MockCloudClient) {
 				mockclient.EXPECT().SetDefaultAPIPublic(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockclient.EXPECT().GetDefaultAPIListening(gomock.Any(), gomock.Any()).Return(cloudingressv1alpha1.External, nil)
 			},
 		},
 		{
@@ -1061,6 +1116,7 @@ func TestReconcileUserManagedIngressFeature(t *testing.T) {
 			ErrorExpected:  false,
 			Mocks: func(mockclient *MockCloudClient) {
 				mockclient.EXPECT().SetDefaultAPIPublic(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockclient.EXPECT().GetDefaultAPIListening(gomock.Any(), gomock.Any()).Return(cloudingressv1alpha1.External, nil)
 			},
 		},
 	}
@@ -1091,7 +1147,7 @@ func TestReconcileUserManagedIngressFeature(t *testing.T) {
 
 		// Mock calls to cloud client to ensure that API ingress matches default API server ingress
 		mockcloudclient := NewMockCloudClient(gomock.NewController(t))
-		cloudclient.Register(aws.ClientIdentifier, func(kclient client.Client) cloudclient.CloudClient { return mockcloudclient })
+		cloudclient.Register(aws.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
 		test.Mocks(mockcloudclient)
 
 		// Create the client with the scheme and objects, then wrap it in our custom client
@@ -1245,6 +1301,7 @@ func TestReconcileAWS(t *testing.T) {
 			RuntimeObj: []runtime.Object{&ingresscontroller.IngressControllerList{}},
 			Mocks: func(mockclient *MockCloudClient) {
 				mockclient.EXPECT().SetDefaultAPIPublic(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				mockclient.EXPECT().GetDefaultAPIListening(gomock.Any(), gomock.Any()).Return(cloudingressv1alpha1.External, nil).AnyTimes()
 			},
 		},
 		{
@@ -1268,7 +1325,7 @@ func TestReconcileAWS(t *testing.T) {
 
 		// Mock calls to cloud client to ensure that API ingress matches default API server ingress
 		mockcloudclient := NewMockCloudClient(gomock.NewController(t))
-		cloudclient.Register(aws.ClientIdentifier, func(kclient client.Client) cloudclient.CloudClient { return mockcloudclient })
+		cloudclient.Register(aws.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
 		test.Mocks(mockcloudclient)
 
 		// Create the client with the scheme and objects, then wrap it in our custom client
@@ -1371,6 +1428,7 @@ func TestReconcileAWSNLB(t *testing.T) {
 			RuntimeObj: []runtime.Object{&ingresscontroller.IngressControllerList{}},
 			Mocks: func(mockclient *MockCloudClient) {
 				mockclient.EXPECT().SetDefaultAPIPublic(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				mockclient.EXPECT().GetDefaultAPIListening(gomock.Any(), gomock.Any()).Return(cloudingressv1alpha1.External, nil).AnyTimes()
 			},
 		},
 		{
@@ -1393,7 +1451,7 @@ func TestReconcileAWSNLB(t *testing.T) {
 		test.RuntimeObj = append(test.RuntimeObj, infraObj)
 
 		mockcloudclient := NewMockCloudClient(gomock.NewController(t))
-		cloudclient.Register(aws.ClientIdentifier, func(kclient client.Client) cloudclient.CloudClient { return mockcloudclient })
+		cloudclient.Register(aws.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
 		test.Mocks(mockcloudclient)
 
 		// Create the client with the scheme and objects, then wrap it in our custom client
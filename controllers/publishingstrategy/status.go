@@ -0,0 +1,148 @@
+package publishingstrategy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+)
+
+// setApplicationIngressStatus records the outcome of reconciling an ApplicationIngress.
+// The action is only recorded when it was carried out, that is when the step requeued without an error.
+func setApplicationIngressStatus(instance *v1alpha1.PublishingStrategy, dnsName, ingressName string, action v1alpha1.IngressControllerAction, result reconcile.Result, err error) {
+	var ingressStatus *v1alpha1.ApplicationIngressStatus
+	for i := range instance.Status.ApplicationIngress {
+		if instance.Status.ApplicationIngress[i].DNSName == dnsName {
+			ingressStatus = &instance.Status.ApplicationIngress[i]
+			break
+		}
+	}
+	if ingressStatus == nil {
+		instance.Status.ApplicationIngress = append(instance.Status.ApplicationIngress, v1alpha1.ApplicationIngressStatus{DNSName: dnsName})
+		ingressStatus = &instance.Status.ApplicationIngress[len(instance.Status.ApplicationIngress)-1]
+	}
+
+	ingressStatus.IngressControllerName = ingressName
+	if action != "" && err == nil && result.Requeue {
+		now := metav1.Now()
+		ingressStatus.LastAction = action
+		ingressStatus.LastActionTime = &now
+	}
+	ingressStatus.LastError = ""
+	if err != nil {
+		ingressStatus.LastError = err.Error()
+	}
+}
+
+// pruneApplicationIngressStatus removes the status of ApplicationIngresses which are no longer in the spec
+func pruneApplicationIngressStatus(instance *v1alpha1.PublishingStrategy) {
+	inSpec := make(map[string]bool, len(instance.Spec.ApplicationIngress))
+	for _, ingressDefinition := range instance.Spec.ApplicationIngress {
+		inSpec[ingressDefinition.DNSName] = true
+	}
+
+	var ingressStatuses []v1alpha1.ApplicationIngressStatus
+	for _, ingressStatus := range instance.Status.ApplicationIngress {
+		if inSpec[ingressStatus.DNSName] {
+			ingressStatuses = append(ingressStatuses, ingressStatus)
+		}
+	}
+	instance.Status.ApplicationIngress = ingressStatuses
+}
+
+// setDefaultAPIServerIngressStatus records the default API scope as reported by the cloud provider.
+// A failure to read it is only logged, the previously observed value is kept.
+func setDefaultAPIServerIngressStatus(reqLogger logr.Logger, kclient client.Client, cloudClient cloudclient.CloudClient, instance *v1alpha1.PublishingStrategy) {
+	listening, err := cloudClient.GetDefaultAPIListening(context.TODO(), kclient)
+	if err != nil {
+		reqLogger.Error(err, "Couldn't get the default API scope from the cloud provider")
+		return
+	}
+	instance.Status.DefaultAPIServerIngress.Listening = listening
+}
+
+// setDefaultAPIServerIngressPlan records the actions planned in dry-run mode.
+// The plan is cleared when there is nothing to do, and its GeneratedTime is only
+// bumped when the plan changes, so that reconciles don't keep updating the status.
+func setDefaultAPIServerIngressPlan(instance *v1alpha1.PublishingStrategy, actions []v1alpha1.PlannedAction) {
+	if len(actions) == 0 {
+		instance.Status.DefaultAPIServerIngressPlan = nil
+		return
+	}
+	listening := instance.Spec.DefaultAPIServerIngress.Listening
+	plan := instance.Status.DefaultAPIServerIngressPlan
+	if plan != nil && plan.Listening == listening && reflect.DeepEqual(plan.Actions, actions) {
+		return
+	}
+	instance.Status.DefaultAPIServerIngressPlan = &v1alpha1.APIScopeChangePlan{
+		Listening:     listening,
+		GeneratedTime: metav1.Now(),
+		Actions:       actions,
+	}
+}
+
+// setPublishingStrategyConditions sets the Ready, Progressing and Degraded conditions from the outcome of a reconcile
+func setPublishingStrategyConditions(instance *v1alpha1.PublishingStrategy, result reconcile.Result, err error) {
+	instance.Status.ObservedGeneration = instance.Generation
+
+	observedListening := instance.Status.DefaultAPIServerIngress.Listening
+	switch {
+	case isCloudClientError(err):
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyReady, metav1.ConditionFalse, v1alpha1.ReasonCloudClientError, err.Error())
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyProgressing, metav1.ConditionFalse, v1alpha1.ReasonCloudClientError, "The default API scope can't be managed without a cloud client")
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyDegraded, metav1.ConditionTrue, v1alpha1.ReasonCloudClientError, err.Error())
+	case err != nil:
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyReady, metav1.ConditionFalse, v1alpha1.ReasonReconcileFailed, err.Error())
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyProgressing, metav1.ConditionFalse, v1alpha1.ReasonReconcileFailed, "Reconcile will be retried")
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyDegraded, metav1.ConditionTrue, v1alpha1.ReasonReconcileFailed, err.Error())
+	case instance.Status.ControlPlaneMachineSetReactivation != nil:
+		reactivation := instance.Status.ControlPlaneMachineSetReactivation
+		message := fmt.Sprintf("Setting the ControlPlaneMachineSet back to active, next step is %s", reactivation.Phase)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyReady, metav1.ConditionFalse, v1alpha1.ReasonControlPlaneMachineSetReactivating, message)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyProgressing, metav1.ConditionTrue, v1alpha1.ReasonControlPlaneMachineSetReactivating, message)
+		if reactivation.LastError != "" {
+			message := fmt.Sprintf("Setting the ControlPlaneMachineSet back to active failed %d times: %s", reactivation.Attempts, reactivation.LastError)
+			setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyDegraded, metav1.ConditionTrue, v1alpha1.ReasonControlPlaneMachineSetReactivationFailing, message)
+		} else {
+			setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyDegraded, metav1.ConditionFalse, v1alpha1.ReasonAsExpected, "")
+		}
+	case result.Requeue || result.RequeueAfter > 0:
+		message := "Waiting for the IngressControllers to match the ApplicationIngresses"
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyReady, metav1.ConditionFalse, v1alpha1.ReasonIngressControllerChanging, message)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyProgressing, metav1.ConditionTrue, v1alpha1.ReasonIngressControllerChanging, message)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyDegraded, metav1.ConditionFalse, v1alpha1.ReasonAsExpected, "")
+	case instance.Status.DefaultAPIServerIngressPlan != nil:
+		plan := instance.Status.DefaultAPIServerIngressPlan
+		message := fmt.Sprintf("Changing the default API to %s is planned with %d actions, remove the %s annotation to apply it", plan.Listening, len(plan.Actions), v1alpha1.APIScopeDryRunAnnotation)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyReady, metav1.ConditionFalse, v1alpha1.ReasonAPIScopeChangePlanned, message)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyProgressing, metav1.ConditionFalse, v1alpha1.ReasonAPIScopeChangePlanned, message)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyDegraded, metav1.ConditionFalse, v1alpha1.ReasonAsExpected, "")
+	case observedListening != "" && observedListening != instance.Spec.DefaultAPIServerIngress.Listening:
+		message := fmt.Sprintf("Default API is %s, expected %s", observedListening, instance.Spec.DefaultAPIServerIngress.Listening)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyReady, metav1.ConditionFalse, v1alpha1.ReasonDefaultAPIScopeMismatch, message)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyProgressing, metav1.ConditionTrue, v1alpha1.ReasonDefaultAPIScopeMismatch, message)
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyDegraded, metav1.ConditionFalse, v1alpha1.ReasonAsExpected, "")
+	default:
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyReady, metav1.ConditionTrue, v1alpha1.ReasonAsExpected, "PublishingStrategy has been applied")
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyProgressing, metav1.ConditionFalse, v1alpha1.ReasonAsExpected, "")
+		setPublishingStrategyCondition(instance, v1alpha1.PublishingStrategyDegraded, metav1.ConditionFalse, v1alpha1.ReasonAsExpected, "")
+	}
+}
+
+func setPublishingStrategyCondition(instance *v1alpha1.PublishingStrategy, conditionType v1alpha1.PublishingStrategyConditionType, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               string(conditionType),
+		Status:             status,
+		ObservedGeneration: instance.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
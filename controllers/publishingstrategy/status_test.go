@@ -0,0 +1,496 @@
+package publishingstrategy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/gcp"
+	. "github.com/openshift/cloud-ingress-operator/pkg/cloudclient/mock_cloudclient"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	"github.com/openshift/cloud-ingress-operator/pkg/ingresscontroller"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestSetApplicationIngressStatus(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Existing       []cloudingressv1alpha1.ApplicationIngressStatus
+		Action         cloudingressv1alpha1.IngressControllerAction
+		Result         reconcile.Result
+		Err            error
+		ExpectedAction cloudingressv1alpha1.IngressControllerAction
+		ExpectedError  string
+	}{
+		{
+			Name:           "Should record the action when the step requeued",
+			Action:         cloudingressv1alpha1.IngressControllerPatched,
+			Result:         reconcile.Result{Requeue: true},
+			ExpectedAction: cloudingressv1alpha1.IngressControllerPatched,
+		},
+		{
+			Name:          "Should record the error without an action when the step failed",
+			Action:        cloudingressv1alpha1.IngressControllerRecreated,
+			Result:        reconcile.Result{Requeue: true},
+			Err:           errors.New("failed to add finalizer"),
+			ExpectedError: "failed to add finalizer",
+		},
+		{
+			Name: "Should keep the previous action and clear the error when nothing changed",
+			Existing: []cloudingressv1alpha1.ApplicationIngressStatus{
+				{DNSName: "apps.unit.test", IngressControllerName: "default", LastAction: cloudingressv1alpha1.IngressControllerCreated, LastError: "boom"},
+			},
+			ExpectedAction: cloudingressv1alpha1.IngressControllerCreated,
+		},
+	}
+
+	for _, test := range tests {
+		instance := &cloudingressv1alpha1.PublishingStrategy{
+			Status: cloudingressv1alpha1.PublishingStrategyStatus{ApplicationIngress: test.Existing},
+		}
+		setApplicationIngressStatus(instance, "apps.unit.test", "default", test.Action, test.Result, test.Err)
+
+		if len(instance.Status.ApplicationIngress) != 1 {
+			t.Fatalf("Test [%v] FAILED. Expected 1 ApplicationIngress status, got %d", test.Name, len(instance.Status.ApplicationIngress))
+		}
+		ingressStatus := instance.Status.ApplicationIngress[0]
+		if ingressStatus.IngressControllerName != "default" {
+			t.Fatalf("Test [%v] FAILED. Expected IngressController default, got %v", test.Name, ingressStatus.IngressControllerName)
+		}
+		if ingressStatus.LastAction != test.ExpectedAction {
+			t.Fatalf("Test [%v] FAILED. Expected action %v, got %v", test.Name, test.ExpectedAction, ingressStatus.LastAction)
+		}
+		if ingressStatus.LastError != test.ExpectedError {
+			t.Fatalf("Test [%v] FAILED. Expected error %q, got %q", test.Name, test.ExpectedError, ingressStatus.LastError)
+		}
+	}
+}
+
+func TestPruneApplicationIngressStatus(t *testing.T) {
+	instance := &cloudingressv1alpha1.PublishingStrategy{
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{{DNSName: "apps.unit.test", Default: true}},
+		},
+		Status: cloudingressv1alpha1.PublishingStrategyStatus{
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngressStatus{
+				{DNSName: "apps.unit.test"},
+				{DNSName: "apps2.unit.test"},
+			},
+		},
+	}
+
+	pruneApplicationIngressStatus(instance)
+
+	if len(instance.Status.ApplicationIngress) != 1 || instance.Status.ApplicationIngress[0].DNSName != "apps.unit.test" {
+		t.Fatalf("Expected only apps.unit.test to be kept, got %+v", instance.Status.ApplicationIngress)
+	}
+}
+
+func TestSetPublishingStrategyConditions(t *testing.T) {
+	tests := []struct {
+		Name              string
+		Result            reconcile.Result
+		Err               error
+		ObservedListening cloudingressv1alpha1.Listening
+		Plan              *cloudingressv1alpha1.APIScopeChangePlan
+		Reactivation      *cloudingressv1alpha1.ControlPlaneMachineSetReactivation
+		Ready             metav1.ConditionStatus
+		Progressing       metav1.ConditionStatus
+		Degraded          metav1.ConditionStatus
+		Reason            string
+	}{
+		{
+			Name:              "Should be ready when the reconcile completed",
+			ObservedListening: cloudingressv1alpha1.External,
+			Ready:             metav1.ConditionTrue,
+			Progressing:       metav1.ConditionFalse,
+			Degraded:          metav1.ConditionFalse,
+			Reason:            cloudingressv1alpha1.ReasonAsExpected,
+		},
+		{
+			Name:        "Should be progressing when the reconcile requeued",
+			Result:      reconcile.Result{Requeue: true},
+			Ready:       metav1.ConditionFalse,
+			Progressing: metav1.ConditionTrue,
+			Degraded:    metav1.ConditionFalse,
+			Reason:      cloudingressv1alpha1.ReasonIngressControllerChanging,
+		},
+		{
+			Name:        "Should be degraded when the reconcile failed",
+			Err:         errors.New("boom"),
+			Ready:       metav1.ConditionFalse,
+			Progressing: metav1.ConditionFalse,
+			Degraded:    metav1.ConditionTrue,
+			Reason:      cloudingressv1alpha1.ReasonReconcileFailed,
+		},
+		{
+			Name:        "Should be degraded when there is no cloud client",
+			Err:         cioerrors.NewUnsupportedPlatformError("BareMetal"),
+			Ready:       metav1.ConditionFalse,
+			Progressing: metav1.ConditionFalse,
+			Degraded:    metav1.ConditionTrue,
+			Reason:      cloudingressv1alpha1.ReasonCloudClientError,
+		},
+		{
+			Name:        "Should be degraded when the cloud client couldn't be created",
+			Err:         cioerrors.NewCloudClientError("AWS", errors.New("secret not found")),
+			Ready:       metav1.ConditionFalse,
+			Progressing: metav1.ConditionFalse,
+			Degraded:    metav1.ConditionTrue,
+			Reason:      cloudingressv1alpha1.ReasonCloudClientError,
+		},
+		{
+			Name:              "Should be progressing when the observed API scope differs from the spec",
+			ObservedListening: cloudingressv1alpha1.Internal,
+			Ready:             metav1.ConditionFalse,
+			Progressing:       metav1.ConditionTrue,
+			Degraded:          metav1.ConditionFalse,
+			Reason:            cloudingressv1alpha1.ReasonDefaultAPIScopeMismatch,
+		},
+		{
+			Name:              "Should not be progressing when the API scope change is only planned",
+			ObservedListening: cloudingressv1alpha1.Internal,
+			Plan: &cloudingressv1alpha1.APIScopeChangePlan{
+				Listening: cloudingressv1alpha1.External,
+				Actions:   []cloudingressv1alpha1.PlannedAction{{Action: cloudingressv1alpha1.PlannedActionCreate, Kind: cloudingressv1alpha1.PlannedResourceLoadBalancer}},
+			},
+			Ready:       metav1.ConditionFalse,
+			Progressing: metav1.ConditionFalse,
+			Degraded:    metav1.ConditionFalse,
+			Reason:      cloudingressv1alpha1.ReasonAPIScopeChangePlanned,
+		},
+		{
+			Name:              "Should be progressing while the ControlPlaneMachineSet is reactivated",
+			Result:            reconcile.Result{RequeueAfter: cpmsReactivationInterval},
+			ObservedListening: cloudingressv1alpha1.Internal,
+			Reactivation:      &cloudingressv1alpha1.ControlPlaneMachineSetReactivation{Phase: cloudingressv1alpha1.CPMSReactivationRemovingLoadBalancers},
+			Ready:             metav1.ConditionFalse,
+			Progressing:       metav1.ConditionTrue,
+			Degraded:          metav1.ConditionFalse,
+			Reason:            cloudingressv1alpha1.ReasonControlPlaneMachineSetReactivating,
+		},
+		{
+			Name:              "Should be degraded when the ControlPlaneMachineSet reactivation is failing",
+			Result:            reconcile.Result{RequeueAfter: cpmsReactivationInterval},
+			ObservedListening: cloudingressv1alpha1.Internal,
+			Reactivation: &cloudingressv1alpha1.ControlPlaneMachineSetReactivation{
+				Phase:     cloudingressv1alpha1.CPMSReactivationActivating,
+				Attempts:  2,
+				LastError: "conflict",
+			},
+			Ready:       metav1.ConditionFalse,
+			Progressing: metav1.ConditionTrue,
+			Degraded:    metav1.ConditionTrue,
+			Reason:      cloudingressv1alpha1.ReasonControlPlaneMachineSetReactivating,
+		},
+	}
+
+	for _, test := range tests {
+		instance := &cloudingressv1alpha1.PublishingStrategy{
+			ObjectMeta: metav1.ObjectMeta{Generation: 3},
+			Spec: cloudingressv1alpha1.PublishingStrategySpec{
+				DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.External},
+			},
+			Status: cloudingressv1alpha1.PublishingStrategyStatus{
+				DefaultAPIServerIngress:            cloudingressv1alpha1.DefaultAPIServerIngress{Listening: test.ObservedListening},
+				DefaultAPIServerIngressPlan:        test.Plan,
+				ControlPlaneMachineSetReactivation: test.Reactivation,
+			},
+		}
+
+		setPublishingStrategyConditions(instance, test.Result, test.Err)
+
+		if instance.Status.ObservedGeneration != 3 {
+			t.Fatalf("Test [%v] FAILED. Expected observedGeneration 3, got %d", test.Name, instance.Status.ObservedGeneration)
+		}
+		for conditionType, expected := range map[cloudingressv1alpha1.PublishingStrategyConditionType]metav1.ConditionStatus{
+			cloudingressv1alpha1.PublishingStrategyReady:       test.Ready,
+			cloudingressv1alpha1.PublishingStrategyProgressing: test.Progressing,
+			cloudingressv1alpha1.PublishingStrategyDegraded:    test.Degraded,
+		} {
+			condition := meta.FindStatusCondition(instance.Status.Conditions, string(conditionType))
+			if condition == nil {
+				t.Fatalf("Test [%v] FAILED. Missing condition %v", test.Name, conditionType)
+			}
+			if condition.Status != expected {
+				t.Fatalf("Test [%v] FAILED. Expected %v to be %v, got %v", test.Name, conditionType, expected, condition.Status)
+			}
+		}
+		if ready := meta.FindStatusCondition(instance.Status.Conditions, string(cloudingressv1alpha1.PublishingStrategyReady)); ready.Reason != test.Reason {
+			t.Fatalf("Test [%v] FAILED. Expected reason %v, got %v", test.Name, test.Reason, ready.Reason)
+		}
+	}
+}
+
+func TestSetDefaultAPIServerIngressPlan(t *testing.T) {
+	generated := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	actions := []cloudingressv1alpha1.PlannedAction{
+		{Action: cloudingressv1alpha1.PlannedActionCreate, Kind: cloudingressv1alpha1.PlannedResourceLoadBalancer, Name: "test-ext"},
+	}
+	instance := &cloudingressv1alpha1.PublishingStrategy{
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.External},
+		},
+		Status: cloudingressv1alpha1.PublishingStrategyStatus{
+			DefaultAPIServerIngressPlan: &cloudingressv1alpha1.APIScopeChangePlan{
+				Listening:     cloudingressv1alpha1.External,
+				GeneratedTime: generated,
+				Actions:       actions,
+			},
+		},
+	}
+
+	setDefaultAPIServerIngressPlan(instance, actions)
+	if !instance.Status.DefaultAPIServerIngressPlan.GeneratedTime.Equal(&generated) {
+		t.Fatalf("Expected the generated time of an unchanged plan to be kept, got %v", instance.Status.DefaultAPIServerIngressPlan.GeneratedTime)
+	}
+
+	instance.Spec.DefaultAPIServerIngress.Listening = cloudingressv1alpha1.Internal
+	setDefaultAPIServerIngressPlan(instance, actions)
+	if instance.Status.DefaultAPIServerIngressPlan.GeneratedTime.Equal(&generated) || instance.Status.DefaultAPIServerIngressPlan.Listening != cloudingressv1alpha1.Internal {
+		t.Fatalf("Expected a new plan for the internal scope, got %+v", instance.Status.DefaultAPIServerIngressPlan)
+	}
+
+	setDefaultAPIServerIngressPlan(instance, nil)
+	if instance.Status.DefaultAPIServerIngressPlan != nil {
+		t.Fatalf("Expected the plan to be cleared when there is nothing to do, got %+v", instance.Status.DefaultAPIServerIngressPlan)
+	}
+}
+
+func TestReconcileUpdatesStatus(t *testing.T) {
+	publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "publishingstrategy",
+			Namespace:  "openshift-cloud-ingress-operator",
+			Generation: 2,
+		},
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.External},
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{
+				{
+					Default:       true,
+					DNSName:       "my.unit.test",
+					Listening:     "external",
+					Certificate:   corev1.SecretReference{Name: "test-cert-bundle-secret", Namespace: "openshift-ingress-operator"},
+					RouteSelector: metav1.LabelSelector{MatchLabels: map[string]string{}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		Name                string
+		ClientObj           []client.Object
+		Mocks               func(mockclient *MockCloudClient)
+		ExpectedAction      cloudingressv1alpha1.IngressControllerAction
+		ExpectedReady       metav1.ConditionStatus
+		ExpectedListening   cloudingressv1alpha1.Listening
+		ExpectedProgressing metav1.ConditionStatus
+	}{
+		{
+			Name:                "Should report the created IngressController as progressing",
+			ClientObj:           []client.Object{publishingStrategy.DeepCopy()},
+			Mocks:               func(mockclient *MockCloudClient) {},
+			ExpectedAction:      cloudingressv1alpha1.IngressControllerCreated,
+			ExpectedReady:       metav1.ConditionFalse,
+			ExpectedProgressing: metav1.ConditionTrue,
+		},
+		{
+			Name:      "Should report ready with the observed API scope once converged",
+			ClientObj: []client.Object{publishingStrategy.DeepCopy(), makeIngressControllerCRForPatch("default", "external", []string{ClusterIngressFinalizer})},
+			Mocks: func(mockclient *MockCloudClient) {
+				mockclient.EXPECT().SetDefaultAPIPublic(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockclient.EXPECT().GetDefaultAPIListening(gomock.Any(), gomock.Any()).Return(cloudingressv1alpha1.External, nil)
+			},
+			ExpectedReady:       metav1.ConditionTrue,
+			ExpectedListening:   cloudingressv1alpha1.External,
+			ExpectedProgressing: metav1.ConditionFalse,
+		},
+	}
+
+	for _, test := range tests {
+		infraObj := testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		runtimeObj := []runtime.Object{&ingresscontroller.IngressControllerList{}, infraObj}
+		testScheme := setupLocalV1alpha1Scheme(test.ClientObj, runtimeObj)
+		testScheme.AddKnownTypes(schema.GroupVersion{Group: "config.openshift.io", Version: "v1"}, infraObj)
+
+		testClient := fake.NewClientBuilder().
+			WithScheme(testScheme).
+			WithRuntimeObjects(runtimeObj...).
+			WithObjects(test.ClientObj...).
+			WithStatusSubresource(&cloudingressv1alpha1.PublishingStrategy{}).
+			Build()
+
+		mockcloudclient := NewMockCloudClient(gomock.NewController(t))
+		cloudclient.Register(gcp.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
+		test.Mocks(mockcloudclient)
+
+		r := &PublishingStrategyReconciler{Client: testClient, Scheme: testScheme}
+		namespacedName := types.NamespacedName{Name: "publishingstrategy", Namespace: "openshift-cloud-ingress-operator"}
+		if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: namespacedName}); err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+
+		updated := &cloudingressv1alpha1.PublishingStrategy{}
+		if err := testClient.Get(context.TODO(), namespacedName, updated); err != nil {
+			t.Fatalf("Test [%v] FAILED. Couldn't get PublishingStrategy: %v", test.Name, err)
+		}
+		if updated.Status.ObservedGeneration != 2 {
+			t.Fatalf("Test [%v] FAILED. Expected observedGeneration 2, got %d", test.Name, updated.Status.ObservedGeneration)
+		}
+		if !meta.IsStatusConditionPresentAndEqual(updated.Status.Conditions, string(cloudingressv1alpha1.PublishingStrategyReady), test.ExpectedReady) {
+			t.Fatalf("Test [%v] FAILED. Expected Ready %v, got %+v", test.Name, test.ExpectedReady, updated.Status.Conditions)
+		}
+		if !meta.IsStatusConditionPresentAndEqual(updated.Status.Conditions, string(cloudingressv1alpha1.PublishingStrategyProgressing), test.ExpectedProgressing) {
+			t.Fatalf("Test [%v] FAILED. Expected Progressing %v, got %+v", test.Name, test.ExpectedProgressing, updated.Status.Conditions)
+		}
+		if updated.Status.DefaultAPIServerIngress.Listening != test.ExpectedListening {
+			t.Fatalf("Test [%v] FAILED. Expected listening %v, got %v", test.Name, test.ExpectedListening, updated.Status.DefaultAPIServerIngress.Listening)
+		}
+		if len(updated.Status.ApplicationIngress) != 1 {
+			t.Fatalf("Test [%v] FAILED. Expected 1 ApplicationIngress status, got %+v", test.Name, updated.Status.ApplicationIngress)
+		}
+		if updated.Status.ApplicationIngress[0].IngressControllerName != "default" || updated.Status.ApplicationIngress[0].LastAction != test.ExpectedAction {
+			t.Fatalf("Test [%v] FAILED. Unexpected ApplicationIngress status %+v", test.Name, updated.Status.ApplicationIngress[0])
+		}
+	}
+}
+
+func TestReconcileUnsupportedPlatform(t *testing.T) {
+	publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "publishingstrategy",
+			Namespace:  "openshift-cloud-ingress-operator",
+			Generation: 2,
+		},
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.External},
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{
+				{
+					Default:       true,
+					DNSName:       "my.unit.test",
+					Listening:     "external",
+					Certificate:   corev1.SecretReference{Name: "test-cert-bundle-secret", Namespace: "openshift-ingress-operator"},
+					RouteSelector: metav1.LabelSelector{MatchLabels: map[string]string{}},
+				},
+			},
+		},
+	}
+	clientObj := []client.Object{publishingStrategy, makeIngressControllerCRForPatch("default", "external", []string{ClusterIngressFinalizer})}
+
+	infraObj := testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	infraObj.Status.PlatformStatus.Type = "BareMetal"
+	runtimeObj := []runtime.Object{&ingresscontroller.IngressControllerList{}, infraObj}
+	testScheme := setupLocalV1alpha1Scheme(clientObj, runtimeObj)
+	testScheme.AddKnownTypes(schema.GroupVersion{Group: "config.openshift.io", Version: "v1"}, infraObj)
+
+	testClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithRuntimeObjects(runtimeObj...).
+		WithObjects(clientObj...).
+		WithStatusSubresource(&cloudingressv1alpha1.PublishingStrategy{}).
+		Build()
+
+	r := &PublishingStrategyReconciler{Client: testClient, Scheme: testScheme}
+	namespacedName := types.NamespacedName{Name: "publishingstrategy", Namespace: "openshift-cloud-ingress-operator"}
+	// Retrying won't help, so no error is returned
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: namespacedName}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	updated := &cloudingressv1alpha1.PublishingStrategy{}
+	if err := testClient.Get(context.TODO(), namespacedName, updated); err != nil {
+		t.Fatalf("Couldn't get PublishingStrategy: %v", err)
+	}
+	degraded := meta.FindStatusCondition(updated.Status.Conditions, string(cloudingressv1alpha1.PublishingStrategyDegraded))
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != cloudingressv1alpha1.ReasonCloudClientError {
+		t.Fatalf("Expected Degraded with reason %s, got %+v", cloudingressv1alpha1.ReasonCloudClientError, updated.Status.Conditions)
+	}
+	// The IngressControllers are still reconciled
+	if len(updated.Status.ApplicationIngress) != 1 || updated.Status.ApplicationIngress[0].IngressControllerName != "default" || updated.Status.ApplicationIngress[0].LastError != "" {
+		t.Fatalf("Unexpected ApplicationIngress status %+v", updated.Status.ApplicationIngress)
+	}
+}
+
+func TestReconcileResumesCPMSReactivation(t *testing.T) {
+	publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "publishingstrategy",
+			Namespace: "openshift-cloud-ingress-operator",
+		},
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.Internal},
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{
+				{
+					Default:       true,
+					DNSName:       "my.unit.test",
+					Listening:     "external",
+					Certificate:   corev1.SecretReference{Name: "test-cert-bundle-secret", Namespace: "openshift-ingress-operator"},
+					RouteSelector: metav1.LabelSelector{MatchLabels: map[string]string{}},
+				},
+			},
+		},
+		// Left behind by an operator restart
+		Status: cloudingressv1alpha1.PublishingStrategyStatus{
+			ControlPlaneMachineSetReactivation: &cloudingressv1alpha1.ControlPlaneMachineSetReactivation{
+				Phase:         cloudingressv1alpha1.CPMSReactivationRemovingLoadBalancers,
+				LoadBalancers: []string{"basename-api"},
+			},
+		},
+	}
+	clientObj := []client.Object{publishingStrategy, makeIngressControllerCRForPatch("default", "external", []string{ClusterIngressFinalizer})}
+
+	infraObj := testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	runtimeObj := []runtime.Object{&ingresscontroller.IngressControllerList{}, infraObj}
+	testScheme := setupLocalV1alpha1Scheme(clientObj, runtimeObj)
+	testScheme.AddKnownTypes(schema.GroupVersion{Group: "config.openshift.io", Version: "v1"}, infraObj)
+
+	testClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithRuntimeObjects(runtimeObj...).
+		WithObjects(clientObj...).
+		WithStatusSubresource(&cloudingressv1alpha1.PublishingStrategy{}).
+		Build()
+
+	mockcloudclient := NewMockCloudClient(gomock.NewController(t))
+	cloudclient.Register(gcp.ClientIdentifier, func(kclient client.Client) (cloudclient.CloudClient, error) { return mockcloudclient, nil })
+	// The CPMS hasn't been recreated yet, so the reactivation doesn't move on
+	mockcloudclient.EXPECT().ReactivateControlPlaneMachineSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockcloudclient.EXPECT().SetDefaultAPIPrivate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockcloudclient.EXPECT().GetDefaultAPIListening(gomock.Any(), gomock.Any()).Return(cloudingressv1alpha1.Internal, nil)
+
+	r := &PublishingStrategyReconciler{Client: testClient, Scheme: testScheme}
+	namespacedName := types.NamespacedName{Name: "publishingstrategy", Namespace: "openshift-cloud-ingress-operator"}
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: namespacedName})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if result.RequeueAfter != cpmsReactivationInterval {
+		t.Fatalf("Expected the reactivation to be retried after %v, got %+v", cpmsReactivationInterval, result)
+	}
+
+	updated := &cloudingressv1alpha1.PublishingStrategy{}
+	if err := testClient.Get(context.TODO(), namespacedName, updated); err != nil {
+		t.Fatalf("Couldn't get PublishingStrategy: %v", err)
+	}
+	if updated.Status.ControlPlaneMachineSetReactivation == nil {
+		t.Fatal("The pending reactivation was dropped")
+	}
+	progressing := meta.FindStatusCondition(updated.Status.Conditions, string(cloudingressv1alpha1.PublishingStrategyProgressing))
+	if progressing == nil || progressing.Status != metav1.ConditionTrue || progressing.Reason != cloudingressv1alpha1.ReasonControlPlaneMachineSetReactivating {
+		t.Fatalf("Expected Progressing with reason %s, got %+v", cloudingressv1alpha1.ReasonControlPlaneMachineSetReactivating, updated.Status.Conditions)
+	}
+}
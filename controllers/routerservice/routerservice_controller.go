@@ -18,7 +18,10 @@ package routerservice
 
 import (
 	"context"
+	"strconv"
 
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -29,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -38,13 +42,18 @@ var log = logf.Log.WithName("controller_router_service")
 const (
 	RouterServiceNamespace = "openshift-ingress"
 	ELBAnnotationKey       = "service.beta.kubernetes.io/aws-load-balancer-connection-idle-timeout"
-	ELBAnnotationValue     = "1800"
 )
 
+// ELBAnnotationValue is the idle timeout of the router load balancers, in seconds
+func ELBAnnotationValue() string {
+	return strconv.Itoa(int(operatorconfig.Get().ELBIdleTimeoutSeconds))
+}
+
 // RouterServiceReconciler reconciles a RouterService object
 type RouterServiceReconciler struct {
-	Client client.Client
-	Scheme *runtime.Scheme
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -82,15 +91,17 @@ func (r *RouterServiceReconciler) Reconcile(ctx context.Context, request ctrl.Re
 	// Only check LoadBalancer service types for annotations
 	// Only set timeout annotations on services for < OCP 4.11. In 4.11+, the cluster-ingress-operator maintains this annotation
 	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && !baseutils.IsVersionHigherThan("4.11") {
+		idleTimeout := ELBAnnotationValue()
 		if !metav1.HasAnnotation(svc.ObjectMeta, ELBAnnotationKey) ||
-			svc.Annotations[ELBAnnotationKey] != ELBAnnotationValue {
+			svc.Annotations[ELBAnnotationKey] != idleTimeout {
 			reqLogger.Info("Updating annotation for " + svc.Name)
-			metav1.SetMetaDataAnnotation(&svc.ObjectMeta, ELBAnnotationKey, ELBAnnotationValue)
+			metav1.SetMetaDataAnnotation(&svc.ObjectMeta, ELBAnnotationKey, idleTimeout)
 			err = r.Client.Update(context.TODO(), svc)
 			if err != nil {
 				reqLogger.Error(err, "Error updating service annotation")
 				return reconcile.Result{}, err
 			}
+			cioevents.Normal(cioevents.IntoContext(ctx, r.Recorder, svc), cioevents.ReasonServiceUpdated, cioevents.ActionUpdate, "Set the load balancer idle timeout to %s seconds", idleTimeout)
 		} else {
 			reqLogger.Info("skipping service " + svc.Name + " w/ proper annotations")
 		}
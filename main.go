@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/openshift/cloud-ingress-operator/config"
 	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
 	"github.com/openshift/cloud-ingress-operator/pkg/ingresscontroller"
 	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
@@ -49,8 +51,13 @@ import (
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
 	apiv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
 	apischemecontroller "github.com/openshift/cloud-ingress-operator/controllers/apischeme"
+	cloudingressoperatorconfigcontroller "github.com/openshift/cloud-ingress-operator/controllers/cloudingressoperatorconfig"
+	controlplaneloadbalancercontroller "github.com/openshift/cloud-ingress-operator/controllers/controlplaneloadbalancer"
+	dnsdriftcontroller "github.com/openshift/cloud-ingress-operator/controllers/dnsdrift"
+	orphanedresourcescontroller "github.com/openshift/cloud-ingress-operator/controllers/orphanedresources"
 	publishingstrategycontroller "github.com/openshift/cloud-ingress-operator/controllers/publishingstrategy"
 	routerservicecontroller "github.com/openshift/cloud-ingress-operator/controllers/routerservice"
+	"github.com/openshift/cloud-ingress-operator/webhooks"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	//+kubebuilder:scaffold:imports
@@ -172,8 +179,9 @@ func main() {
 
 	// setup apischemecontroller with mgr
 	if err = (&apischemecontroller.APISchemeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("cloud-ingress-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "APIScheme")
 		os.Exit(1)
@@ -181,22 +189,75 @@ func main() {
 
 	// setup publishingstrategycontroller with mgr
 	if err = (&publishingstrategycontroller.PublishingStrategyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("cloud-ingress-operator"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PublishingStrategy")
+		os.Exit(1)
+	}
+
+	// setup controlplaneloadbalancercontroller with mgr
+	if err = (&controlplaneloadbalancercontroller.ControlPlaneLoadBalancerReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("cloud-ingress-operator"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ControlPlaneLoadBalancer")
+		os.Exit(1)
+	}
+
+	// setup dnsdriftcontroller with mgr
+	if err = (&dnsdriftcontroller.DNSDriftReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("cloud-ingress-operator"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DNSDrift")
+		os.Exit(1)
+	}
+
+	// setup orphanedresourcescontroller with mgr
+	if err = (&orphanedresourcescontroller.OrphanedResourcesReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("cloud-ingress-operator"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OrphanedResources")
+		os.Exit(1)
+	}
+
+	// setup cloudingressoperatorconfigcontroller with mgr
+	if err = (&cloudingressoperatorconfigcontroller.CloudIngressOperatorConfigReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "PublishingStrategy")
+		setupLog.Error(err, "unable to create controller", "controller", "CloudIngressOperatorConfig")
 		os.Exit(1)
 	}
 
 	// setup routerservice with mgr
 	if err = (&routerservicecontroller.RouterServiceReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("cloud-ingress-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RouterService")
 		os.Exit(1)
 	}
 
+	// setup the admission webhooks, they can be turned off when running the operator outside of the cluster
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhooks.SetupAPISchemeWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "APIScheme")
+			os.Exit(1)
+		}
+		if err = webhooks.SetupPublishingStrategyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PublishingStrategy")
+			os.Exit(1)
+		}
+	}
+
 	addMetrics(ctx)
 
 	//+kubebuilder:scaffold:builder
@@ -207,7 +268,16 @@ func main() {
 		if err != nil {
 			return err
 		}
-		cloudClient := cloudclient.GetClientFor(kubeCli, *cloudPlatform)
+		cloudClient, err := cloudclient.GetClientFor(kubeCli, *cloudPlatform)
+		if err != nil {
+			var unsupported *cioerrors.UnsupportedPlatformError
+			if !errors.As(err, &unsupported) {
+				// Restarting the operator won't bring the credentials back, the
+				// controllers report the error in the Degraded conditions instead
+				setupLog.Error(err, "Skipping the cloud health check")
+				return baseutils.SAhealthcheck(kubeCli)
+			}
+		}
 		if err := cloudClient.Healthcheck(context.TODO(), kubeCli); err != nil {
 			return err
 		}
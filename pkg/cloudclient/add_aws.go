@@ -2,6 +2,7 @@ package cloudclient
 
 import (
 	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/aws"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -10,13 +11,17 @@ func init() {
 		aws.ClientIdentifier,
 		produceAWS,
 	)
+	RegisterCredentialsSecret(
+		aws.ClientIdentifier,
+		func() string { return operatorconfig.Get().AWSSecretName },
+	)
 }
 
-func produceAWS(kclient client.Client) CloudClient {
+func produceAWS(kclient client.Client) (CloudClient, error) {
 	cli, err := aws.NewClient(kclient)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return cli
+	return cli, nil
 }
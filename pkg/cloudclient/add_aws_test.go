@@ -7,13 +7,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func TestProduceAWSPanics(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("testing panic: should have been failed")
-		}
-	}()
+func TestProduceAWSError(t *testing.T) {
 	objs := []runtime.Object{}
 	mocks := testutils.NewTestMock(t, objs)
-	_ = produceAWS(mocks.FakeKubeClient)
+	cli, err := produceAWS(mocks.FakeKubeClient)
+	if err == nil {
+		t.Errorf("testing error: should have been failed")
+	}
+	if cli != nil {
+		t.Errorf("no client should be returned with the error")
+	}
 }
@@ -0,0 +1,27 @@
+package cloudclient
+
+import (
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/azure"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func init() {
+	Register(
+		azure.ClientIdentifier,
+		produceAzure,
+	)
+	RegisterCredentialsSecret(
+		azure.ClientIdentifier,
+		func() string { return operatorconfig.Get().AzureSecretName },
+	)
+}
+
+func produceAzure(kclient client.Client) (CloudClient, error) {
+	cli, err := azure.NewClient(kclient)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli, nil
+}
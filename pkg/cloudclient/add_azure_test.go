@@ -0,0 +1,20 @@
+package cloudclient
+
+import (
+	"testing"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestProduceAzureError(t *testing.T) {
+	objs := []runtime.Object{}
+	mocks := testutils.NewTestMock(t, objs)
+	cli, err := produceAzure(mocks.FakeKubeClient)
+	if err == nil {
+		t.Errorf("testing error: should have been failed")
+	}
+	if cli != nil {
+		t.Errorf("no client should be returned with the error")
+	}
+}
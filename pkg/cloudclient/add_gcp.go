@@ -2,6 +2,7 @@ package cloudclient
 
 import (
 	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/gcp"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -10,13 +11,17 @@ func init() {
 		gcp.ClientIdentifier,
 		produceGCP,
 	)
+	RegisterCredentialsSecret(
+		gcp.ClientIdentifier,
+		func() string { return operatorconfig.Get().GCPSecretName },
+	)
 }
 
-func produceGCP(kclient client.Client) CloudClient {
+func produceGCP(kclient client.Client) (CloudClient, error) {
 	cli, err := gcp.NewClient(kclient)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return cli
+	return cli, nil
 }
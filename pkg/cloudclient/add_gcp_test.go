@@ -7,13 +7,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func TestProducePanics(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("testing panic: should have been failed")
-		}
-	}()
+func TestProduceGCPError(t *testing.T) {
 	objs := []runtime.Object{}
 	mocks := testutils.NewTestMock(t, objs)
-	_ = produceGCP(mocks.FakeKubeClient)
+	cli, err := produceGCP(mocks.FakeKubeClient)
+	if err == nil {
+		t.Errorf("testing error: should have been failed")
+	}
+	if cli != nil {
+		t.Errorf("no client should be returned with the error")
+	}
 }
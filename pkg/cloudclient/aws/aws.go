@@ -20,6 +20,8 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
 	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,6 +54,16 @@ func (ac *Client) DeleteAdminAPIDNS(ctx context.Context, kclient k8s.Client, ins
 	return ac.deleteAdminAPIDNS(ctx, kclient, instance, svc)
 }
 
+// DeleteAdminAPIDNSWithoutService implements cloudclient.CloudClient
+func (ac *Client) DeleteAdminAPIDNSWithoutService(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return ac.deleteAdminAPIDNSWithoutService(ctx, kclient, instance)
+}
+
+// CheckAdminAPIDNS implements cloudclient.CloudClient
+func (ac *Client) CheckAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	return ac.checkAdminAPIDNS(ctx, kclient, instance, svc, repair)
+}
+
 // SetDefaultAPIPrivate implements cloudclient.CloudClient
 func (ac *Client) SetDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
 	return ac.setDefaultAPIPrivate(ctx, kclient, instance)
@@ -62,6 +74,46 @@ func (ac *Client) SetDefaultAPIPublic(ctx context.Context, kclient k8s.Client, i
 	return ac.setDefaultAPIPublic(ctx, kclient, instance)
 }
 
+// PlanDefaultAPIPrivate implements cloudclient.CloudClient
+func (ac *Client) PlanDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	return ac.planDefaultAPIPrivate(ctx, kclient, instance)
+}
+
+// PlanDefaultAPIPublic implements cloudclient.CloudClient
+func (ac *Client) PlanDefaultAPIPublic(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	return ac.planDefaultAPIPublic(ctx, kclient, instance)
+}
+
+// ReactivateControlPlaneMachineSet implements cloudclient.CloudClient
+func (ac *Client) ReactivateControlPlaneMachineSet(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return baseutils.ResumeCPMSReactivation(ctx, kclient, instance, removeLoadBalancerCPMS)
+}
+
+// EnsureControlPlaneLoadBalancers implements cloudclient.CloudClient
+func (ac *Client) EnsureControlPlaneLoadBalancers(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening) error {
+	return ac.ensureControlPlaneLoadBalancers(ctx, kclient, listening)
+}
+
+// GetDefaultAPIListening implements cloudclient.CloudClient
+func (ac *Client) GetDefaultAPIListening(ctx context.Context, kclient k8s.Client) (cloudingressv1alpha1.Listening, error) {
+	return ac.getDefaultAPIListening(ctx, kclient)
+}
+
+// CheckDefaultAPIDNS implements cloudclient.CloudClient
+func (ac *Client) CheckDefaultAPIDNS(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	return ac.checkDefaultAPIDNS(ctx, kclient, listening, repair)
+}
+
+// FindOrphanedResources implements cloudclient.CloudClient
+func (ac *Client) FindOrphanedResources(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening) ([]cloudingressv1alpha1.OrphanedResource, error) {
+	return ac.findOrphanedResources(ctx, kclient, listening)
+}
+
+// DeleteOrphanedResource implements cloudclient.CloudClient
+func (ac *Client) DeleteOrphanedResource(ctx context.Context, kclient k8s.Client, resource cloudingressv1alpha1.OrphanedResource) error {
+	return ac.deleteOrphanedResource(ctx, kclient, resource)
+}
+
 // Healthcheck performs basic calls to make sure client is healthy
 func (ac *Client) Healthcheck(ctx context.Context, kclient k8s.Client) error {
 	input := &elb.DescribeLoadBalancersInput{}
@@ -71,48 +123,66 @@ func (ac *Client) Healthcheck(ctx context.Context, kclient k8s.Client) error {
 }
 
 func newClient(region string, kclient k8s.Client) (*Client, error) {
-	sessionOptions := session.Options{
-		Config: aws.Config{
-			Region: aws.String(region),
-		},
-	}
-
 	creds := &corev1.Secret{}
 	err := kclient.Get(
 		context.TODO(),
 		types.NamespacedName{
-			Name:      config.AWSSecretName,
+			Name:      operatorconfig.Get().AWSSecretName,
 			Namespace: config.OperatorNamespace,
 		},
 		creds)
 
 	if err != nil {
-		panic(fmt.Sprintf("Couldn't get secret with credentials %s", err.Error()))
+		return nil, fmt.Errorf("couldn't get Secret with credentials %w", err)
 	}
 
-	// get sharedCredsFile from secret
-	sharedCredsFile, err := SharedCredentialsFileFromSecret(creds)
+	s, err := newSession(region, creds)
 	if err != nil {
 		return nil, err
 	}
 
-	sessionOptions.SharedConfigState = session.SharedConfigEnable // Force enable Shared Config support
-	sessionOptions.SharedConfigFiles = []string{sharedCredsFile}  // Ordered list of files the session will load configuration from.
+	ec2Client := ec2.New(s)
+	instrument(&ec2Client.Handlers, "ec2")
+	elbClient := elb.New(s)
+	instrument(&elbClient.Handlers, "elb")
+	elbv2Client := elbv2.New(s)
+	instrument(&elbv2Client.Handlers, "elbv2")
+	route53Client := route53.New(s)
+	instrument(&route53Client.Handlers, "route53")
 
-	s, err := session.NewSessionWithOptions(sessionOptions)
+	return &Client{
+		ec2Client:     ec2Client,
+		elbClient:     elbClient,
+		elbv2Client:   elbv2Client,
+		route53Client: route53Client,
+	}, nil
+}
+
+// newSession creates a session with the credentials from the Secret. On STS
+// clusters, these are temporary credentials of the role in the Secret.
+func newSession(region string, creds *corev1.Secret) (*session.Session, error) {
+	if identity, ok := webIdentityFromSecret(creds); ok {
+		return newWebIdentitySession(region, identity, "")
+	}
+
+	sessionOptions := session.Options{
+		Config: aws.Config{
+			Region: aws.String(region),
+		},
+	}
+
+	// get sharedCredsFile from secret
+	sharedCredsFile, err := SharedCredentialsFileFromSecret(creds)
 	if err != nil {
 		return nil, err
 	}
-
 	// Remove temporary shared credentials token at end of func after creating session
 	defer os.Remove(sharedCredsFile)
 
-	return &Client{
-		ec2Client:     ec2.New(s),
-		elbClient:     elb.New(s),
-		elbv2Client:   elbv2.New(s),
-		route53Client: route53.New(s),
-	}, nil
+	sessionOptions.SharedConfigState = session.SharedConfigEnable // Force enable Shared Config support
+	sessionOptions.SharedConfigFiles = []string{sharedCredsFile}  // Ordered list of files the session will load configuration from.
+
+	return session.NewSessionWithOptions(sessionOptions)
 }
 
 // NewClient creates a new CloudClient for use with AWS.
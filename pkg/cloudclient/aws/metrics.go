@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/retry"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+)
+
+// metricsHandlerName names the handler so it isn't added twice to the same client
+const metricsHandlerName = "cloudingress.metrics"
+
+// instrument records the calls made through handlers in the cloud API
+// metrics. service prefixes the operation, as elb and elbv2 share the same
+// service name.
+func instrument(handlers *request.Handlers, service string) {
+	handlers.Complete.RemoveByName(metricsHandlerName)
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: metricsHandlerName,
+		Fn: func(r *request.Request) {
+			observeRequest(service, r)
+		},
+	})
+}
+
+// observeRequest is called once the SDK is done with the request, after its own retries
+func observeRequest(service string, r *request.Request) {
+	operation := service + ":" + r.Operation.Name
+	localmetrics.MetricCloudAPIRequests.WithLabelValues("aws", operation).Inc()
+	localmetrics.MetricCloudAPIDuration.WithLabelValues("aws", operation).Observe(time.Since(r.Time).Seconds())
+	if r.Error == nil {
+		return
+	}
+	code := "Unknown"
+	var aerr awserr.Error
+	if errors.As(r.Error, &aerr) {
+		code = aerr.Code()
+	}
+	localmetrics.MetricCloudAPIErrors.WithLabelValues("aws", operation, code).Inc()
+	if retry.Classify(r.Error) == retry.Throttled {
+		localmetrics.MetricCloudAPIThrottled.WithLabelValues("aws", operation).Inc()
+	}
+}
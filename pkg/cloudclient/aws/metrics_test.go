@@ -0,0 +1,45 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+)
+
+func TestInstrument(t *testing.T) {
+	handlers := request.Handlers{}
+	instrument(&handlers, "route53")
+	// Instrumenting the same client twice doesn't count the calls twice
+	instrument(&handlers, "route53")
+	if handlers.Complete.Len() != 1 {
+		t.Fatalf("Expected the metrics handler to be added once, got %d handlers", handlers.Complete.Len())
+	}
+
+	operation := "route53:ChangeResourceRecordSets"
+	requests := testutil.ToFloat64(localmetrics.MetricCloudAPIRequests.WithLabelValues("aws", operation))
+	errors := testutil.ToFloat64(localmetrics.MetricCloudAPIErrors.WithLabelValues("aws", operation, "Throttling"))
+	throttled := testutil.ToFloat64(localmetrics.MetricCloudAPIThrottled.WithLabelValues("aws", operation))
+
+	r := &request.Request{
+		Operation: &request.Operation{Name: "ChangeResourceRecordSets"},
+		Time:      time.Now().Add(-time.Second),
+	}
+	observeRequest("route53", r)
+	r.Error = awserr.New("Throttling", "Rate exceeded", nil)
+	observeRequest("route53", r)
+
+	if got := testutil.ToFloat64(localmetrics.MetricCloudAPIRequests.WithLabelValues("aws", operation)) - requests; got != 2 {
+		t.Errorf("Expected 2 requests, got %v", got)
+	}
+	if got := testutil.ToFloat64(localmetrics.MetricCloudAPIErrors.WithLabelValues("aws", operation, "Throttling")) - errors; got != 1 {
+		t.Errorf("Expected 1 error, got %v", got)
+	}
+	if got := testutil.ToFloat64(localmetrics.MetricCloudAPIThrottled.WithLabelValues("aws", operation)) - throttled; got != 1 {
+		t.Errorf("Expected 1 throttled request, got %v", got)
+	}
+}
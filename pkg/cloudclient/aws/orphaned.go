@@ -0,0 +1,365 @@
+package aws
+
+import (
+	"context"
+	goError "errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+)
+
+// serviceNameTag is set by the cloud provider on the load balancers it
+// creates for the Services, to namespace/name
+const serviceNameTag = "kubernetes.io/service-name"
+
+// elbSecurityGroupPrefix prefixes the name of the security group the cloud
+// provider creates for the Classic ELB of a Service
+const elbSecurityGroupPrefix = "k8s-elb-"
+
+// serviceNamespaces are the namespaces of the Services the operator manages
+// the load balancers of, the rh-api Service and the IngressController ones.
+// The operator only watches those, the load balancers of the other Services
+// are left alone.
+var serviceNamespaces = []string{"openshift-kube-apiserver", "openshift-ingress"}
+
+// findOrphanedResources returns the cluster-owned load balancers which neither
+// the default API nor their Service use anymore, the security groups of the
+// Classic ELBs which are gone, and the records of the admin API names which no
+// APIScheme uses
+func (ac *Client) findOrphanedResources(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening) ([]cloudingressv1alpha1.OrphanedResource, error) {
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return nil, err
+	}
+	var orphans []cloudingressv1alpha1.OrphanedResource
+	orphan := func(kind cloudingressv1alpha1.OrphanedResourceKind, name, reason string) {
+		orphans = append(orphans, cloudingressv1alpha1.OrphanedResource{Kind: kind, Name: name, Reason: reason})
+	}
+
+	nlbs, err := ac.listOwnedNLBs(kclient)
+	if err != nil {
+		return nil, err
+	}
+	for _, nlb := range nlbs {
+		tags, err := ac.getAllTagsFromLoadBalancer(nlb.loadBalancerArn)
+		if err != nil {
+			return nil, err
+		}
+		if serviceName, ok := tags[serviceNameTag]; ok {
+			reason, err := serviceLoadBalancerOrphaned(ctx, kclient, serviceName, nlb.dnsName)
+			if err != nil {
+				return nil, err
+			}
+			if reason != "" {
+				orphan(cloudingressv1alpha1.OrphanedLoadBalancer, nlb.loadBalancerName, reason)
+			}
+			continue
+		}
+		// setDefaultAPIPrivate may have failed after the DNS records were
+		// moved, or the scope changed back while the NLB was being created
+		if listening == cloudingressv1alpha1.Internal && nlb.scheme == "internet-facing" && nlb.loadBalancerName == infrastructureName+"-ext" {
+			orphan(cloudingressv1alpha1.OrphanedLoadBalancer, nlb.loadBalancerName, "the default API is internal")
+		}
+	}
+
+	elbs, err := ac.listOwnedELBs(infrastructureName)
+	if err != nil {
+		return nil, err
+	}
+	for _, classicELB := range elbs {
+		serviceName, ok := classicELB.tags[serviceNameTag]
+		if !ok {
+			continue
+		}
+		reason, err := serviceLoadBalancerOrphaned(ctx, kclient, serviceName, classicELB.dnsName)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			orphan(cloudingressv1alpha1.OrphanedLoadBalancer, classicELB.name, reason)
+		}
+	}
+
+	// Deleting the ELB of a Service, eg to recover the rh-api one, leaves
+	// its security group behind
+	securityGroups, err := ac.listOwnedELBSecurityGroups(infrastructureName)
+	if err != nil {
+		return nil, err
+	}
+	for _, securityGroup := range securityGroups {
+		elbName := strings.TrimPrefix(aws.StringValue(securityGroup.GroupName), elbSecurityGroupPrefix)
+		if !slices.ContainsFunc(elbs, func(classicELB ownedELB) bool { return classicELB.name == elbName }) {
+			orphan(cloudingressv1alpha1.OrphanedSecurityGroup, aws.StringValue(securityGroup.GroupId), fmt.Sprintf("the ELB %s doesn't exist", elbName))
+		}
+	}
+
+	records, err := ac.findOrphanedAdminAPIRecords(ctx, kclient)
+	if err != nil {
+		return nil, err
+	}
+	return append(orphans, records...), nil
+}
+
+// serviceLoadBalancerOrphaned tells why the load balancer with dnsName, created
+// for the Service namespace/name, is orphaned. It is empty when the Service
+// still uses it, is still waiting for it, or isn't one the operator manages.
+func serviceLoadBalancerOrphaned(ctx context.Context, kclient k8s.Client, serviceName, dnsName string) (string, error) {
+	namespace, name, ok := strings.Cut(serviceName, "/")
+	if !ok || !slices.Contains(serviceNamespaces, namespace) {
+		return "", nil
+	}
+	svc := &corev1.Service{}
+	err := kclient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, svc)
+	if k8serrors.IsNotFound(err) {
+		return fmt.Sprintf("the Service %s doesn't exist", serviceName), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return fmt.Sprintf("the Service %s isn't a LoadBalancer", serviceName), nil
+	}
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		// The cloud provider hasn't written the status yet
+		return "", nil
+	}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if strings.EqualFold(ingress.Hostname, dnsName) {
+			return "", nil
+		}
+	}
+	return fmt.Sprintf("the Service %s uses another load balancer", serviceName), nil
+}
+
+// ownedELB is a Classic ELB owned by the cluster
+type ownedELB struct {
+	name    string
+	dnsName string
+	tags    map[string]string
+}
+
+// listOwnedELBs returns the Classic ELBs tagged as owned by the cluster
+func (ac *Client) listOwnedELBs(infrastructureName string) ([]ownedELB, error) {
+	var all []ownedELB
+	err := ac.elbClient.DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{}, func(page *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+		for _, description := range page.LoadBalancerDescriptions {
+			all = append(all, ownedELB{name: aws.StringValue(description.LoadBalancerName), dnsName: aws.StringValue(description.DNSName)})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Request tags for up to 20 load balancers at a time.
+	owned := make([]ownedELB, 0, len(all))
+	for i := 0; i < len(all); i += 20 {
+		batch := all[i:min(i+20, len(all))]
+		names := make([]string, 0, len(batch))
+		for _, classicELB := range batch {
+			names = append(names, classicELB.name)
+		}
+		tagsOutput, err := ac.elbClient.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: aws.StringSlice(names)})
+		if err != nil {
+			return nil, err
+		}
+		for _, tagDescription := range tagsOutput.TagDescriptions {
+			tags := make(map[string]string, len(tagDescription.Tags))
+			for _, tag := range tagDescription.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			if tags["kubernetes.io/cluster/"+infrastructureName] != "owned" {
+				continue
+			}
+			for _, classicELB := range batch {
+				if classicELB.name == aws.StringValue(tagDescription.LoadBalancerName) {
+					classicELB.tags = tags
+					owned = append(owned, classicELB)
+				}
+			}
+		}
+	}
+	return owned, nil
+}
+
+// listOwnedELBSecurityGroups returns the security groups the cloud provider
+// created for the Classic ELBs of the cluster
+func (ac *Client) listOwnedELBSecurityGroups(infrastructureName string) ([]*ec2.SecurityGroup, error) {
+	var securityGroups []*ec2.SecurityGroup
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("tag:kubernetes.io/cluster/" + infrastructureName), Values: aws.StringSlice([]string{"owned"})},
+			{Name: aws.String("group-name"), Values: aws.StringSlice([]string{elbSecurityGroupPrefix + "*"})},
+		},
+	}
+	err := ac.ec2Client.DescribeSecurityGroupsPages(input, func(page *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+		securityGroups = append(securityGroups, page.SecurityGroups...)
+		return true
+	})
+	return securityGroups, err
+}
+
+// findOrphanedAdminAPIRecords returns the A records of the admin API names
+// which no APIScheme uses, from both zones
+func (ac *Client) findOrphanedAdminAPIRecords(ctx context.Context, kclient k8s.Client) ([]cloudingressv1alpha1.OrphanedResource, error) {
+	names, err := baseutils.UnusedAdminAPINames(ctx, kclient)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+	clusterBaseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return nil, err
+	}
+	var orphans []cloudingressv1alpha1.OrphanedResource
+	for _, zone := range clusterZones(clusterBaseDomain) {
+		hostedZoneID, err := ac.getPublicHostedZoneID(zone.name + ".")
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			recordName := name + "." + clusterBaseDomain + "."
+			record, err := ac.getARecord(hostedZoneID, recordName)
+			if err != nil {
+				return nil, err
+			}
+			if record != nil {
+				orphans = append(orphans, cloudingressv1alpha1.OrphanedResource{
+					Kind:   cloudingressv1alpha1.OrphanedDNSRecord,
+					Name:   recordName,
+					Zone:   zone.zone,
+					Reason: fmt.Sprintf("no APIScheme uses %s", name),
+				})
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// clusterZone is one of the Route53 zones of the cluster
+type clusterZone struct {
+	zone cloudingressv1alpha1.DNSRecordZone
+	name string // without the trailing dot
+}
+
+// clusterZones returns the private and public zones of the cluster. The public
+// zone name omits the cluster name, eg mycluster.abcd.s1.openshift.com ->
+// abcd.s1.openshift.com
+func clusterZones(clusterBaseDomain string) []clusterZone {
+	return []clusterZone{
+		{zone: cloudingressv1alpha1.DNSRecordZonePrivate, name: clusterBaseDomain},
+		{zone: cloudingressv1alpha1.DNSRecordZonePublic, name: clusterBaseDomain[strings.Index(clusterBaseDomain, ".")+1:]},
+	}
+}
+
+// deleteOrphanedResource deletes a resource found by findOrphanedResources
+func (ac *Client) deleteOrphanedResource(ctx context.Context, kclient k8s.Client, resource cloudingressv1alpha1.OrphanedResource) error {
+	switch resource.Kind {
+	case cloudingressv1alpha1.OrphanedLoadBalancer:
+		return ac.deleteLoadBalancerByName(resource.Name)
+	case cloudingressv1alpha1.OrphanedSecurityGroup:
+		return ac.deleteELBSecurityGroup(resource.Name)
+	case cloudingressv1alpha1.OrphanedDNSRecord:
+		clusterBaseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+		if err != nil {
+			return err
+		}
+		for _, zone := range clusterZones(clusterBaseDomain) {
+			if zone.zone == resource.Zone {
+				return ac.deleteARecordsByName(ctx, zone.name+".", resource.Name)
+			}
+		}
+		return fmt.Errorf("unknown zone %q", resource.Zone)
+	}
+	return fmt.Errorf("can't delete a %s on AWS", resource.Kind)
+}
+
+// deleteLoadBalancerByName deletes the NLB or, when there is none, the Classic
+// ELB with the name
+func (ac *Client) deleteLoadBalancerByName(name string) error {
+	output, err := ac.elbv2Client.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{Names: aws.StringSlice([]string{name})})
+	var aerr awserr.Error
+	if goError.As(err, &aerr) && aerr.Code() == elbv2.ErrCodeLoadBalancerNotFoundException {
+		_, err = ac.elbClient.DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String(name)})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	for _, loadBalancer := range output.LoadBalancers {
+		if err := ac.deleteExternalLoadBalancer(aws.StringValue(loadBalancer.LoadBalancerArn)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteELBSecurityGroup deletes the security group of a Classic ELB, once the
+// rules of the other security groups referencing it, such as the one letting
+// it reach the nodes, are removed
+func (ac *Client) deleteELBSecurityGroup(groupID string) error {
+	for _, filter := range []string{"ip-permission.group-id", "egress.ip-permission.group-id"} {
+		input := &ec2.DescribeSecurityGroupsInput{
+			Filters: []*ec2.Filter{{Name: aws.String(filter), Values: aws.StringSlice([]string{groupID})}},
+		}
+		var referencing []*ec2.SecurityGroup
+		err := ac.ec2Client.DescribeSecurityGroupsPages(input, func(page *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+			referencing = append(referencing, page.SecurityGroups...)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		for _, securityGroup := range referencing {
+			if filter == "ip-permission.group-id" {
+				permissions := permissionsReferencing(securityGroup.IpPermissions, groupID)
+				if len(permissions) == 0 {
+					continue
+				}
+				_, err = ac.ec2Client.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{GroupId: securityGroup.GroupId, IpPermissions: permissions})
+			} else {
+				permissions := permissionsReferencing(securityGroup.IpPermissionsEgress, groupID)
+				if len(permissions) == 0 {
+					continue
+				}
+				_, err = ac.ec2Client.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{GroupId: securityGroup.GroupId, IpPermissions: permissions})
+			}
+			if err != nil {
+				return fmt.Errorf("could not remove the rules of %s referencing %s: %w", aws.StringValue(securityGroup.GroupId), groupID, err)
+			}
+		}
+	}
+	_, err := ac.ec2Client.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: aws.String(groupID)})
+	return err
+}
+
+// permissionsReferencing returns the permissions granted to the security
+// group, only with the group, so no other grant is revoked with them
+func permissionsReferencing(permissions []*ec2.IpPermission, groupID string) []*ec2.IpPermission {
+	var referencing []*ec2.IpPermission
+	for _, permission := range permissions {
+		for _, pair := range permission.UserIdGroupPairs {
+			if aws.StringValue(pair.GroupId) == groupID {
+				referencing = append(referencing, &ec2.IpPermission{
+					IpProtocol:       permission.IpProtocol,
+					FromPort:         permission.FromPort,
+					ToPort:           permission.ToPort,
+					UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: pair.GroupId}},
+				})
+			}
+		}
+	}
+	return referencing
+}
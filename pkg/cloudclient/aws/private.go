@@ -9,8 +9,9 @@ import (
 	"fmt"
 	"path"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
-	"time"
 
 	"gopkg.in/yaml.v2"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -25,8 +26,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/retry"
 	"github.com/openshift/cloud-ingress-operator/pkg/errors"
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -37,15 +40,29 @@ import (
 	"github.com/aws/aws-sdk-go/service/route53"
 )
 
+// maxParallelRoute53Changes bounds the Route53 changes made at the same time
+// for a DNS record, one per hosted zone
+const maxParallelRoute53Changes = 2
+
+// route53Backoff keeps the failed attempts of the DNS changes. It outlives the
+// Client, which is created again when the credentials are rotated
+var route53Backoff = retry.NewBackoff("aws")
+
+// serviceLoadBalancerTypeAnnotation is set to nlb on a Service whose load
+// balancer is an NLB instead of a Classic ELB
+const serviceLoadBalancerTypeAnnotation = "service.beta.kubernetes.io/aws-load-balancer-type"
+
 type awsLoadBalancer struct {
 	elbName   string
 	dnsName   string
 	dnsZoneID string
+	dualStack bool // has IPv6 addresses, so AAAA records alias it too
 }
 
 type loadBalancer struct {
 	endpointName string // from APIScheme
 	baseDomain   string // cluster base domain
+	internal     bool   // only published in the private zone
 }
 
 type loadBalancerV2 struct {
@@ -54,7 +71,9 @@ type loadBalancerV2 struct {
 	loadBalancerArn           string
 	loadBalancerName          string
 	scheme                    string
+	ipAddressType             string // ipv4 or dualstack
 	vpcID                     string
+	subnets                   map[string]string // subnet ID by availability zone
 }
 
 // installConfig represents the bare minimum requirement to get the AWS cluster region from the install-config
@@ -67,6 +86,16 @@ type installConfig struct {
 	} `json:"platform"`
 }
 
+// aliasRecord is an A record aliasing a load balancer, as the operator expects it to be
+type aliasRecord struct {
+	zone      cloudingressv1alpha1.DNSRecordZone
+	zoneName  string // without the trailing dot
+	name      string // with the trailing dot
+	dnsName   string
+	dnsZoneID string
+	comment   string
+}
+
 // removeAWSLBFromMasterMachines removes a Load Balancer (with name elbName) from
 // the spec.providerSpec.value.loadBalancers list for each of the master machine
 // objects in a cluster
@@ -139,11 +168,105 @@ func updateAWSLBList(kclient k8s.Client, oldLBList []machinev1beta1.LoadBalancer
 	return nil
 }
 
+// ensureControlPlaneLoadBalancers adds the external API NLB to the
+// loadBalancers of the master machines and of the CPMS template when the
+// default API is external, and removes it when it is internal. Machines being
+// deleted are left alone, the machines are updated before the CPMS so it
+// doesn't see them as outdated.
+func (ac *Client) ensureControlPlaneLoadBalancers(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening) error {
+	clusterName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	extNLB := machinev1beta1.LoadBalancerReference{Name: clusterName + "-ext", Type: machinev1beta1.NetworkLoadBalancerType}
+	desiredLBList := func(lbList []machinev1beta1.LoadBalancerReference) []machinev1beta1.LoadBalancerReference {
+		newLBList := []machinev1beta1.LoadBalancerReference{}
+		for _, lb := range lbList {
+			if lb.Name == extNLB.Name {
+				if listening == cloudingressv1alpha1.External {
+					return lbList
+				}
+				continue
+			}
+			newLBList = append(newLBList, lb)
+		}
+		if listening == cloudingressv1alpha1.External {
+			newLBList = append(newLBList, extNLB)
+		}
+		return newLBList
+	}
+
+	masterList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return err
+	}
+	for _, machine := range masterList.Items {
+		if machine.DeletionTimestamp != nil {
+			continue
+		}
+		providerSpecDecoded, err := getAWSDecodedProviderSpec(machine, kclient.Scheme())
+		if err != nil {
+			return err
+		}
+		lbList := providerSpecDecoded.LoadBalancers
+		newLBList := desiredLBList(lbList)
+		if equalAWSLBLists(lbList, newLBList) {
+			continue
+		}
+		if err := updateAWSLBList(kclient, lbList, newLBList, machine, providerSpecDecoded); err != nil {
+			return err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonControlPlaneLoadBalancersUpdated, cioevents.ActionUpdate, "Set the load balancers of Machine %s to %s", machine.Name, formatAWSLBList(newLBList))
+	}
+
+	cpms, err := baseutils.GetControlPlaneMachineSet(kclient)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	cpmsPatch := k8s.MergeFrom(cpms.DeepCopy())
+	spec, err := baseutils.ConvertFromRawExtension[machinev1beta1.AWSMachineProviderConfig](cpms.Spec.Template.OpenShiftMachineV1Beta1Machine.Spec.ProviderSpec.Value)
+	if err != nil {
+		return err
+	}
+	newLBList := desiredLBList(spec.LoadBalancers)
+	if equalAWSLBLists(spec.LoadBalancers, newLBList) {
+		return nil
+	}
+	spec.LoadBalancers = newLBList
+	extension, err := baseutils.ConvertToRawBytes(spec)
+	if err != nil {
+		return err
+	}
+	cpms.Spec.Template.OpenShiftMachineV1Beta1Machine.Spec.ProviderSpec.Value.Raw = extension
+	if err := kclient.Patch(ctx, cpms, cpmsPatch); err != nil {
+		return fmt.Errorf("could not update CPMS: %v", err)
+	}
+	cioevents.Normal(ctx, cioevents.ReasonControlPlaneLoadBalancersUpdated, cioevents.ActionUpdate, "Set the load balancers of the ControlPlaneMachineSet to %s", formatAWSLBList(newLBList))
+	return nil
+}
+
+// equalAWSLBLists doesn't tell a nil list from an empty one
+func equalAWSLBLists(a, b []machinev1beta1.LoadBalancerReference) bool {
+	return len(a) == len(b) && (len(a) == 0 || reflect.DeepEqual(a, b))
+}
+
+func formatAWSLBList(lbList []machinev1beta1.LoadBalancerReference) string {
+	names := make([]string, 0, len(lbList))
+	for _, lb := range lbList {
+		names = append(names, lb.Name)
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
 // ensureAdminAPIDNS ensure the DNS record for the rh-api "admin API" for
 // APIScheme is present and mapped to the corresponding Service's AWS
-// LoadBalancer
+// LoadBalancer. The record of an internal APIScheme is only in the private zone.
 func (ac *Client) ensureAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return ac.ensureDNSForService(ctx, kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName, "RH API Endpoint")
+	internal := instance.Spec.ManagementAPIServerIngress.Listening == cloudingressv1alpha1.Internal
+	return ac.ensureDNSForService(ctx, kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName, "RH API Endpoint", internal)
 }
 
 // deleteAdminAPIDNS removes the DNS record for the rh-api "admin API" for
@@ -152,16 +275,60 @@ func (ac *Client) deleteAdminAPIDNS(ctx context.Context, kclient k8s.Client, ins
 	return ac.removeDNSForService(ctx, kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName, "RH API Endpoint")
 }
 
+// deleteAdminAPIDNSWithoutService removes the DNS records for the rh-api
+// "admin API" for APIScheme without knowing the load balancer they alias to
+func (ac *Client) deleteAdminAPIDNSWithoutService(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return ac.removeDNSForName(ctx, kclient, instance.Spec.ManagementAPIServerIngress.DNSName)
+}
+
+// checkAdminAPIDNS compares the rh-api "admin API" records in the private and
+// public zones with the Service's AWS LoadBalancer
+func (ac *Client) checkAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	awsELB, err := ac.serviceLoadBalancer(svc)
+	if err != nil {
+		return nil, err
+	}
+	clusterBaseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return nil, err
+	}
+	recordName := instance.Spec.ManagementAPIServerIngress.DNSName + "." + clusterBaseDomain + "."
+	records := []aliasRecord{{
+		zone:      cloudingressv1alpha1.DNSRecordZonePrivate,
+		zoneName:  clusterBaseDomain,
+		name:      recordName,
+		dnsName:   awsELB.dnsName,
+		dnsZoneID: awsELB.dnsZoneID,
+		comment:   "RH API Endpoint",
+	}}
+	// An internal APIScheme has no public record
+	if instance.Spec.ManagementAPIServerIngress.Listening != cloudingressv1alpha1.Internal {
+		records = append(records, aliasRecord{
+			zone:      cloudingressv1alpha1.DNSRecordZonePublic,
+			zoneName:  clusterBaseDomain[strings.Index(clusterBaseDomain, ".")+1:],
+			name:      recordName,
+			dnsName:   awsELB.dnsName,
+			dnsZoneID: awsELB.dnsZoneID,
+			comment:   "RH API Endpoint",
+		})
+	}
+	return ac.checkAliasRecords(ctx, repair, records...)
+}
+
 // setDefaultAPIPrivate sets the default api (api.<cluster-domain>) to private
 // scope
-func (ac *Client) setDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) error {
+func (ac *Client) setDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
 	// Delete the NLB and remove the NLB from the master Machine objects in
 	// cluster. At the same time, get the name of the DNS zone and base domain for
 	// the internal load balancer
-	intDNSName, intHostedZoneID, err := ac.removeLoadBalancerFromMasterNodes(ctx, kclient)
+	intNLB, err := ac.removeLoadBalancerFromMasterNodes(ctx, kclient, instance)
 	if err != nil {
 		return err
 	}
+	// the external NLB forwarded to it, nothing else does
+	if err := ac.deleteDedicatedExternalAPITargetGroup(ctx, kclient); err != nil {
+		return err
+	}
 
 	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
 	if err != nil {
@@ -171,92 +338,489 @@ func (ac *Client) setDefaultAPIPrivate(ctx context.Context, kclient k8s.Client,
 	pubDomainName := baseDomain[strings.Index(baseDomain, ".")+1:]
 	apiDNSName := fmt.Sprintf("api.%s.", baseDomain)
 	comment := "Update api.<clusterName> alias to internal NLB"
-	err = ac.upsertARecord(pubDomainName+".", intDNSName, intHostedZoneID, apiDNSName, comment, false)
+	err = ac.upsertARecord(ctx, pubDomainName+".", intNLB.dnsName, intNLB.canonicalHostedZoneNameID, apiDNSName, comment, false)
 	if err != nil {
 		return err
 	}
-	return nil
+	return ac.ensureAAAARecord(ctx, pubDomainName+".", intNLB.dnsName, intNLB.canonicalHostedZoneNameID, apiDNSName, comment, isDualStack(intNLB.ipAddressType))
 }
 
 // setDefaultAPIPublic sets the default API (api.<cluster-domain>) to public
-// scope
+// scope. The listener and the records are ensured on an existing external NLB
+// too, as a previous reconcile may have failed right after creating it.
 func (ac *Client) setDefaultAPIPublic(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
 	nlbs, err := ac.listOwnedNLBs(kclient)
 	if err != nil {
 		return err
 	}
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
 
-	for _, networkLoadBalancer := range nlbs {
-		if networkLoadBalancer.scheme == "internet-facing" && strings.HasSuffix(networkLoadBalancer.loadBalancerName, "-ext") {
-			// nothing to do
-			return nil
+	var extNLB loadBalancerV2
+	if i := slices.IndexFunc(nlbs, func(nlb loadBalancerV2) bool {
+		return nlb.scheme == "internet-facing" && strings.HasSuffix(nlb.loadBalancerName, "-ext")
+	}); i >= 0 {
+		extNLB = nlbs[i]
+		if err := ac.reconcileExternalNLBSubnets(ctx, kclient, extNLB); err != nil {
+			return err
+		}
+		if err := ac.syncDedicatedExternalAPITargetGroup(ctx, kclient); err != nil {
+			return err
+		}
+	} else {
+		// create new ext nlb
+		extNLBName := infrastructureName + "-ext"
+
+		subnets, err := ac.getExternalNLBSubnets(kclient)
+		if err != nil {
+			return err
+		}
+		subnetIDs := make([]string, 0, len(subnets))
+		for _, zone := range sortedZones(subnets) {
+			subnetIDs = append(subnetIDs, subnets[zone])
+		}
+
+		tags := ac.GetTags(infrastructureName)
+
+		// The external NLB has the IP address type of the internal one the
+		// installer created, so it is dual-stack in a dual-stack VPC
+		var ipAddressType string
+		if i := slices.IndexFunc(nlbs, func(nlb loadBalancerV2) bool {
+			return nlb.scheme == "internal" && nlb.loadBalancerName == infrastructureName+"-int"
+		}); i >= 0 {
+			ipAddressType = nlbs[i].ipAddressType
 		}
+
+		newNLBs, err := ac.createNetworkLoadBalancer(extNLBName, "internet-facing", ipAddressType, subnetIDs, tags)
+		if err != nil {
+			cioevents.Warning(ctx, cioevents.ReasonLoadBalancerCreateFailed, cioevents.ActionCreate, "Couldn't create the external NLB %s: %v", extNLBName, err)
+			return err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonLoadBalancerCreated, cioevents.ActionCreate, "Created the external NLB %s", extNLBName)
+		if len(newNLBs) != 1 {
+			return fmt.Errorf("more than one NLB, or no new NLB detected (expected 1, got %d)", len(newNLBs))
+		}
+		extNLB = newNLBs[0]
 	}
-	// create new ext nlb
-	infrastructureName, err := baseutils.GetClusterName(kclient)
-	if err != nil {
+
+	if err := ac.ensureExternalNLBListener(ctx, kclient, infrastructureName, extNLB); err != nil {
 		return err
 	}
-	extNLBName := infrastructureName + "-ext"
 
-	subnetIDs, err := ac.getPublicSubnets(kclient)
+	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
 	if err != nil {
 		return err
 	}
-	if len(subnetIDs) == 0 {
-		err = goError.New("no public subnets, can't change API to public")
+	pubDomainName := baseDomain[strings.Index(baseDomain, ".")+1:]
+	apiDNSName := fmt.Sprintf("api.%s.", baseDomain)
+	// not tested yet
+	comment := "Update api.<clusterName> alias to external NLB"
+	err = ac.upsertARecord(ctx, pubDomainName+".",
+		extNLB.dnsName,
+		extNLB.canonicalHostedZoneNameID,
+		apiDNSName,
+		comment,
+		false)
+	if err != nil {
 		return err
 	}
+	return ac.ensureAAAARecord(ctx, pubDomainName+".", extNLB.dnsName, extNLB.canonicalHostedZoneNameID, apiDNSName, comment, isDualStack(extNLB.ipAddressType))
+}
 
-	tags := ac.GetTags(infrastructureName)
+// ensureExternalNLBListener forwards the API port of the external NLB to the
+// installer's external API target group, or to a dedicated one when the
+// installer's is still associated with another load balancer
+func (ac *Client) ensureExternalNLBListener(ctx context.Context, kclient k8s.Client, infrastructureName string, extNLB loadBalancerV2) error {
+	listener, err := ac.getNLBListener(extNLB.loadBalancerArn)
+	if err != nil || listener != nil {
+		return err
+	}
 
-	newNLBs, err := ac.createNetworkLoadBalancer(extNLBName, "internet-facing", subnetIDs[0], tags)
+	// attempt to use existing TargetGroup
+	targetGroupName := fmt.Sprintf("%s-%s", infrastructureName, operatorconfig.Get().ExternalAPITargetGroupSuffix)
+	targetGroupARN, associated, err := ac.getExternalAPITargetGroup(targetGroupName, extNLB.loadBalancerArn)
 	if err != nil {
 		return err
 	}
-	if len(newNLBs) != 1 {
-		return fmt.Errorf("more than one NLB, or no new NLB detected (expected 1, got %d)", len(newNLBs))
+	if !associated {
+		err = ac.createListenerForNLB(targetGroupARN, extNLB.loadBalancerArn)
+		// associated with another load balancer in the meantime
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeTargetGroupAssociationLimitException {
+			associated = true
+		}
+	}
+	if associated {
+		// the installer's target group is still associated with another load
+		// balancer, forward to a target group of our own instead
+		log.Info("Target group is associated with another load balancer, using a dedicated one", "targetGroup", targetGroupName)
+		targetGroupARN, err = ac.ensureDedicatedExternalAPITargetGroup(ctx, kclient, extNLB.vpcID)
+		if err != nil {
+			return err
+		}
+		err = ac.createListenerForNLB(targetGroupARN, extNLB.loadBalancerArn)
 	}
+	if err != nil {
+		cioevents.Warning(ctx, cioevents.ReasonLoadBalancerUpdateFailed, cioevents.ActionCreate, "Couldn't create the listener of the external NLB %s: %v", extNLB.loadBalancerName, err)
+		return fmt.Errorf("couldn't create the listener of the external NLB %s: %w", extNLB.loadBalancerName, err)
+	}
+	cioevents.Normal(ctx, cioevents.ReasonLoadBalancerUpdated, cioevents.ActionCreate, "Created the listener of the external NLB %s", extNLB.loadBalancerName)
+	return nil
+}
 
-	// attempt to use existing TargetGroup
-	targetGroupName := fmt.Sprintf("%s-aext", infrastructureName)
-	targetGroupARN, err := ac.getTargetGroupArn(targetGroupName)
+// getExternalAPITargetGroup returns the ARN of the installer's external API
+// target group, and tells if it is associated with a load balancer other than
+// the external NLB. A target group can't be associated with two network load
+// balancers, the external NLB then forwards to the dedicated target group.
+func (ac *Client) getExternalAPITargetGroup(targetGroupName, extNLBArn string) (string, bool, error) {
+	result, err := ac.elbv2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		Names: []*string{aws.String(targetGroupName)},
+	})
 	if err != nil {
-		return err
+		return "", false, err
+	}
+	targetGroup := result.TargetGroups[0]
+	for _, loadBalancerArn := range targetGroup.LoadBalancerArns {
+		if aws.StringValue(loadBalancerArn) != extNLBArn {
+			return aws.StringValue(targetGroup.TargetGroupArn), true, nil
+		}
 	}
-	err = ac.createListenerForNLB(targetGroupARN, newNLBs[0].loadBalancerArn)
+	return aws.StringValue(targetGroup.TargetGroupArn), false, nil
+}
+
+// planExternalNLBListener lists the changes ensureExternalNLBListener would
+// make to forward the API port of the external NLB, whose ARN is empty when it
+// doesn't exist yet: the dedicated target group it creates or reuses and the
+// control plane instances it registers with it, then the listener
+func (ac *Client) planExternalNLBListener(kclient k8s.Client, infrastructureName, extNLBName, extNLBArn string) ([]cloudingressv1alpha1.PlannedAction, error) {
+	targetGroupName := fmt.Sprintf("%s-%s", infrastructureName, operatorconfig.Get().ExternalAPITargetGroupSuffix)
+	_, associated, err := ac.getExternalAPITargetGroup(targetGroupName, extNLBArn)
 	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			if aerr.Code() == "TargetGroupAssociationLimit" {
-				// not possible to modify lb, we'd have to create a new targetGroup
-				return nil
+		return nil, err
+	}
+	if !associated {
+		return []cloudingressv1alpha1.PlannedAction{{
+			Action:      cloudingressv1alpha1.PlannedActionCreate,
+			Kind:        cloudingressv1alpha1.PlannedResourceListener,
+			Name:        extNLBName,
+			Description: fmt.Sprintf("Forward TCP port 6443 to target group %s", targetGroupName),
+		}}, nil
+	}
+
+	var actions []cloudingressv1alpha1.PlannedAction
+	dedicatedName := dedicatedExternalAPITargetGroupName(infrastructureName)
+	listenerDescription := fmt.Sprintf("Forward TCP port 6443 to the existing target group %s, %s is associated with another load balancer", dedicatedName, targetGroupName)
+	dedicatedARN, err := ac.getTargetGroupArn(dedicatedName)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != elbv2.ErrCodeTargetGroupNotFoundException {
+			return nil, err
+		}
+		actions = append(actions, cloudingressv1alpha1.PlannedAction{
+			Action:      cloudingressv1alpha1.PlannedActionCreate,
+			Kind:        cloudingressv1alpha1.PlannedResourceTargetGroup,
+			Name:        dedicatedName,
+			Description: fmt.Sprintf("Create a TCP target group for port 6443, %s is associated with another load balancer", targetGroupName),
+		})
+		listenerDescription = fmt.Sprintf("Forward TCP port 6443 to target group %s", dedicatedName)
+	}
+	instanceActions, err := ac.planTargetGroupInstances(kclient, dedicatedName, dedicatedARN)
+	if err != nil {
+		return nil, err
+	}
+	actions = append(actions, instanceActions...)
+	return append(actions, cloudingressv1alpha1.PlannedAction{
+		Action:      cloudingressv1alpha1.PlannedActionCreate,
+		Kind:        cloudingressv1alpha1.PlannedResourceListener,
+		Name:        extNLBName,
+		Description: listenerDescription,
+	}), nil
+}
+
+// getNLBListener returns the listener of the NLB on the API port, nil when
+// there is none
+func (ac *Client) getNLBListener(loadBalancerArn string) (*elbv2.Listener, error) {
+	var found *elbv2.Listener
+	err := ac.elbv2Client.DescribeListenersPages(&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(loadBalancerArn)},
+		func(page *elbv2.DescribeListenersOutput, lastPage bool) bool {
+			for _, listener := range page.Listeners {
+				if aws.Int64Value(listener.Port) == 6443 {
+					found = listener
+					return false
+				}
 			}
-			return err
+			return true
+		})
+	return found, err
+}
+
+// getDefaultAPIListening reports the default API as external when the
+// internet-facing NLB created by setDefaultAPIPublic exists
+func (ac *Client) getDefaultAPIListening(ctx context.Context, kclient k8s.Client) (cloudingressv1alpha1.Listening, error) {
+	nlbs, err := ac.listOwnedNLBs(kclient)
+	if err != nil {
+		return "", err
+	}
+	for _, networkLoadBalancer := range nlbs {
+		if networkLoadBalancer.scheme == "internet-facing" && strings.HasSuffix(networkLoadBalancer.loadBalancerName, "-ext") {
+			return cloudingressv1alpha1.External, nil
 		}
-		// TODO: log - cant create listener for new ext nlb
-		return err
+	}
+	return cloudingressv1alpha1.Internal, nil
+}
+
+// checkDefaultAPIDNS compares the api records with the NLBs of the scope. The
+// public zone aliases the external NLB when the API is external and the
+// internal one otherwise, the private zone always aliases the internal NLB.
+func (ac *Client) checkDefaultAPIDNS(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	intNLB, err := ac.getInteralAPINLB(kclient)
+	if err != nil {
+		return nil, err
+	}
+	publicNLB := intNLB
+	publicComment := "Update api.<clusterName> alias to internal NLB"
+	if listening == cloudingressv1alpha1.External {
+		nlbs, err := ac.listOwnedNLBs(kclient)
+		if err != nil {
+			return nil, err
+		}
+		i := slices.IndexFunc(nlbs, func(nlb loadBalancerV2) bool {
+			return nlb.scheme == "internet-facing" && strings.HasSuffix(nlb.loadBalancerName, "-ext")
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("could not find external API NLB")
+		}
+		publicNLB = nlbs[i]
+		publicComment = "Update api.<clusterName> alias to external NLB"
 	}
 
-	// can't create listener for new ext nlb
 	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	apiDNSName := fmt.Sprintf("api.%s.", baseDomain)
+	return ac.checkAliasRecords(ctx, repair,
+		aliasRecord{
+			zone:      cloudingressv1alpha1.DNSRecordZonePrivate,
+			zoneName:  baseDomain,
+			name:      apiDNSName,
+			dnsName:   intNLB.dnsName,
+			dnsZoneID: intNLB.canonicalHostedZoneNameID,
+			comment:   "Update api.<clusterName> alias to internal NLB",
+		},
+		aliasRecord{
+			zone:      cloudingressv1alpha1.DNSRecordZonePublic,
+			zoneName:  baseDomain[strings.Index(baseDomain, ".")+1:],
+			name:      apiDNSName,
+			dnsName:   publicNLB.dnsName,
+			dnsZoneID: publicNLB.canonicalHostedZoneNameID,
+			comment:   publicComment,
+		})
+}
+
+// planDefaultAPIPrivate lists the changes setDefaultAPIPrivate would make: the
+// external NLBs it deletes, the ControlPlaneMachineSet and Machines it updates
+// to stop referencing them, the dedicated external API target group it deletes
+// and the api A record pointed to the internal NLB
+func (ac *Client) planDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	clusterName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return nil, err
+	}
+	nlbs, err := ac.listOwnedNLBs(kclient)
+	if err != nil {
+		return nil, err
+	}
+	masterList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return nil, err
+	}
+	cpms, err := baseutils.GetControlPlaneMachineSet(kclient)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		cpms = nil
+	}
+
+	var actions []cloudingressv1alpha1.PlannedAction
+	for _, networkLoadBalancer := range nlbs {
+		if networkLoadBalancer.scheme != "internet-facing" {
+			continue
+		}
+		canDelete, err := ac.canDeleteNlb(networkLoadBalancer, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		if !canDelete {
+			continue
+		}
+		lbName := networkLoadBalancer.loadBalancerName
+		actions = append(actions, cloudingressv1alpha1.PlannedAction{
+			Action:      cloudingressv1alpha1.PlannedActionDelete,
+			Kind:        cloudingressv1alpha1.PlannedResourceLoadBalancer,
+			Name:        lbName,
+			Description: "Delete the external API NLB",
+		})
+		if cpms != nil && cpms.Spec.State == machinev1.ControlPlaneMachineSetStateActive {
+			actions = append(actions,
+				cloudingressv1alpha1.PlannedAction{
+					Action:      cloudingressv1alpha1.PlannedActionDelete,
+					Kind:        cloudingressv1alpha1.PlannedResourceControlPlaneMachineSet,
+					Name:        cpms.Name,
+					Description: "Delete the active ControlPlaneMachineSet so the Machines can be updated",
+				},
+				cloudingressv1alpha1.PlannedAction{
+					Action:      cloudingressv1alpha1.PlannedActionUpdate,
+					Kind:        cloudingressv1alpha1.PlannedResourceControlPlaneMachineSet,
+					Name:        cpms.Name,
+					Description: fmt.Sprintf("Remove load balancer %s from the recreated ControlPlaneMachineSet and set it active again", lbName),
+				})
+		}
+		machineActions, err := planAWSLBRemovalFromMasterMachines(kclient, lbName, masterList)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, machineActions...)
+	}
+	targetGroupName := dedicatedExternalAPITargetGroupName(clusterName)
+	if _, err := ac.getTargetGroupArn(targetGroupName); err == nil {
+		actions = append(actions, cloudingressv1alpha1.PlannedAction{
+			Action:      cloudingressv1alpha1.PlannedActionDelete,
+			Kind:        cloudingressv1alpha1.PlannedResourceTargetGroup,
+			Name:        targetGroupName,
+			Description: "Delete the dedicated external API target group",
+		})
+	} else if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != elbv2.ErrCodeTargetGroupNotFoundException {
+		return nil, err
+	}
+
+	internalAPINLB, err := ac.getInteralAPINLB(kclient)
+	if err != nil {
+		return nil, err
+	}
+	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return nil, err
 	}
 	pubDomainName := baseDomain[strings.Index(baseDomain, ".")+1:]
 	apiDNSName := fmt.Sprintf("api.%s.", baseDomain)
-	// not tested yet
-	comment := "Update api.<clusterName> alias to external NLB"
-	err = ac.upsertARecord(pubDomainName+".",
-		newNLBs[0].dnsName,
-		newNLBs[0].canonicalHostedZoneNameID,
-		apiDNSName,
-		comment,
-		false)
+	publicHostedZoneID, err := ac.getPublicHostedZoneID(pubDomainName + ".")
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// success
-	return nil
+	recordExists, err := ac.recordExists(newAliasARecord(internalAPINLB.dnsName, internalAPINLB.canonicalHostedZoneNameID, apiDNSName, false), publicHostedZoneID)
+	if err != nil {
+		return nil, err
+	}
+	if !recordExists {
+		actions = append(actions, cloudingressv1alpha1.PlannedAction{
+			Action:      cloudingressv1alpha1.PlannedActionUpdate,
+			Kind:        cloudingressv1alpha1.PlannedResourceDNSRecord,
+			Name:        apiDNSName,
+			Description: fmt.Sprintf("Alias to the internal NLB %s in Route53 zone %s", internalAPINLB.dnsName, pubDomainName),
+		})
+	}
+	return actions, nil
+}
+
+// planDefaultAPIPublic lists the changes setDefaultAPIPublic would make: the
+// external NLB and its listener it creates and the api A record pointed to it.
+// When the external NLB already exists, only the subnets and the listener it's
+// missing are planned.
+func (ac *Client) planDefaultAPIPublic(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	nlbs, err := ac.listOwnedNLBs(kclient)
+	if err != nil {
+		return nil, err
+	}
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return nil, err
+	}
+	for _, networkLoadBalancer := range nlbs {
+		if networkLoadBalancer.scheme == "internet-facing" && strings.HasSuffix(networkLoadBalancer.loadBalancerName, "-ext") {
+			actions, err := ac.planExternalNLBSubnets(kclient, networkLoadBalancer)
+			if err != nil {
+				return nil, err
+			}
+			listener, err := ac.getNLBListener(networkLoadBalancer.loadBalancerArn)
+			if err != nil {
+				return nil, err
+			}
+			if listener == nil {
+				listenerActions, err := ac.planExternalNLBListener(kclient, infrastructureName, networkLoadBalancer.loadBalancerName, networkLoadBalancer.loadBalancerArn)
+				if err != nil {
+					return nil, err
+				}
+				return append(actions, listenerActions...), nil
+			}
+			// the instances of the dedicated target group are kept in sync
+			dedicatedName := dedicatedExternalAPITargetGroupName(infrastructureName)
+			dedicatedARN, err := ac.getTargetGroupArn(dedicatedName)
+			if err != nil {
+				if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeTargetGroupNotFoundException {
+					return actions, nil
+				}
+				return nil, err
+			}
+			instanceActions, err := ac.planTargetGroupInstances(kclient, dedicatedName, dedicatedARN)
+			if err != nil {
+				return nil, err
+			}
+			return append(actions, instanceActions...), nil
+		}
+	}
+	subnets, err := ac.getExternalNLBSubnets(kclient)
+	if err != nil {
+		return nil, err
+	}
+	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return nil, err
+	}
+	extNLBName := infrastructureName + "-ext"
+	pubDomainName := baseDomain[strings.Index(baseDomain, ".")+1:]
+	actions := []cloudingressv1alpha1.PlannedAction{{
+		Action:      cloudingressv1alpha1.PlannedActionCreate,
+		Kind:        cloudingressv1alpha1.PlannedResourceLoadBalancer,
+		Name:        extNLBName,
+		Description: fmt.Sprintf("Create an internet-facing NLB in the public subnets %s", formatSubnets(subnets)),
+	}}
+	listenerActions, err := ac.planExternalNLBListener(kclient, infrastructureName, extNLBName, "")
+	if err != nil {
+		return nil, err
+	}
+	actions = append(actions, listenerActions...)
+	return append(actions, cloudingressv1alpha1.PlannedAction{
+		Action:      cloudingressv1alpha1.PlannedActionUpdate,
+		Kind:        cloudingressv1alpha1.PlannedResourceDNSRecord,
+		Name:        fmt.Sprintf("api.%s.", baseDomain),
+		Description: fmt.Sprintf("Alias to the external NLB %s in Route53 zone %s", extNLBName, pubDomainName),
+	}), nil
+}
+
+// planAWSLBRemovalFromMasterMachines lists the control plane Machines which
+// removeAWSLBFromMasterMachines would update
+func planAWSLBRemovalFromMasterMachines(kclient k8s.Client, elbName string, masterNodes *machinev1beta1.MachineList) ([]cloudingressv1alpha1.PlannedAction, error) {
+	var actions []cloudingressv1alpha1.PlannedAction
+	for _, machine := range masterNodes.Items {
+		providerSpecDecoded, err := getAWSDecodedProviderSpec(machine, kclient.Scheme())
+		if err != nil {
+			return nil, err
+		}
+		for _, lb := range providerSpecDecoded.LoadBalancers {
+			if lb.Name == elbName {
+				actions = append(actions, cloudingressv1alpha1.PlannedAction{
+					Action:      cloudingressv1alpha1.PlannedActionUpdate,
+					Kind:        cloudingressv1alpha1.PlannedResourceMachine,
+					Name:        machine.Name,
+					Description: fmt.Sprintf("Remove load balancer %s from the providerSpec", elbName),
+				})
+				break
+			}
+		}
+	}
+	return actions, nil
 }
 
 // getMasterNodeSubnets returns all the subnets for Machines with 'master' label.
@@ -294,9 +858,11 @@ func getMasterNodeSubnets(kclient k8s.Client) (map[string]string, error) {
 	return subnets, nil
 }
 
-func (ac *Client) getPublicSubnets(kclient k8s.Client) ([]string, error) {
+// getPublicSubnets returns the subnets routed to an internet gateway in the VPC
+// of the control plane
+func (ac *Client) getPublicSubnets(kclient k8s.Client) ([]*ec2.Subnet, error) {
 
-	var publicSubnets []string
+	var publicSubnets []*ec2.Subnet
 
 	machineList, err := baseutils.GetMasterMachines(kclient)
 
@@ -355,13 +921,202 @@ func (ac *Client) getPublicSubnets(kclient k8s.Client) ([]string, error) {
 			return nil, err
 		}
 		if isPublic {
-			publicSubnets = append(publicSubnets, *subnet.SubnetId)
+			publicSubnets = append(publicSubnets, subnet)
 		}
 	}
 
 	return publicSubnets, nil
 }
 
+// getMasterNodeAvailabilityZones returns the availability zones of the Machines
+// with 'master' label, sorted
+func getMasterNodeAvailabilityZones(kclient k8s.Client) ([]string, error) {
+	machineList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return nil, err
+	}
+	if len(machineList.Items) == 0 {
+		return nil, fmt.Errorf("did not find any master Machine objects")
+	}
+	zones := map[string]string{}
+	for _, machine := range machineList.Items {
+		awsconfig, err := getAWSDecodedProviderSpec(machine, kclient.Scheme())
+		if err != nil {
+			return nil, err
+		}
+		if awsconfig.Placement.AvailabilityZone != "" {
+			zones[awsconfig.Placement.AvailabilityZone] = ""
+		}
+	}
+	return sortedZones(zones), nil
+}
+
+// getExternalNLBSubnets returns the public subnet the external NLB should have
+// in each availability zone of the control plane, indexed by zone. The NLB only
+// sends traffic to targets in its own zones, so a zone without a subnet would
+// leave its control plane Machines out of the external API.
+func (ac *Client) getExternalNLBSubnets(kclient k8s.Client) (map[string]string, error) {
+	zones, err := getMasterNodeAvailabilityZones(kclient)
+	if err != nil {
+		return nil, err
+	}
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return nil, err
+	}
+	publicSubnets, err := ac.getPublicSubnets(kclient)
+	if err != nil {
+		return nil, err
+	}
+	subnets := selectPublicSubnets(infrastructureName, zones, publicSubnets)
+	if len(subnets) == 0 {
+		return nil, goError.New("no public subnets, can't change API to public")
+	}
+	for _, zone := range zones {
+		if _, ok := subnets[zone]; !ok {
+			log.Info("No public subnet for the external NLB in a control plane availability zone", "zone", zone)
+		}
+	}
+	return subnets, nil
+}
+
+// selectPublicSubnets picks one public subnet in each of the zones. The subnet
+// the installer created for the zone, named <infrastructure-name>-public-<zone>,
+// is preferred, then the one with the lowest ID so the choice is stable.
+func selectPublicSubnets(infrastructureName string, zones []string, publicSubnets []*ec2.Subnet) map[string]string {
+	subnets := map[string]string{}
+	for _, zone := range zones {
+		installerName := fmt.Sprintf("%s-public-%s", infrastructureName, zone)
+		var chosen *ec2.Subnet
+		for _, subnet := range publicSubnets {
+			if aws.StringValue(subnet.AvailabilityZone) != zone {
+				continue
+			}
+			if subnetName(subnet) == installerName {
+				chosen = subnet
+				break
+			}
+			if chosen == nil || aws.StringValue(subnet.SubnetId) < aws.StringValue(chosen.SubnetId) {
+				chosen = subnet
+			}
+		}
+		if chosen != nil {
+			subnets[zone] = aws.StringValue(chosen.SubnetId)
+		}
+	}
+	return subnets
+}
+
+// subnetName returns the Name tag of the subnet
+func subnetName(subnet *ec2.Subnet) string {
+	for _, tag := range subnet.Tags {
+		if aws.StringValue(tag.Key) == "Name" {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
+
+// subnetsByZone returns the subnet ID of each availability zone of a load balancer
+func subnetsByZone(zones []*elbv2.AvailabilityZone) map[string]string {
+	var subnets map[string]string
+	for _, zone := range zones {
+		if aws.StringValue(zone.ZoneName) == "" || aws.StringValue(zone.SubnetId) == "" {
+			continue
+		}
+		if subnets == nil {
+			subnets = map[string]string{}
+		}
+		subnets[aws.StringValue(zone.ZoneName)] = aws.StringValue(zone.SubnetId)
+	}
+	return subnets
+}
+
+// sortedZones returns the zones of a subnet map, sorted
+func sortedZones(subnets map[string]string) []string {
+	zones := make([]string, 0, len(subnets))
+	for zone := range subnets {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// formatSubnets lists the subnets with their zone, in the order of the zones
+func formatSubnets(subnets map[string]string) string {
+	formatted := make([]string, 0, len(subnets))
+	for _, zone := range sortedZones(subnets) {
+		formatted = append(formatted, fmt.Sprintf("%s (%s)", subnets[zone], zone))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// missingExternalNLBSubnets returns the subnets of the zones the external NLB
+// should be in but isn't. Subnets in other zones are left alone, the NLB can't
+// swap the subnet of a zone it's already in.
+func (ac *Client) missingExternalNLBSubnets(kclient k8s.Client, networkLoadBalancer loadBalancerV2) (map[string]string, error) {
+	desired, err := ac.getExternalNLBSubnets(kclient)
+	if err != nil {
+		return nil, err
+	}
+	missing := map[string]string{}
+	for zone, subnetID := range desired {
+		if _, ok := networkLoadBalancer.subnets[zone]; !ok {
+			missing[zone] = subnetID
+		}
+	}
+	return missing, nil
+}
+
+// reconcileExternalNLBSubnets adds the external NLB to the public subnets of
+// the control plane zones it has drifted from
+func (ac *Client) reconcileExternalNLBSubnets(ctx context.Context, kclient k8s.Client, networkLoadBalancer loadBalancerV2) error {
+	missing, err := ac.missingExternalNLBSubnets(kclient, networkLoadBalancer)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	subnetIDs := make([]string, 0, len(networkLoadBalancer.subnets)+len(missing))
+	for _, zone := range sortedZones(networkLoadBalancer.subnets) {
+		subnetIDs = append(subnetIDs, networkLoadBalancer.subnets[zone])
+	}
+	for _, zone := range sortedZones(missing) {
+		subnetIDs = append(subnetIDs, missing[zone])
+	}
+	_, err = ac.elbv2Client.SetSubnets(&elbv2.SetSubnetsInput{
+		LoadBalancerArn: aws.String(networkLoadBalancer.loadBalancerArn),
+		Subnets:         aws.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		cioevents.Warning(ctx, cioevents.ReasonLoadBalancerUpdateFailed, cioevents.ActionUpdate, "Couldn't add the subnets %s to the external NLB %s: %v", formatSubnets(missing), networkLoadBalancer.loadBalancerName, err)
+		return err
+	}
+	cioevents.Normal(ctx, cioevents.ReasonLoadBalancerUpdated, cioevents.ActionUpdate, "Added the subnets %s to the external NLB %s", formatSubnets(missing), networkLoadBalancer.loadBalancerName)
+	return nil
+}
+
+// planExternalNLBSubnets lists the subnets reconcileExternalNLBSubnets would add
+func (ac *Client) planExternalNLBSubnets(kclient k8s.Client, networkLoadBalancer loadBalancerV2) ([]cloudingressv1alpha1.PlannedAction, error) {
+	missing, err := ac.missingExternalNLBSubnets(kclient, networkLoadBalancer)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	return []cloudingressv1alpha1.PlannedAction{
+		{
+			Action:      cloudingressv1alpha1.PlannedActionUpdate,
+			Kind:        cloudingressv1alpha1.PlannedResourceLoadBalancer,
+			Name:        networkLoadBalancer.loadBalancerName,
+			Description: fmt.Sprintf("Add the public subnets %s", formatSubnets(missing)),
+		},
+	}, nil
+}
+
 func (ac *Client) getAllSubnetsInVPC(vpcID string) ([]*ec2.Subnet, error) {
 
 	var subnetIDs []*ec2.Subnet
@@ -524,16 +1279,57 @@ func (ac *Client) doesELBExist(elbName string) (*awsLoadBalancer, error) {
 		nil
 }
 
-// route53
+// doesNLBExist checks for the existence of an NLB by name. If there's an AWS
+// error it is returned.
+func (ac *Client) doesNLBExist(nlbName string) (*awsLoadBalancer, error) {
+	output, err := ac.elbv2Client.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		Names: []*string{aws.String(nlbName)},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeLoadBalancerNotFoundException {
+			return &awsLoadBalancer{}, errors.NewLoadBalancerNotReadyError()
+		}
+		return &awsLoadBalancer{}, err
+	}
+	if len(output.LoadBalancers) == 0 {
+		return &awsLoadBalancer{}, errors.NewLoadBalancerNotReadyError()
+	}
+	return &awsLoadBalancer{
+			elbName:   nlbName,
+			dnsName:   aws.StringValue(output.LoadBalancers[0].DNSName),
+			dnsZoneID: aws.StringValue(output.LoadBalancers[0].CanonicalHostedZoneId),
+			dualStack: isDualStack(aws.StringValue(output.LoadBalancers[0].IpAddressType))},
+		nil
+}
+
+// isDualStack returns whether an NLB of the IP address type has IPv6 addresses
+func isDualStack(ipAddressType string) bool {
+	return strings.HasPrefix(ipAddressType, elbv2.IpAddressTypeDualstack)
+}
+
+// serviceLoadBalancer returns the NLB or the Classic ELB of a LoadBalancer
+// Service, as chosen by its aws-load-balancer-type annotation
+func (ac *Client) serviceLoadBalancer(svc *corev1.Service) (*awsLoadBalancer, error) {
+	if strings.EqualFold(svc.Annotations[serviceLoadBalancerTypeAnnotation], "nlb") {
+		return ac.doesNLBExist(serviceELBName(svc))
+	}
+	return ac.doesELBExist(serviceELBName(svc))
+}
 
-func (ac *Client) ensureDNSForService(ctx context.Context, kclient k8s.Client, svc *corev1.Service, dnsName, dnsComment string) error {
-	// Get the ELB name from the Service's UID. Truncate to 32 characters for AWS
+// serviceELBName returns the name of the ELB of a LoadBalancer Service, which
+// is derived from the Service's UID and truncated to 32 characters for AWS
+func serviceELBName(svc *corev1.Service) string {
 	elbName := strings.ReplaceAll("a"+string(svc.UID), "-", "")
 	if len(elbName) > 32 {
-		// Truncate to 32 characters
 		elbName = elbName[0:32]
 	}
-	awsELB, err := ac.doesELBExist(elbName)
+	return elbName
+}
+
+// route53
+
+func (ac *Client) ensureDNSForService(ctx context.Context, kclient k8s.Client, svc *corev1.Service, dnsName, dnsComment string, internal bool) error {
+	awsELB, err := ac.serviceLoadBalancer(svc)
 	// Primarily checking to see if this exists. It is an error if it does not,
 	// likely because AWS is still creating it and the Reconcile should be retried
 	if err != nil {
@@ -547,15 +1343,14 @@ func (ac *Client) ensureDNSForService(ctx context.Context, kclient k8s.Client, s
 	lb := &loadBalancer{
 		endpointName: dnsName,
 		baseDomain:   clusterBaseDomain,
+		internal:     internal,
 	}
-	return ac.ensureDNSRecord(lb, awsELB, dnsComment)
+	return ac.ensureDNSRecord(ctx, lb, awsELB, dnsComment)
 }
 
 // removeDNSForService will remove a DNS entry for a particular Service
 func (ac *Client) removeDNSForService(ctx context.Context, kclient k8s.Client, svc *corev1.Service, dnsName, dnsComment string) error {
-	// Get the ELB name from the Service's UID. Truncate to 32 characters for AWS
-	elbName := strings.ReplaceAll("a"+string(svc.UID), "-", "")[0:32]
-	awsELB, err := ac.doesELBExist(elbName)
+	awsELB, err := ac.serviceLoadBalancer(svc)
 	// Primarily checking to see if this exists. It is an error if it does not,
 	// likely because AWS is still creating it and the Reconcile should be retried
 	if err != nil {
@@ -566,7 +1361,7 @@ func (ac *Client) removeDNSForService(ctx context.Context, kclient k8s.Client, s
 	if err != nil {
 		return err
 	}
-	return ac.ensureDNSRecordsRemoved(
+	return ac.ensureDNSRecordsRemoved(ctx,
 		clusterBaseDomain,
 		awsELB.dnsName,
 		awsELB.dnsZoneID,
@@ -575,7 +1370,84 @@ func (ac *Client) removeDNSForService(ctx context.Context, kclient k8s.Client, s
 		false)
 }
 
-func (ac *Client) deleteARecord(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName string, targetHealth bool) error {
+// removeDNSForName removes the A and AAAA records for dnsName from the private and
+// public zones, whatever they alias to
+func (ac *Client) removeDNSForName(ctx context.Context, kclient k8s.Client, dnsName string) error {
+	clusterBaseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return err
+	}
+	resourceRecordSetName := dnsName + "." + clusterBaseDomain + "."
+	zones := []string{
+		clusterBaseDomain + ".",
+		// The public zone name omits the cluster name.
+		// e.g. mycluster.abcd.s1.openshift.com -> abcd.s1.openshift.com
+		clusterBaseDomain[strings.Index(clusterBaseDomain, ".")+1:] + ".",
+	}
+	for _, zone := range zones {
+		for _, recordType := range []string{route53.RRTypeA, route53.RRTypeAaaa} {
+			if err := ac.deleteRecordsByName(ctx, recordType, zone, resourceRecordSetName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteARecordsByName deletes every A record named resourceRecordSetName
+// from the hosted zone of clusterDomain
+func (ac *Client) deleteARecordsByName(ctx context.Context, clusterDomain, resourceRecordSetName string) error {
+	return ac.deleteRecordsByName(ctx, route53.RRTypeA, clusterDomain, resourceRecordSetName)
+}
+
+// deleteRecordsByName deletes every record of the type named
+// resourceRecordSetName from the hosted zone of clusterDomain
+func (ac *Client) deleteRecordsByName(ctx context.Context, recordType, clusterDomain, resourceRecordSetName string) error {
+	hostedZoneID, err := ac.getPublicHostedZoneID(clusterDomain)
+	if err != nil {
+		return err
+	}
+
+	var changes []*route53.Change
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneID),
+		StartRecordName: aws.String(resourceRecordSetName),
+		StartRecordType: aws.String(recordType),
+	}
+	err = ac.route53Client.ListResourceRecordSetsPages(input, func(p *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		for _, record := range p.ResourceRecordSets {
+			// The records are sorted by name and type from the start of the listing,
+			// so the first other one means there are no more to delete
+			if aws.StringValue(record.Name) != resourceRecordSetName || aws.StringValue(record.Type) != recordType {
+				return false
+			}
+			// A deletion has to match the record exactly, so reuse it as returned by route53
+			changes = append(changes, &route53.Change{
+				Action:            aws.String("DELETE"),
+				ResourceRecordSet: record,
+			})
+		}
+		return true
+	})
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+
+	log.Info("Deleting DNS records", "zone", clusterDomain, "name", resourceRecordSetName, "count", len(changes))
+	_, err = ac.route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: changes,
+		},
+		HostedZoneId: aws.String(hostedZoneID),
+	})
+	if err != nil {
+		return err
+	}
+	cioevents.Normal(ctx, cioevents.ReasonDNSRecordDeleted, cioevents.ActionDelete, "Deleted the %s records for %s from Route53 zone %s", recordType, resourceRecordSetName, clusterDomain)
+	return nil
+}
+
+func (ac *Client) deleteARecord(ctx context.Context, clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName string, targetHealth bool) error {
 	publicHostedZoneID, err := ac.getPublicHostedZoneID(clusterDomain)
 	if err != nil {
 		return err
@@ -617,9 +1489,10 @@ func (ac *Client) deleteARecord(clusterDomain, DNSName, aliasDNSZoneID, resource
 				return nil
 			}
 		}
+		return err
 	}
-
-	return err
+	cioevents.Normal(ctx, cioevents.ReasonDNSRecordDeleted, cioevents.ActionDelete, "Deleted the A record for %s from Route53 zone %s", resourceRecordSetName, clusterDomain)
+	return nil
 }
 
 // recordExists checks if a specific RecordSet already exist in route53
@@ -662,22 +1535,40 @@ func (ac *Client) recordExists(resourceRecordSet *route53.ResourceRecordSet, pub
 	return recordExists, err
 }
 
-func (ac *Client) upsertARecord(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
-	publicHostedZoneID, err := ac.getPublicHostedZoneID(clusterDomain)
-	if err != nil {
-		return err
-	}
+// newAliasARecord returns an A record named resourceRecordSetName aliasing the
+// load balancer DNSName
+func newAliasARecord(DNSName, aliasDNSZoneID, resourceRecordSetName string, targetHealth bool) *route53.ResourceRecordSet {
+	return newAliasRecord(route53.RRTypeA, DNSName, aliasDNSZoneID, resourceRecordSetName, targetHealth)
+}
 
-	resourceRecordSet := &route53.ResourceRecordSet{
+// newAliasRecord returns an A or AAAA record named resourceRecordSetName
+// aliasing the load balancer DNSName
+func newAliasRecord(recordType, DNSName, aliasDNSZoneID, resourceRecordSetName string, targetHealth bool) *route53.ResourceRecordSet {
+	return &route53.ResourceRecordSet{
 		AliasTarget: &route53.AliasTarget{
 			DNSName:              aws.String(DNSName),
 			EvaluateTargetHealth: aws.Bool(targetHealth),
 			HostedZoneId:         aws.String(aliasDNSZoneID),
 		},
 		Name: aws.String(resourceRecordSetName),
-		Type: aws.String("A"),
+		Type: aws.String(recordType),
+	}
+}
+
+func (ac *Client) upsertARecord(ctx context.Context, clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
+	return ac.upsertAliasRecord(ctx, route53.RRTypeA, clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment, targetHealth)
+}
+
+// upsertAliasRecord points the A or AAAA record resourceRecordSetName in the
+// hosted zone of clusterDomain to the load balancer DNSName
+func (ac *Client) upsertAliasRecord(ctx context.Context, recordType, clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
+	publicHostedZoneID, err := ac.getPublicHostedZoneID(clusterDomain)
+	if err != nil {
+		return err
 	}
 
+	resourceRecordSet := newAliasRecord(recordType, DNSName, aliasDNSZoneID, resourceRecordSetName, targetHealth)
+
 	recordExists, err := ac.recordExists(resourceRecordSet, publicHostedZoneID)
 	if err != nil || recordExists {
 		return err
@@ -696,7 +1587,20 @@ func (ac *Client) upsertARecord(clusterDomain, DNSName, aliasDNSZoneID, resource
 		HostedZoneId: aws.String(publicHostedZoneID),
 	}
 	_, err = ac.route53Client.ChangeResourceRecordSets(change)
-	return err
+	if err != nil {
+		return err
+	}
+	cioevents.Normal(ctx, cioevents.ReasonDNSRecordUpdated, cioevents.ActionUpdate, "Pointed %s to %s in Route53 zone %s", resourceRecordSetName, DNSName, clusterDomain)
+	return nil
+}
+
+// ensureAAAARecord points the AAAA record resourceRecordSetName to a dual-stack
+// load balancer, or removes it when the load balancer only has IPv4 addresses
+func (ac *Client) ensureAAAARecord(ctx context.Context, clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, dualStack bool) error {
+	if dualStack {
+		return ac.upsertAliasRecord(ctx, route53.RRTypeAaaa, clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment, false)
+	}
+	return ac.deleteRecordsByName(ctx, route53.RRTypeAaaa, clusterDomain, strings.TrimSuffix(resourceRecordSetName, ".")+".")
 }
 
 func (ac *Client) getPublicHostedZoneID(clusterDomain string) (string, error) {
@@ -717,135 +1621,197 @@ func (ac *Client) getPublicHostedZoneID(clusterDomain string) (string, error) {
 
 }
 
-func (ac *Client) ensureDNSRecord(lb *loadBalancer, awsObj *awsLoadBalancer, comment string) error {
-	// private zone
-
-	for i := 1; i <= config.MaxAPIRetries; i++ {
-		err := ac.upsertARecord(
-			lb.baseDomain+".",
-			awsObj.dnsName,
-			awsObj.dnsZoneID,
-			lb.endpointName+"."+lb.baseDomain,
-			comment,
-			false)
-		if err != nil {
-			log.Error(err, "Couldn't upsert A record for private zone",
-				"retryAttempt", i,
-				"publicZone", lb.baseDomain+".",
-				"dnsName", awsObj.dnsName,
-				"dnsZoneID", awsObj.dnsZoneID,
-				"endpointName", lb.endpointName+".", lb.baseDomain)
-			if i == config.MaxAPIRetries {
-				log.Error(err, "Couldn't upsert A record for private zone: Retries Exhausted")
-				return err
-			}
-			// TODO: Logging - sleep
-			time.Sleep(time.Duration(i) * time.Second)
-		} else {
-			// success
-			break
-		}
-	}
-
-	// Public zone
-	// The public zone omits the cluster name. So an example:
-	// A cluster's base domain of alice-cluster.l4s7.s1.domain.com will need an
-	// entry made in l4s7.s1.domain.com. zone.
+func (ac *Client) ensureDNSRecord(ctx context.Context, lb *loadBalancer, awsObj *awsLoadBalancer, comment string) error {
+	recordName := lb.endpointName + "." + lb.baseDomain
 	// Public zone
 	// The public zone omits the cluster name. So an example:
 	// A cluster's base domain of alice-cluster.l4s7.s1.domain.com will need an
 	// entry made in l4s7.s1.domain.com. zone.
 	publicZone := lb.baseDomain[strings.Index(lb.baseDomain, ".")+1:]
 
-	for i := 1; i <= config.MaxAPIRetries; i++ {
-		// Append a . to get the zone name
-		err := ac.upsertARecord(
-			publicZone+".",
-			awsObj.dnsName,
-			awsObj.dnsZoneID,
-			lb.endpointName+"."+lb.baseDomain,
-			"RH API Endpoint",
-			false)
-		if err != nil {
-			log.Error(err, "Couldn't upsert A record for public zone",
-				"retryAttempt", i,
-				"publicZone", publicZone+".",
-				"dnsName", awsObj.dnsName,
-				"dnsZoneID", awsObj.dnsZoneID,
-				"endpointName", lb.endpointName+".", lb.baseDomain)
-			if i == config.MaxAPIRetries {
-				log.Error(err, "Couldn't upsert A record for public zone: Retries Exhausted")
+	return retry.Parallel(maxParallelRoute53Changes,
+		func() error {
+			return route53Backoff.Do("route53:UpsertRecord", lb.baseDomain+"/"+recordName, func() error {
+				err := ac.upsertARecord(ctx, lb.baseDomain+".", awsObj.dnsName, awsObj.dnsZoneID, recordName, comment, false)
+				if err == nil {
+					err = ac.ensureAAAARecord(ctx, lb.baseDomain+".", awsObj.dnsName, awsObj.dnsZoneID, recordName, comment, awsObj.dualStack)
+				}
+				if err != nil {
+					log.Error(err, "Couldn't upsert A record for private zone",
+						"privateZone", lb.baseDomain+".",
+						"dnsName", awsObj.dnsName,
+						"dnsZoneID", awsObj.dnsZoneID,
+						"endpointName", recordName)
+				}
 				return err
+			})
+		},
+		func() error {
+			if lb.internal {
+				// Left by an external load balancer the endpoint was moved from
+				return route53Backoff.Do("route53:DeleteRecord", publicZone+"/"+recordName, func() error {
+					if err := ac.deleteARecordsByName(ctx, publicZone+".", recordName+"."); err != nil {
+						return err
+					}
+					return ac.deleteRecordsByName(ctx, route53.RRTypeAaaa, publicZone+".", recordName+".")
+				})
 			}
-			time.Sleep(time.Duration(i) * time.Second)
-		} else {
-			// success
-			break
+			return route53Backoff.Do("route53:UpsertRecord", publicZone+"/"+recordName, func() error {
+				// Append a . to get the zone name
+				err := ac.upsertARecord(ctx, publicZone+".", awsObj.dnsName, awsObj.dnsZoneID, recordName, "RH API Endpoint", false)
+				if err == nil {
+					err = ac.ensureAAAARecord(ctx, publicZone+".", awsObj.dnsName, awsObj.dnsZoneID, recordName, "RH API Endpoint", awsObj.dualStack)
+				}
+				if err != nil {
+					log.Error(err, "Couldn't upsert A record for public zone",
+						"publicZone", publicZone+".",
+						"dnsName", awsObj.dnsName,
+						"dnsZoneID", awsObj.dnsZoneID,
+						"endpointName", recordName)
+				}
+				return err
+			})
+		})
+}
+
+// checkAliasRecords compares the records with the ones in Route53 and returns
+// those which have drifted. They are upserted again when repair is true.
+func (ac *Client) checkAliasRecords(ctx context.Context, repair bool, records ...aliasRecord) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	var drifts []cloudingressv1alpha1.DNSRecordDrift
+	var errs []error
+	for _, expected := range records {
+		drift, err := ac.checkAliasRecord(ctx, expected, repair)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not check %s in zone %s: %w", expected.name, expected.zoneName, err))
+		}
+		if drift != nil {
+			drifts = append(drifts, *drift)
 		}
 	}
-	return nil
+	return drifts, goError.Join(errs...)
 }
 
-// ensureDNSRecordsRemoved undoes ensureDNSRecord
-func (ac *Client) ensureDNSRecordsRemoved(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
-	for i := 1; i <= config.MaxAPIRetries; i++ {
-		err := ac.deleteARecord(
-			clusterDomain+".",
-			DNSName,
-			aliasDNSZoneID,
-			resourceRecordSetName,
-			targetHealth)
-		if err != nil {
-			// retry
-			// TODO: logging
-			if i == config.MaxAPIRetries {
-				// TODO: logging
-				return err
-			}
-			// TODO: logging
-			time.Sleep(time.Duration(i) * time.Second)
-		} else {
-			break
-		}
-	}
-	for i := 1; i <= config.MaxAPIRetries; i++ {
-		err := ac.deleteARecord(
-			// The public zone name omits the cluster name.
-			// e.g. mycluster.abcd.s1.openshift.com -> abcd.s1.openshift.com
-			clusterDomain[strings.Index(clusterDomain, ".")+1:]+".",
-			DNSName,
-			aliasDNSZoneID,
-			resourceRecordSetName,
-			targetHealth)
-		if err != nil {
-			// retry
-			// TODO: logging
-			if i == config.MaxAPIRetries {
-				// TODO: logging
-				return err
+// checkAliasRecord returns the drift of a record, nil when it aliases the
+// expected load balancer. A repaired record is still returned, with Repaired set.
+func (ac *Client) checkAliasRecord(ctx context.Context, expected aliasRecord, repair bool) (*cloudingressv1alpha1.DNSRecordDrift, error) {
+	hostedZoneID, err := ac.getPublicHostedZoneID(expected.zoneName + ".")
+	if err != nil {
+		return nil, err
+	}
+	actual, err := ac.getARecord(hostedZoneID, expected.name)
+	if err != nil {
+		return nil, err
+	}
+	if actual != nil && actual.AliasTarget != nil &&
+		sameAliasTarget(aws.StringValue(actual.AliasTarget.DNSName), expected.dnsName) &&
+		aws.StringValue(actual.AliasTarget.HostedZoneId) == expected.dnsZoneID {
+		return nil, nil
+	}
+
+	drift := &cloudingressv1alpha1.DNSRecordDrift{
+		Name:     expected.name,
+		Zone:     expected.zone,
+		Expected: "ALIAS " + strings.TrimSuffix(expected.dnsName, ".") + ".",
+		Actual:   describeARecord(actual),
+	}
+	if !repair {
+		return drift, nil
+	}
+	err = route53Backoff.Do("route53:UpsertRecord", expected.zoneName+"/"+expected.name, func() error {
+		return ac.upsertARecord(ctx, expected.zoneName+".", expected.dnsName, expected.dnsZoneID, expected.name, expected.comment, false)
+	})
+	if err != nil {
+		return drift, err
+	}
+	drift.Repaired = true
+	return drift, nil
+}
+
+// getARecord returns the A record named resourceRecordSetName from the hosted
+// zone, nil when there is none
+func (ac *Client) getARecord(hostedZoneID, resourceRecordSetName string) (*route53.ResourceRecordSet, error) {
+	var found *route53.ResourceRecordSet
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneID),
+		StartRecordName: aws.String(resourceRecordSetName),
+		StartRecordType: aws.String("A"),
+	}
+	err := ac.route53Client.ListResourceRecordSetsPages(input, func(p *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		// The listing starts at the record, so it is the first one or there is none
+		if len(p.ResourceRecordSets) > 0 {
+			record := p.ResourceRecordSets[0]
+			if aws.StringValue(record.Name) == resourceRecordSetName && aws.StringValue(record.Type) == "A" {
+				found = record
 			}
-			// TODO: logging
-			time.Sleep(time.Duration(i) * time.Second)
-		} else {
-			break
 		}
+		return false
+	})
+	return found, err
+}
+
+// sameAliasTarget compares the DNS names of load balancers. Route53 stores
+// them in lowercase with a trailing dot, and prefixes those made through the
+// console with dualstack.
+func sameAliasTarget(actual, expected string) bool {
+	normalize := func(name string) string {
+		return strings.TrimPrefix(strings.TrimSuffix(strings.ToLower(name), "."), "dualstack.")
 	}
-	// public
-	return nil
+	return normalize(actual) == normalize(expected)
+}
+
+// describeARecord returns the target of an A record for the status, empty when
+// there is no record
+func describeARecord(record *route53.ResourceRecordSet) string {
+	switch {
+	case record == nil:
+		return ""
+	case record.AliasTarget != nil:
+		return "ALIAS " + aws.StringValue(record.AliasTarget.DNSName)
+	}
+	values := make([]string, 0, len(record.ResourceRecords))
+	for _, rr := range record.ResourceRecords {
+		values = append(values, aws.StringValue(rr.Value))
+	}
+	return fmt.Sprintf("A %s TTL %d", strings.Join(values, ","), aws.Int64Value(record.TTL))
+}
+
+// ensureDNSRecordsRemoved undoes ensureDNSRecord
+func (ac *Client) ensureDNSRecordsRemoved(ctx context.Context, clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
+	// The public zone name omits the cluster name.
+	// e.g. mycluster.abcd.s1.openshift.com -> abcd.s1.openshift.com
+	publicZone := clusterDomain[strings.Index(clusterDomain, ".")+1:]
+
+	calls := []func() error{}
+	for _, zone := range []string{clusterDomain, publicZone} {
+		calls = append(calls, func() error {
+			return route53Backoff.Do("route53:DeleteRecord", zone+"/"+resourceRecordSetName, func() error {
+				err := ac.deleteARecord(ctx, zone+".", DNSName, aliasDNSZoneID, resourceRecordSetName, targetHealth)
+				if err == nil {
+					err = ac.deleteRecordsByName(ctx, route53.RRTypeAaaa, zone+".", strings.TrimSuffix(resourceRecordSetName, ".")+".")
+				}
+				if err != nil {
+					log.Error(err, "Couldn't delete A record", "zone", zone+".", "endpointName", resourceRecordSetName)
+				}
+				return err
+			})
+		})
+	}
+	return retry.Parallel(maxParallelRoute53Changes, calls...)
 }
 
 // ELBv2
 
-// removeLoadBalancerFromMasterNodes
-func (ac *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient k8s.Client) (string, string, error) {
+// removeLoadBalancerFromMasterNodes deletes the external API NLB and returns
+// the internal one
+func (ac *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) (loadBalancerV2, error) {
 	clusterName, err := baseutils.GetClusterName(kclient)
 	if err != nil {
-		return "", "", err
+		return loadBalancerV2{}, err
 	}
 
 	nlbs, err := ac.listOwnedNLBs(kclient)
 	if err != nil {
-		return "", "", err
+		return loadBalancerV2{}, err
 	}
 
 	// Detect if this is a CPMS active/inactive cluster and choose the right strategy:
@@ -854,13 +1820,13 @@ func (ac *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 	// 3. Readd the CPMS if needed
 	masterList, err := baseutils.GetMasterMachines(kclient)
 	if err != nil {
-		return "", "", err
+		return loadBalancerV2{}, err
 	}
 	var cpms *machinev1.ControlPlaneMachineSet
 	cpms, err = baseutils.GetControlPlaneMachineSet(kclient)
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
-			return "", "", err
+			return loadBalancerV2{}, err
 		}
 		// If there is no CPMS we handle it as an inactive one.
 		cpms = &machinev1.ControlPlaneMachineSet{
@@ -869,8 +1835,8 @@ func (ac *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 			},
 		}
 	}
-	removalClosure := getLoadBalancerRemovalFunc(ctx, kclient, masterList, cpms)
-	var intDNSName, intHostedZoneID, lbName string
+	removalClosure := getLoadBalancerRemovalFunc(ctx, kclient, instance, masterList, cpms)
+	var lbName string
 	for _, networkLoadBalancer := range nlbs {
 		if networkLoadBalancer.scheme == "internet-facing" {
 
@@ -879,7 +1845,7 @@ func (ac *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 			canDelete, err := ac.canDeleteNlb(networkLoadBalancer, clusterName)
 			if err != nil {
 				log.Error(err, "Problem attempting to remove", "NLB", networkLoadBalancer.loadBalancerName)
-				return "", "", err
+				return loadBalancerV2{}, err
 			}
 
 			if canDelete {
@@ -887,11 +1853,13 @@ func (ac *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 				log.Info("Removing from cluster", "NLB", networkLoadBalancer.loadBalancerName)
 				err = ac.deleteExternalLoadBalancer(networkLoadBalancer.loadBalancerArn)
 				if err != nil {
-					return "", "", err
+					cioevents.Warning(ctx, cioevents.ReasonLoadBalancerDeleteFailed, cioevents.ActionDelete, "Couldn't delete the external NLB %s: %v", lbName, err)
+					return loadBalancerV2{}, err
 				}
+				cioevents.Normal(ctx, cioevents.ReasonLoadBalancerDeleted, cioevents.ActionDelete, "Deleted the external NLB %s", lbName)
 				err = removalClosure(lbName)
 				if err != nil {
-					return "", "", err
+					return loadBalancerV2{}, err
 				}
 			}
 
@@ -900,15 +1868,12 @@ func (ac *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 
 	internalAPINLB, err := ac.getInteralAPINLB(kclient)
 	if err != nil {
-		return "", "", err
+		return loadBalancerV2{}, err
 	}
 
 	// Only use the NLB specifically created for internal traffic
 	// This is to avoid other internal NLBs that may come from Service objects
-	intDNSName = internalAPINLB.dnsName
-	intHostedZoneID = internalAPINLB.canonicalHostedZoneNameID
-
-	return intDNSName, intHostedZoneID, nil
+	return internalAPINLB, nil
 }
 
 func (ac *Client) getInteralAPINLB(kclient k8s.Client) (loadBalancerV2, error) {
@@ -1024,7 +1989,9 @@ func (ac *Client) listOwnedNLBs(kclient k8s.Client) ([]loadBalancerV2, error) {
 			loadBalancerArn:           aws.StringValue(loadBalancer.LoadBalancerArn),
 			loadBalancerName:          aws.StringValue(loadBalancer.LoadBalancerName),
 			scheme:                    aws.StringValue(loadBalancer.Scheme),
+			ipAddressType:             aws.StringValue(loadBalancer.IpAddressType),
 			vpcID:                     aws.StringValue(loadBalancer.VpcId),
+			subnets:                   subnetsByZone(loadBalancer.AvailabilityZones),
 		})
 	}
 	return loadBalancers, nil
@@ -1039,16 +2006,18 @@ func (ac *Client) deleteExternalLoadBalancer(extLoadBalancerArn string) error {
 	return err
 }
 
-// createNetworkLoadBalancer should only return one new NLB at a time
-func (ac *Client) createNetworkLoadBalancer(lbName, scheme, subnet string, tags []*elbv2.Tag) ([]loadBalancerV2, error) {
+// createNetworkLoadBalancer should only return one new NLB at a time. An empty
+// ipAddressType is ipv4.
+func (ac *Client) createNetworkLoadBalancer(lbName, scheme, ipAddressType string, subnets []string, tags []*elbv2.Tag) ([]loadBalancerV2, error) {
 	i := &elbv2.CreateLoadBalancerInput{
-		Name:   aws.String(lbName),
-		Scheme: aws.String(scheme),
-		Subnets: []*string{
-			aws.String(subnet),
-		},
-		Type: aws.String("network"),
-		Tags: tags,
+		Name:    aws.String(lbName),
+		Scheme:  aws.String(scheme),
+		Subnets: aws.StringSlice(subnets),
+		Type:    aws.String("network"),
+		Tags:    tags,
+	}
+	if ipAddressType != "" {
+		i.IpAddressType = aws.String(ipAddressType)
 	}
 
 	result, err := ac.elbv2Client.CreateLoadBalancer(i)
@@ -1066,7 +2035,9 @@ func (ac *Client) createNetworkLoadBalancer(lbName, scheme, subnet string, tags
 			loadBalancerArn:           aws.StringValue(loadBalancer.LoadBalancerArn),
 			loadBalancerName:          aws.StringValue(loadBalancer.LoadBalancerName),
 			scheme:                    aws.StringValue(loadBalancer.Scheme),
+			ipAddressType:             aws.StringValue(loadBalancer.IpAddressType),
 			vpcID:                     aws.StringValue(loadBalancer.VpcId),
+			subnets:                   subnetsByZone(loadBalancer.AvailabilityZones),
 		})
 	}
 	return loadBalancers, nil
@@ -1093,6 +2064,217 @@ func (ac *Client) createListenerForNLB(targetGroupArn, loadBalancerArn string) e
 	return nil
 }
 
+// dedicatedExternalAPITargetGroupName returns the name of the target group
+// created by ensureDedicatedExternalAPITargetGroup
+func dedicatedExternalAPITargetGroupName(infrastructureName string) string {
+	return fmt.Sprintf("%s-%s", infrastructureName, operatorconfig.Get().DedicatedExternalAPITargetGroupSuffix)
+}
+
+// ensureDedicatedExternalAPITargetGroup returns the ARN of the dedicated
+// external API target group, which is created in the VPC when it doesn't exist
+// yet. The control plane instances are registered with it.
+func (ac *Client) ensureDedicatedExternalAPITargetGroup(ctx context.Context, kclient k8s.Client, vpcID string) (string, error) {
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return "", err
+	}
+	targetGroupName := dedicatedExternalAPITargetGroupName(infrastructureName)
+	targetGroupARN, err := ac.getTargetGroupArn(targetGroupName)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != elbv2.ErrCodeTargetGroupNotFoundException {
+			return "", err
+		}
+		// same health check as the installer's external API target group
+		result, err := ac.elbv2Client.CreateTargetGroup(&elbv2.CreateTargetGroupInput{
+			Name:                       aws.String(targetGroupName),
+			Protocol:                   aws.String("TCP"),
+			Port:                       aws.Int64(6443),
+			VpcId:                      aws.String(vpcID),
+			TargetType:                 aws.String("instance"),
+			HealthCheckProtocol:        aws.String("HTTPS"),
+			HealthCheckPath:            aws.String("/readyz"),
+			HealthCheckPort:            aws.String("6443"),
+			HealthCheckIntervalSeconds: aws.Int64(10),
+			HealthyThresholdCount:      aws.Int64(2),
+			UnhealthyThresholdCount:    aws.Int64(2),
+			Tags: []*elbv2.Tag{
+				{Key: aws.String("kubernetes.io/cluster/" + infrastructureName), Value: aws.String("owned")},
+				{Key: aws.String("Name"), Value: aws.String(targetGroupName)},
+				{Key: aws.String("red-hat-managed"), Value: aws.String("true")},
+			},
+		})
+		if err != nil {
+			cioevents.Warning(ctx, cioevents.ReasonLoadBalancerCreateFailed, cioevents.ActionCreate, "Couldn't create the target group %s: %v", targetGroupName, err)
+			return "", fmt.Errorf("couldn't create the target group %s: %w", targetGroupName, err)
+		}
+		if len(result.TargetGroups) != 1 {
+			return "", fmt.Errorf("expected 1 new target group, got %d", len(result.TargetGroups))
+		}
+		targetGroupARN = aws.StringValue(result.TargetGroups[0].TargetGroupArn)
+		cioevents.Normal(ctx, cioevents.ReasonLoadBalancerCreated, cioevents.ActionCreate, "Created the target group %s", targetGroupName)
+	}
+
+	if err := ac.syncTargetGroupInstances(ctx, kclient, targetGroupName, targetGroupARN); err != nil {
+		return "", err
+	}
+	return targetGroupARN, nil
+}
+
+// syncDedicatedExternalAPITargetGroup keeps the control plane instances
+// registered with the dedicated external API target group, if there is one
+func (ac *Client) syncDedicatedExternalAPITargetGroup(ctx context.Context, kclient k8s.Client) error {
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	targetGroupName := dedicatedExternalAPITargetGroupName(infrastructureName)
+	targetGroupARN, err := ac.getTargetGroupArn(targetGroupName)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeTargetGroupNotFoundException {
+			return nil
+		}
+		return err
+	}
+	return ac.syncTargetGroupInstances(ctx, kclient, targetGroupName, targetGroupARN)
+}
+
+// deleteDedicatedExternalAPITargetGroup deletes the dedicated external API
+// target group, if there is one. It can't be deleted before the listener of
+// the external NLB is gone.
+func (ac *Client) deleteDedicatedExternalAPITargetGroup(ctx context.Context, kclient k8s.Client) error {
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	targetGroupName := dedicatedExternalAPITargetGroupName(infrastructureName)
+	targetGroupARN, err := ac.getTargetGroupArn(targetGroupName)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeTargetGroupNotFoundException {
+			return nil
+		}
+		return err
+	}
+	_, err = ac.elbv2Client.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(targetGroupARN)})
+	if err != nil {
+		cioevents.Warning(ctx, cioevents.ReasonLoadBalancerDeleteFailed, cioevents.ActionDelete, "Couldn't delete the target group %s: %v", targetGroupName, err)
+		return fmt.Errorf("couldn't delete the target group %s: %w", targetGroupName, err)
+	}
+	cioevents.Normal(ctx, cioevents.ReasonLoadBalancerDeleted, cioevents.ActionDelete, "Deleted the target group %s", targetGroupName)
+	return nil
+}
+
+// syncTargetGroupInstances registers the instances of the control plane
+// Machines with the target group, and deregisters the instances of replaced ones
+func (ac *Client) syncTargetGroupInstances(ctx context.Context, kclient k8s.Client, targetGroupName, targetGroupARN string) error {
+	toRegister, toDeregister, err := ac.targetGroupInstanceChanges(kclient, targetGroupName, targetGroupARN)
+	if err != nil {
+		return err
+	}
+	if len(toRegister) > 0 {
+		_, err := ac.elbv2Client.RegisterTargets(&elbv2.RegisterTargetsInput{TargetGroupArn: aws.String(targetGroupARN), Targets: toRegister})
+		if err != nil {
+			cioevents.Warning(ctx, cioevents.ReasonLoadBalancerUpdateFailed, cioevents.ActionUpdate, "Couldn't register the control plane instances with the target group %s: %v", targetGroupName, err)
+			return err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonLoadBalancerUpdated, cioevents.ActionUpdate, "Registered %d control plane instances with the target group %s", len(toRegister), targetGroupName)
+	}
+	if len(toDeregister) > 0 {
+		_, err := ac.elbv2Client.DeregisterTargets(&elbv2.DeregisterTargetsInput{TargetGroupArn: aws.String(targetGroupARN), Targets: toDeregister})
+		if err != nil {
+			cioevents.Warning(ctx, cioevents.ReasonLoadBalancerUpdateFailed, cioevents.ActionUpdate, "Couldn't deregister the replaced control plane instances from the target group %s: %v", targetGroupName, err)
+			return err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonLoadBalancerUpdated, cioevents.ActionUpdate, "Deregistered %d replaced control plane instances from the target group %s", len(toDeregister), targetGroupName)
+	}
+	return nil
+}
+
+// planTargetGroupInstances lists the changes syncTargetGroupInstances would
+// make to the target group, whose ARN is empty when it doesn't exist yet
+func (ac *Client) planTargetGroupInstances(kclient k8s.Client, targetGroupName, targetGroupARN string) ([]cloudingressv1alpha1.PlannedAction, error) {
+	toRegister, toDeregister, err := ac.targetGroupInstanceChanges(kclient, targetGroupName, targetGroupARN)
+	if err != nil {
+		return nil, err
+	}
+	var actions []cloudingressv1alpha1.PlannedAction
+	if len(toRegister) > 0 {
+		actions = append(actions, cloudingressv1alpha1.PlannedAction{
+			Action:      cloudingressv1alpha1.PlannedActionUpdate,
+			Kind:        cloudingressv1alpha1.PlannedResourceTargetGroup,
+			Name:        targetGroupName,
+			Description: fmt.Sprintf("Register the control plane instances %s", formatTargets(toRegister)),
+		})
+	}
+	if len(toDeregister) > 0 {
+		actions = append(actions, cloudingressv1alpha1.PlannedAction{
+			Action:      cloudingressv1alpha1.PlannedActionUpdate,
+			Kind:        cloudingressv1alpha1.PlannedResourceTargetGroup,
+			Name:        targetGroupName,
+			Description: fmt.Sprintf("Deregister the replaced control plane instances %s", formatTargets(toDeregister)),
+		})
+	}
+	return actions, nil
+}
+
+func formatTargets(targets []*elbv2.TargetDescription) string {
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, aws.StringValue(target.Id))
+	}
+	return strings.Join(ids, ", ")
+}
+
+// targetGroupInstanceChanges returns the instances of the control plane
+// Machines which aren't registered with the target group, and the registered
+// instances of replaced ones. Every instance is to register with a target
+// group without ARN, which doesn't exist yet.
+func (ac *Client) targetGroupInstanceChanges(kclient k8s.Client, targetGroupName, targetGroupARN string) ([]*elbv2.TargetDescription, []*elbv2.TargetDescription, error) {
+	machineList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return nil, nil, err
+	}
+	desired := map[string]bool{}
+	for _, machine := range machineList.Items {
+		if machine.Spec.ProviderID == nil {
+			continue
+		}
+		// the provider ID is in the form of aws:///us-east-1a/i-<hash>
+		split := strings.Split(*machine.Spec.ProviderID, "/")
+		if instanceID := split[len(split)-1]; instanceID != "" {
+			desired[instanceID] = true
+		}
+	}
+	if len(desired) == 0 {
+		return nil, nil, fmt.Errorf("no control plane instances to register with the target group %s", targetGroupName)
+	}
+
+	registered := map[string]bool{}
+	var toDeregister []*elbv2.TargetDescription
+	if targetGroupARN != "" {
+		health, err := ac.elbv2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(targetGroupARN),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, description := range health.TargetHealthDescriptions {
+			instanceID := aws.StringValue(description.Target.Id)
+			registered[instanceID] = true
+			if !desired[instanceID] {
+				toDeregister = append(toDeregister, &elbv2.TargetDescription{Id: aws.String(instanceID), Port: description.Target.Port})
+			}
+		}
+	}
+	var toRegister []*elbv2.TargetDescription
+	for instanceID := range desired {
+		if !registered[instanceID] {
+			toRegister = append(toRegister, &elbv2.TargetDescription{Id: aws.String(instanceID), Port: aws.Int64(6443)})
+		}
+	}
+	sort.Slice(toRegister, func(i, j int) bool { return *toRegister[i].Id < *toRegister[j].Id })
+	return toRegister, toDeregister, nil
+}
+
 // getTargetGroupArn by passing in targetGroup Name
 func (ac *Client) getTargetGroupArn(targetGroupName string) (string, error) {
 	i := &elbv2.DescribeTargetGroupsInput{
@@ -1176,15 +2358,28 @@ func removeLoadBalancerCPMS(ctx context.Context, kclient k8s.Client, lbName stri
 	return nil
 }
 
-func getLoadBalancerRemovalFunc(ctx context.Context, kclient k8s.Client, masterList *machinev1beta1.MachineList, cpms *machinev1.ControlPlaneMachineSet) func(string) error {
+func getLoadBalancerRemovalFunc(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy, masterList *machinev1beta1.MachineList, cpms *machinev1.ControlPlaneMachineSet) func(string) error {
 	if cpms.Spec.State == machinev1.ControlPlaneMachineSetStateActive {
 		return func(lbName string) error {
+			// Record the reactivation before deleting the CPMS, so it is
+			// resumed if the operator restarts before it's active again
+			pending := instance != nil && instance.Status.ControlPlaneMachineSetReactivation != nil
+			err := baseutils.StartCPMSReactivation(ctx, kclient, instance, lbName)
+			if err != nil {
+				return err
+			}
+			if pending {
+				// The CPMS was already deleted for another LB
+				return removeLoadBalancerMachineSet(ctx, kclient, lbName, masterList)
+			}
 			log.Info("Removing active CPMS")
-			err := baseutils.DeleteCPMS(ctx, kclient, cpms)
+			err = baseutils.DeleteCPMS(ctx, kclient, cpms)
 			if err != nil {
 				log.Error(err, "failed to delete CPMS")
+				cioevents.Warning(ctx, cioevents.ReasonControlPlaneMachineSetFailed, cioevents.ActionDelete, "Couldn't delete the active ControlPlaneMachineSet to remove load balancer %s: %v", lbName, err)
 				return err
 			}
+			cioevents.Normal(ctx, cioevents.ReasonControlPlaneMachineSetDeleted, cioevents.ActionDelete, "Deleted the active ControlPlaneMachineSet to remove load balancer %s from it", lbName)
 			// It seems this has to actually do both - the machine api is not
 			// able to remove machines that still reference a LB that does not
 			// exist:
@@ -1195,43 +2390,19 @@ func getLoadBalancerRemovalFunc(ctx context.Context, kclient k8s.Client, masterL
 				log.Error(err, "failed to remove load balancer from machines")
 				return err
 			}
-			go func() {
-				maxRetries := 5
-				for {
-					time.Sleep(60 * time.Second)
-					log.Info("Retrieve cpms again")
-					cpms, err := baseutils.GetControlPlaneMachineSet(kclient)
-					if err != nil {
-						log.Error(err, "could not get updated CPMS")
-					}
-					log.Info("Removing LB from cpms")
-					err = removeLoadBalancerCPMS(ctx, kclient, lbName, cpms)
-					if err != nil {
-						log.Error(err, "failed to update CPMS")
-					}
-					log.Info("Retrieve cpms again")
-					cpms, err = baseutils.GetControlPlaneMachineSet(kclient)
-					if err != nil {
-						log.Error(err, "Could not retrieve CPMS")
-					}
-					err = baseutils.SetCPMSActive(context.Background(), kclient, cpms)
-					if err != nil {
-						log.Error(err, "Could not set CPMS active")
-					} else {
-						break
-					}
-					if maxRetries == 0 {
-						log.Info("Could not set CPMS back to active after 5 attempts")
-						break
-					}
-					maxRetries = maxRetries - 1
-				}
-			}()
-			// Don't fail the following steps - setting CPMS back to active will be tried again
+			// The PublishingStrategy controller removes the LB from the recreated
+			// CPMS and sets it back to active, see ReactivateControlPlaneMachineSet
 			return nil
 		}
 	} else {
 		return func(lbName string) error {
+			// A CPMS which is still to be reactivated mustn't get the LB back
+			if instance != nil && instance.Status.ControlPlaneMachineSetReactivation != nil {
+				err := baseutils.StartCPMSReactivation(ctx, kclient, instance, lbName)
+				if err != nil {
+					return err
+				}
+			}
 			return removeLoadBalancerMachineSet(ctx, kclient, lbName, masterList)
 		}
 	}
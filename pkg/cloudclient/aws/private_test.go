@@ -4,17 +4,28 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
+	"sync"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	machinev1 "github.com/openshift/api/machine/v1"
 	machineapi "github.com/openshift/api/machine/v1beta1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/retry"
+	"github.com/openshift/cloud-ingress-operator/pkg/errors"
 	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -243,6 +254,36 @@ type mockDescribeELBv2LoadBalancers struct {
 	TagsResp         elbv2.DescribeTagsOutput
 	TagsFilteredResp elbv2.DescribeTagsOutput
 	TagsErrResp      string
+	// Listeners are the listeners of every load balancer
+	Listeners []*elbv2.Listener
+	// TargetGroups are the existing target groups
+	TargetGroups []*elbv2.TargetGroup
+	// Registered are the instances registered with the target groups, by ARN
+	Registered map[string][]string
+}
+
+func (m mockDescribeELBv2LoadBalancers) DescribeTargetGroups(input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	for _, targetGroup := range m.TargetGroups {
+		if aws.StringValue(targetGroup.TargetGroupName) == aws.StringValue(input.Names[0]) {
+			return &elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{targetGroup}}, nil
+		}
+	}
+	return nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil)
+}
+
+func (m mockDescribeELBv2LoadBalancers) DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	out := &elbv2.DescribeTargetHealthOutput{}
+	for _, id := range m.Registered[aws.StringValue(input.TargetGroupArn)] {
+		out.TargetHealthDescriptions = append(out.TargetHealthDescriptions, &elbv2.TargetHealthDescription{
+			Target: &elbv2.TargetDescription{Id: aws.String(id), Port: aws.Int64(6443)},
+		})
+	}
+	return out, nil
+}
+
+func (m mockDescribeELBv2LoadBalancers) DescribeListenersPages(_ *elbv2.DescribeListenersInput, fn func(*elbv2.DescribeListenersOutput, bool) bool) error {
+	fn(&elbv2.DescribeListenersOutput{Listeners: m.Listeners}, true)
+	return nil
 }
 
 func (m mockDescribeELBv2LoadBalancers) DescribeLoadBalancers(_ *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
@@ -365,6 +406,7 @@ func TestGetInternalAPINLB(t *testing.T) {
 				loadBalancerArn:           "arn:123456",
 				loadBalancerName:          "testlb-int",
 				scheme:                    "internal",
+				ipAddressType:             "ipv4",
 				vpcID:                     "vpc-123456",
 			},
 		},
@@ -476,6 +518,7 @@ func TestGetInternalAPINLB(t *testing.T) {
 				loadBalancerArn:           "arn:123456",
 				loadBalancerName:          "testlb-int",
 				scheme:                    "internal",
+				ipAddressType:             "ipv4",
 				vpcID:                     "vpc-123456",
 			},
 		},
@@ -548,6 +591,7 @@ func TestGetInternalAPINLB(t *testing.T) {
 				loadBalancerArn:           "arn:123456",
 				loadBalancerName:          "testlb-int",
 				scheme:                    "internal",
+				ipAddressType:             "ipv4",
 				vpcID:                     "vpc-123456",
 			},
 		},
@@ -688,6 +732,7 @@ func TestListOwnedNLBs(t *testing.T) {
 					loadBalancerArn:           "arn:123456",
 					loadBalancerName:          "testlb-ext",
 					scheme:                    "internal-facing",
+					ipAddressType:             "ipv4",
 					vpcID:                     "vpc-123456",
 				},
 			},
@@ -762,6 +807,7 @@ func TestListOwnedNLBs(t *testing.T) {
 					loadBalancerArn:           "arn:654321",
 					loadBalancerName:          "testlb2-ext",
 					scheme:                    "internal-facing",
+					ipAddressType:             "ipv4",
 					vpcID:                     "vpc-654321",
 				},
 			},
@@ -857,12 +903,12 @@ func TestCreateNetworkLoadBalancer(t *testing.T) {
 		Expected      []loadBalancerV2
 		LbName        string
 		Scheme        string
-		Subnet        string
+		Subnets       []string
 	}{
 		{
-			LbName: "test-lb",
-			Scheme: "internal",
-			Subnet: "subnet-12345",
+			LbName:  "test-lb",
+			Scheme:  "internal",
+			Subnets: []string{"subnet-12345", "subnet-67890"},
 			Expected: []loadBalancerV2{
 				{},
 			},
@@ -888,7 +934,7 @@ func TestCreateNetworkLoadBalancer(t *testing.T) {
 
 		tags := []*elbv2.Tag{&tag}
 
-		resp, err := client.createNetworkLoadBalancer(test.LbName, test.Scheme, test.Subnet, tags)
+		resp, err := client.createNetworkLoadBalancer(test.LbName, test.Scheme, "", test.Subnets, tags)
 		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
 			t.Fatalf("Test return mismatch. Expect error? %t: Return %+v", test.ErrorExpected, err)
 		}
@@ -1124,3 +1170,1254 @@ func TestRecordExists(t *testing.T) {
 	}
 
 }
+
+type mockRoute53DeleteClient struct {
+	mockRoute53Client
+	changes *[]*route53.ChangeResourceRecordSetsInput
+}
+
+func (m mockRoute53DeleteClient) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	return &route53.ListHostedZonesByNameOutput{
+		HostedZones: []*route53.HostedZone{
+			{
+				Id:   aws.String("/hostedzone/ZZZZZZZZZZ"),
+				Name: input.DNSName,
+			},
+		},
+	}, nil
+}
+
+func (m mockRoute53DeleteClient) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	*m.changes = append(*m.changes, input)
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+// mockRoute53Pages serves the records of a zone, from StartRecordName on, one per page
+type mockRoute53Pages struct {
+	mockRoute53DeleteClient
+	records []*route53.ResourceRecordSet
+	pages   *int
+}
+
+func (m mockRoute53Pages) ListResourceRecordSetsPages(input *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+	start := slices.IndexFunc(m.records, func(r *route53.ResourceRecordSet) bool {
+		return aws.StringValue(r.Name) == aws.StringValue(input.StartRecordName)
+	})
+	if start < 0 {
+		return nil
+	}
+	for i, record := range m.records[start:] {
+		*m.pages++
+		if !fn(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{record}}, start+i == len(m.records)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestDeleteRecordsByNameStopsPaging(t *testing.T) {
+	var changes []*route53.ChangeResourceRecordSetsInput
+	pages := 0
+	client := &Client{
+		route53Client: mockRoute53Pages{
+			mockRoute53DeleteClient: mockRoute53DeleteClient{changes: &changes},
+			records: []*route53.ResourceRecordSet{
+				{Name: aws.String("rh-api.osd-cluster.org."), Type: aws.String("A")},
+				{Name: aws.String("rh-api.osd-cluster.org."), Type: aws.String("AAAA")},
+				{Name: aws.String("www.osd-cluster.org."), Type: aws.String("A")},
+				{Name: aws.String("zzz.osd-cluster.org."), Type: aws.String("A")},
+			},
+			pages: &pages,
+		},
+	}
+	if err := client.deleteARecordsByName(context.TODO(), "osd-cluster.org.", "rh-api.osd-cluster.org."); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(changes) != 1 || len(changes[0].ChangeBatch.Changes) != 1 {
+		t.Fatalf("Expected the A record to be deleted, got %v", changes)
+	}
+	if pages != 2 {
+		t.Errorf("Expected the listing to stop after the AAAA record, read %d pages", pages)
+	}
+}
+
+func TestDeleteARecordsByName(t *testing.T) {
+	tests := []struct {
+		Name            string
+		RecordName      string
+		ExpectedChanges int
+	}{
+		{
+			Name:            "Should delete the record whatever it aliases to",
+			RecordName:      "rh-api.osd-cluster.org.",
+			ExpectedChanges: 1,
+		},
+		{
+			Name:            "Should do nothing when the record doesn't exist",
+			RecordName:      "rh-api2.osd-cluster.org.",
+			ExpectedChanges: 0,
+		},
+	}
+
+	for _, test := range tests {
+		var changes []*route53.ChangeResourceRecordSetsInput
+		client := &Client{
+			route53Client: mockRoute53DeleteClient{changes: &changes},
+		}
+		err := client.deleteARecordsByName(context.TODO(), "osd-cluster.org.", test.RecordName)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		if len(changes) != test.ExpectedChanges {
+			t.Fatalf("Test [%v] FAILED. Expected %d change batches, got %d", test.Name, test.ExpectedChanges, len(changes))
+		}
+		if test.ExpectedChanges == 0 {
+			continue
+		}
+		change := changes[0]
+		assert.Equal(t, "ZZZZZZZZZZ", aws.StringValue(change.HostedZoneId))
+		assert.Len(t, change.ChangeBatch.Changes, 1)
+		assert.Equal(t, "DELETE", aws.StringValue(change.ChangeBatch.Changes[0].Action))
+		assert.Equal(t, "abcdefgh.us-east-1.elb.amazon.com.", aws.StringValue(change.ChangeBatch.Changes[0].ResourceRecordSet.AliasTarget.DNSName))
+	}
+}
+
+// mockEC2PublicSubnets is an EC2 API where the control plane runs in a VPC
+// with the given public subnets
+type mockEC2PublicSubnets struct {
+	ec2iface.EC2API
+	Subnets []*ec2.Subnet
+}
+
+func (m mockEC2PublicSubnets) DescribeInstances(_ *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{{VpcId: aws.String("vpc-123456")}}}},
+	}, nil
+}
+
+func (m mockEC2PublicSubnets) DescribeSubnets(_ *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return &ec2.DescribeSubnetsOutput{Subnets: m.Subnets}, nil
+}
+
+func (m mockEC2PublicSubnets) DescribeRouteTables(_ *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	return &ec2.DescribeRouteTablesOutput{
+		RouteTables: []*ec2.RouteTable{{
+			RouteTableId: aws.String("rtb-public"),
+			Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+			Routes:       []*ec2.Route{{GatewayId: aws.String("igw-123456")}},
+		}},
+	}, nil
+}
+
+// multiAZMasters returns control plane Machines spread over three zones
+func multiAZMasters(clusterName string) *machineapi.MachineList {
+	machineList := &machineapi.MachineList{}
+	for i, zone := range []string{"us-east-1a", "us-east-1b", "us-east-1c"} {
+		machineList.Items = append(machineList.Items,
+			testutils.CreateMachineObjPre411(fmt.Sprintf("master-%d", i), clusterName, "master", testutils.DefaultRegionName, zone))
+	}
+	return machineList
+}
+
+func publicSubnet(id, zone, name string) *ec2.Subnet {
+	return &ec2.Subnet{
+		SubnetId:         aws.String(id),
+		AvailabilityZone: aws.String(zone),
+		Tags:             []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(name)}},
+	}
+}
+
+func TestPlanDefaultAPIPublic(t *testing.T) {
+	clusterName := "plan-default-api-public-test"
+	infraObj := testutils.CreateInfraObject(clusterName, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	objs := []runtime.Object{infraObj, multiAZMasters(clusterName)}
+	mocks := testutils.NewTestMock(t, objs)
+
+	ownedTag := &elbv2.Tag{
+		Key:   aws.String("kubernetes.io/cluster/" + clusterName),
+		Value: aws.String("owned"),
+	}
+	publicSubnets := []*ec2.Subnet{
+		publicSubnet("subnet-a", "us-east-1a", clusterName+"-public-us-east-1a"),
+		publicSubnet("subnet-b", "us-east-1b", clusterName+"-public-us-east-1b"),
+		publicSubnet("subnet-c", "us-east-1c", clusterName+"-public-us-east-1c"),
+	}
+	externalNLB := func(zones ...*elbv2.AvailabilityZone) elbv2.DescribeLoadBalancersOutput {
+		return elbv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []*elbv2.LoadBalancer{
+				{
+					CanonicalHostedZoneId: aws.String("/test/DEF456"),
+					DNSName:               aws.String("test.example.com"),
+					LoadBalancerArn:       aws.String("arn:654321"),
+					LoadBalancerName:      aws.String(clusterName + "-ext"),
+					Scheme:                aws.String("internet-facing"),
+					VpcId:                 aws.String("vpc-123456"),
+					AvailabilityZones:     zones,
+				},
+			},
+		}
+	}
+
+	installerTargetGroup := func(loadBalancerArns ...string) *elbv2.TargetGroup {
+		return &elbv2.TargetGroup{
+			TargetGroupName:  aws.String(clusterName + "-aext"),
+			TargetGroupArn:   aws.String("arn:aext"),
+			LoadBalancerArns: aws.StringSlice(loadBalancerArns),
+		}
+	}
+	dedicatedTargetGroup := &elbv2.TargetGroup{
+		TargetGroupName:  aws.String(clusterName + "-cext"),
+		TargetGroupArn:   aws.String("arn:cext"),
+		LoadBalancerArns: aws.StringSlice([]string{"arn:654321"}),
+	}
+	ownedTags := elbv2.DescribeTagsOutput{
+		TagDescriptions: []*elbv2.TagDescription{
+			{
+				ResourceArn: aws.String("arn:654321"),
+				Tags:        []*elbv2.Tag{ownedTag},
+			},
+		},
+	}
+
+	tests := []struct {
+		Name string
+		// Resp is the mocked DescribeLoadBalancers response
+		Resp elbv2.DescribeLoadBalancersOutput
+		// TagsResp is the mocked DescribeTags response
+		TagsResp elbv2.DescribeTagsOutput
+		// Listeners are the mocked listeners of the external NLB
+		Listeners []*elbv2.Listener
+		// TargetGroups are the mocked target groups
+		TargetGroups []*elbv2.TargetGroup
+		// Registered are the instances registered with the target groups, by ARN
+		Registered map[string][]string
+		// ExpectedActions are the kinds of the planned actions, in order
+		ExpectedActions []cloudingressv1alpha1.PlannedResourceKind
+		// ExpectedDescription is the description of the first planned action
+		ExpectedDescription string
+		// ExpectedListenerDescription is the description of the planned listener, if any
+		ExpectedListenerDescription string
+	}{
+		{
+			Name: "Should plan nothing when the external NLB exists in every control plane zone",
+			Resp: externalNLB(
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1a"), SubnetId: aws.String("subnet-a")},
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1b"), SubnetId: aws.String("subnet-b")},
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1c"), SubnetId: aws.String("subnet-c")},
+			),
+			TagsResp: elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{
+					{
+						ResourceArn: aws.String("arn:654321"),
+						Tags:        []*elbv2.Tag{ownedTag},
+					},
+				},
+			},
+			Listeners:    []*elbv2.Listener{{Port: aws.Int64(6443)}},
+			TargetGroups: []*elbv2.TargetGroup{installerTargetGroup("arn:654321")},
+		},
+		{
+			Name: "Should plan the listener the external NLB is missing",
+			Resp: externalNLB(
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1a"), SubnetId: aws.String("subnet-a")},
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1b"), SubnetId: aws.String("subnet-b")},
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1c"), SubnetId: aws.String("subnet-c")},
+			),
+			TagsResp: elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{
+					{
+						ResourceArn: aws.String("arn:654321"),
+						Tags:        []*elbv2.Tag{ownedTag},
+					},
+				},
+			},
+			TargetGroups: []*elbv2.TargetGroup{installerTargetGroup()},
+			ExpectedActions: []cloudingressv1alpha1.PlannedResourceKind{
+				cloudingressv1alpha1.PlannedResourceListener,
+			},
+			ExpectedDescription:         "Forward TCP port 6443 to target group " + clusterName + "-aext",
+			ExpectedListenerDescription: "Forward TCP port 6443 to target group " + clusterName + "-aext",
+		},
+		{
+			Name: "Should plan the registration of the missing instances when the listener reuses the dedicated target group",
+			Resp: externalNLB(
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1a"), SubnetId: aws.String("subnet-a")},
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1b"), SubnetId: aws.String("subnet-b")},
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1c"), SubnetId: aws.String("subnet-c")},
+			),
+			TagsResp:     ownedTags,
+			TargetGroups: []*elbv2.TargetGroup{installerTargetGroup("arn:int"), dedicatedTargetGroup},
+			Registered:   map[string][]string{"arn:cext": {"i-master-0", "i-master-1"}},
+			ExpectedActions: []cloudingressv1alpha1.PlannedResourceKind{
+				cloudingressv1alpha1.PlannedResourceTargetGroup,
+				cloudingressv1alpha1.PlannedResourceListener,
+			},
+			ExpectedDescription:         "Register the control plane instances i-master-2",
+			ExpectedListenerDescription: "Forward TCP port 6443 to the existing target group " + clusterName + "-cext, " + clusterName + "-aext is associated with another load balancer",
+		},
+		{
+			Name: "Should plan the sync of the dedicated target group the listener forwards to",
+			Resp: externalNLB(
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1a"), SubnetId: aws.String("subnet-a")},
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1b"), SubnetId: aws.String("subnet-b")},
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1c"), SubnetId: aws.String("subnet-c")},
+			),
+			TagsResp:     ownedTags,
+			Listeners:    []*elbv2.Listener{{Port: aws.Int64(6443)}},
+			TargetGroups: []*elbv2.TargetGroup{installerTargetGroup("arn:int"), dedicatedTargetGroup},
+			Registered:   map[string][]string{"arn:cext": {"i-master-0", "i-master-1", "i-master-old"}},
+			ExpectedActions: []cloudingressv1alpha1.PlannedResourceKind{
+				cloudingressv1alpha1.PlannedResourceTargetGroup,
+				cloudingressv1alpha1.PlannedResourceTargetGroup,
+			},
+			ExpectedDescription: "Register the control plane instances i-master-2",
+		},
+		{
+			Name: "Should plan the missing subnets when the external NLB has drifted",
+			Resp: externalNLB(
+				&elbv2.AvailabilityZone{ZoneName: aws.String("us-east-1a"), SubnetId: aws.String("subnet-a")},
+			),
+			TagsResp: elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{
+					{
+						ResourceArn: aws.String("arn:654321"),
+						Tags:        []*elbv2.Tag{ownedTag},
+					},
+				},
+			},
+			Listeners: []*elbv2.Listener{{Port: aws.Int64(6443)}},
+			ExpectedActions: []cloudingressv1alpha1.PlannedResourceKind{
+				cloudingressv1alpha1.PlannedResourceLoadBalancer,
+			},
+			ExpectedDescription: "Add the public subnets subnet-b (us-east-1b), subnet-c (us-east-1c)",
+		},
+		{
+			Name:         "Should plan the external NLB, its listener and the DNS record when there is no external NLB",
+			TargetGroups: []*elbv2.TargetGroup{installerTargetGroup()},
+			ExpectedActions: []cloudingressv1alpha1.PlannedResourceKind{
+				cloudingressv1alpha1.PlannedResourceLoadBalancer,
+				cloudingressv1alpha1.PlannedResourceListener,
+				cloudingressv1alpha1.PlannedResourceDNSRecord,
+			},
+			ExpectedDescription:         "Create an internet-facing NLB in the public subnets subnet-a (us-east-1a), subnet-b (us-east-1b), subnet-c (us-east-1c)",
+			ExpectedListenerDescription: "Forward TCP port 6443 to target group " + clusterName + "-aext",
+		},
+		{
+			Name:         "Should plan the dedicated target group and its instances when the installer's is associated with another load balancer",
+			TargetGroups: []*elbv2.TargetGroup{installerTargetGroup("arn:int")},
+			ExpectedActions: []cloudingressv1alpha1.PlannedResourceKind{
+				cloudingressv1alpha1.PlannedResourceLoadBalancer,
+				cloudingressv1alpha1.PlannedResourceTargetGroup,
+				cloudingressv1alpha1.PlannedResourceTargetGroup,
+				cloudingressv1alpha1.PlannedResourceListener,
+				cloudingressv1alpha1.PlannedResourceDNSRecord,
+			},
+			ExpectedDescription:         "Create an internet-facing NLB in the public subnets subnet-a (us-east-1a), subnet-b (us-east-1b), subnet-c (us-east-1c)",
+			ExpectedListenerDescription: "Forward TCP port 6443 to target group " + clusterName + "-cext",
+		},
+	}
+
+	for _, test := range tests {
+		client := &Client{
+			elbv2Client: mockDescribeELBv2LoadBalancers{
+				Resp:         test.Resp,
+				TagsResp:     test.TagsResp,
+				Listeners:    test.Listeners,
+				TargetGroups: test.TargetGroups,
+				Registered:   test.Registered,
+			},
+			ec2Client: mockEC2PublicSubnets{Subnets: publicSubnets},
+		}
+		actions, err := client.planDefaultAPIPublic(context.TODO(), mocks.FakeKubeClient, nil)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if len(actions) != len(test.ExpectedActions) {
+			t.Fatalf("Test [%v] FAILED: expected %d actions, got %+v", test.Name, len(test.ExpectedActions), actions)
+		}
+		for i, action := range actions {
+			if action.Kind != test.ExpectedActions[i] {
+				t.Errorf("Test [%v] FAILED: expected action %d to be on a %s, got %+v", test.Name, i, test.ExpectedActions[i], action)
+			}
+		}
+		if len(actions) > 0 && actions[0].Description != test.ExpectedDescription {
+			t.Errorf("Test [%v] FAILED: expected %q, got %q", test.Name, test.ExpectedDescription, actions[0].Description)
+		}
+		for _, action := range actions {
+			if action.Kind == cloudingressv1alpha1.PlannedResourceListener && action.Description != test.ExpectedListenerDescription {
+				t.Errorf("Test [%v] FAILED: expected the listener %q, got %q", test.Name, test.ExpectedListenerDescription, action.Description)
+			}
+		}
+	}
+}
+
+func TestSelectPublicSubnets(t *testing.T) {
+	zones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+	publicSubnets := []*ec2.Subnet{
+		publicSubnet("subnet-a2", "us-east-1a", "sut-public-us-east-1a"),
+		publicSubnet("subnet-a1", "us-east-1a", "custom"),
+		publicSubnet("subnet-b2", "us-east-1b", "custom"),
+		publicSubnet("subnet-b1", "us-east-1b", "custom"),
+		publicSubnet("subnet-d1", "us-east-1d", "sut-public-us-east-1d"),
+	}
+
+	subnets := selectPublicSubnets("sut", zones, publicSubnets)
+	// The installer's subnet first, then the lowest ID. No subnet in us-east-1c,
+	// none out of the control plane zones.
+	expected := map[string]string{
+		"us-east-1a": "subnet-a2",
+		"us-east-1b": "subnet-b1",
+	}
+	if !reflect.DeepEqual(subnets, expected) {
+		t.Fatalf("Expected %v, got %v", expected, subnets)
+	}
+}
+
+type mockSetSubnets struct {
+	mockDescribeELBv2LoadBalancers
+	input *elbv2.SetSubnetsInput
+}
+
+func (m *mockSetSubnets) SetSubnets(input *elbv2.SetSubnetsInput) (*elbv2.SetSubnetsOutput, error) {
+	m.input = input
+	return &elbv2.SetSubnetsOutput{}, nil
+}
+
+func (m *mockSetSubnets) DescribeTargetGroups(_ *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	return nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil)
+}
+
+func TestSetDefaultAPIPublicReconcilesSubnets(t *testing.T) {
+	clusterName := "reconcile-subnets-test"
+	infraObj := testutils.CreateInfraObject(clusterName, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	objs := []runtime.Object{infraObj, multiAZMasters(clusterName)}
+	mocks := testutils.NewTestMock(t, objs)
+
+	elbv2Client := &mockSetSubnets{
+		mockDescribeELBv2LoadBalancers: mockDescribeELBv2LoadBalancers{
+			Resp: elbv2.DescribeLoadBalancersOutput{
+				LoadBalancers: []*elbv2.LoadBalancer{
+					{
+						LoadBalancerArn:  aws.String("arn:654321"),
+						LoadBalancerName: aws.String(clusterName + "-ext"),
+						Scheme:           aws.String("internet-facing"),
+						AvailabilityZones: []*elbv2.AvailabilityZone{
+							// installed with a subnet of another name, kept
+							{ZoneName: aws.String("us-east-1b"), SubnetId: aws.String("subnet-custom")},
+						},
+					},
+				},
+			},
+			TagsResp: elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{
+					{
+						ResourceArn: aws.String("arn:654321"),
+						Tags: []*elbv2.Tag{{
+							Key:   aws.String("kubernetes.io/cluster/" + clusterName),
+							Value: aws.String("owned"),
+						}},
+					},
+				},
+			},
+			Listeners: []*elbv2.Listener{{Port: aws.Int64(6443)}},
+		},
+	}
+	client := &Client{
+		elbv2Client: elbv2Client,
+		ec2Client: mockEC2PublicSubnets{Subnets: []*ec2.Subnet{
+			publicSubnet("subnet-a", "us-east-1a", clusterName+"-public-us-east-1a"),
+			publicSubnet("subnet-b", "us-east-1b", clusterName+"-public-us-east-1b"),
+			publicSubnet("subnet-c", "us-east-1c", clusterName+"-public-us-east-1c"),
+		}},
+		route53Client: &mockRoute53Zone{},
+	}
+
+	if err := client.setDefaultAPIPublic(context.TODO(), mocks.FakeKubeClient, nil); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if elbv2Client.input == nil {
+		t.Fatal("Expected the subnets of the external NLB to be updated")
+	}
+	expected := []string{"subnet-custom", "subnet-a", "subnet-c"}
+	if !reflect.DeepEqual(aws.StringValueSlice(elbv2Client.input.Subnets), expected) {
+		t.Errorf("Expected the subnets %v, got %v", expected, aws.StringValueSlice(elbv2Client.input.Subnets))
+	}
+}
+
+// mockTargetGroups is an ELBv2 API where a target group can only be associated
+// with a single load balancer
+type mockTargetGroups struct {
+	elbv2iface.ELBV2API
+	// TargetGroups are the ARNs of the existing target groups, by name
+	TargetGroups map[string]string
+	// Associated are the load balancers the target groups are associated with, by ARN
+	Associated map[string][]string
+	// HideAssociations leaves the associations out of the described target
+	// groups, as if they were made after the target groups were described
+	HideAssociations bool
+	// Registered are the instances registered with the target groups, by ARN
+	Registered map[string][]string
+	// Listeners are the target groups the listeners forward to
+	Listeners       []string
+	CreateTGErrResp string
+	// CreateListenerErrResp fails the next listener creation only
+	CreateListenerErrResp string
+	// LoadBalancers are the load balancers created, with their tags by ARN
+	LoadBalancers []*elbv2.LoadBalancer
+	Tags          map[string][]*elbv2.Tag
+	// SubnetZones are the zones of the subnets load balancers are created in
+	SubnetZones     map[string]string
+	DeleteTGErrResp string
+}
+
+func (m *mockTargetGroups) DeleteTargetGroup(input *elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error) {
+	if m.DeleteTGErrResp != "" {
+		return nil, awserr.New(m.DeleteTGErrResp, m.DeleteTGErrResp, nil)
+	}
+	for name, arn := range m.TargetGroups {
+		if arn == aws.StringValue(input.TargetGroupArn) {
+			delete(m.TargetGroups, name)
+		}
+	}
+	return &elbv2.DeleteTargetGroupOutput{}, nil
+}
+
+func (m *mockTargetGroups) DescribeLoadBalancersPages(_ *elbv2.DescribeLoadBalancersInput, fn func(*elbv2.DescribeLoadBalancersOutput, bool) bool) error {
+	fn(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: m.LoadBalancers}, true)
+	return nil
+}
+
+func (m *mockTargetGroups) DescribeTags(input *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
+	out := &elbv2.DescribeTagsOutput{}
+	for _, arn := range input.ResourceArns {
+		out.TagDescriptions = append(out.TagDescriptions, &elbv2.TagDescription{ResourceArn: arn, Tags: m.Tags[aws.StringValue(arn)]})
+	}
+	return out, nil
+}
+
+func (m *mockTargetGroups) CreateLoadBalancer(input *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
+	loadBalancer := &elbv2.LoadBalancer{
+		LoadBalancerArn:       aws.String("arn:ext"),
+		LoadBalancerName:      input.Name,
+		DNSName:               aws.String("ext.elb.amazonaws.com"),
+		CanonicalHostedZoneId: aws.String("ELBZONE"),
+		Scheme:                input.Scheme,
+		VpcId:                 aws.String("vpc-123456"),
+	}
+	for _, subnet := range input.Subnets {
+		loadBalancer.AvailabilityZones = append(loadBalancer.AvailabilityZones, &elbv2.AvailabilityZone{
+			SubnetId: subnet,
+			ZoneName: aws.String(m.SubnetZones[aws.StringValue(subnet)]),
+		})
+	}
+	m.LoadBalancers = append(m.LoadBalancers, loadBalancer)
+	if m.Tags == nil {
+		m.Tags = map[string][]*elbv2.Tag{}
+	}
+	m.Tags["arn:ext"] = input.Tags
+	return &elbv2.CreateLoadBalancerOutput{LoadBalancers: []*elbv2.LoadBalancer{loadBalancer}}, nil
+}
+
+func (m *mockTargetGroups) DescribeListenersPages(_ *elbv2.DescribeListenersInput, fn func(*elbv2.DescribeListenersOutput, bool) bool) error {
+	out := &elbv2.DescribeListenersOutput{}
+	for _, arn := range m.Listeners {
+		out.Listeners = append(out.Listeners, &elbv2.Listener{
+			Port:           aws.Int64(6443),
+			DefaultActions: []*elbv2.Action{{TargetGroupArn: aws.String(arn)}},
+		})
+	}
+	fn(out, true)
+	return nil
+}
+
+func (m *mockTargetGroups) DescribeTargetGroups(input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	arn, ok := m.TargetGroups[aws.StringValue(input.Names[0])]
+	if !ok {
+		return nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil)
+	}
+	targetGroup := &elbv2.TargetGroup{TargetGroupArn: aws.String(arn)}
+	if !m.HideAssociations {
+		targetGroup.LoadBalancerArns = aws.StringSlice(m.Associated[arn])
+	}
+	return &elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{targetGroup}}, nil
+}
+
+func (m *mockTargetGroups) CreateTargetGroup(input *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
+	if m.CreateTGErrResp != "" {
+		return nil, awserr.New(m.CreateTGErrResp, m.CreateTGErrResp, nil)
+	}
+	arn := "arn:" + aws.StringValue(input.Name)
+	m.TargetGroups[aws.StringValue(input.Name)] = arn
+	return &elbv2.CreateTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String(arn)}}}, nil
+}
+
+func (m *mockTargetGroups) DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	out := &elbv2.DescribeTargetHealthOutput{}
+	for _, id := range m.Registered[aws.StringValue(input.TargetGroupArn)] {
+		out.TargetHealthDescriptions = append(out.TargetHealthDescriptions, &elbv2.TargetHealthDescription{
+			Target: &elbv2.TargetDescription{Id: aws.String(id), Port: aws.Int64(6443)},
+		})
+	}
+	return out, nil
+}
+
+func (m *mockTargetGroups) RegisterTargets(input *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
+	arn := aws.StringValue(input.TargetGroupArn)
+	for _, target := range input.Targets {
+		m.Registered[arn] = append(m.Registered[arn], aws.StringValue(target.Id))
+	}
+	return &elbv2.RegisterTargetsOutput{}, nil
+}
+
+func (m *mockTargetGroups) DeregisterTargets(input *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
+	arn := aws.StringValue(input.TargetGroupArn)
+	for _, target := range input.Targets {
+		var kept []string
+		for _, id := range m.Registered[arn] {
+			if id != aws.StringValue(target.Id) {
+				kept = append(kept, id)
+			}
+		}
+		m.Registered[arn] = kept
+	}
+	return &elbv2.DeregisterTargetsOutput{}, nil
+}
+
+func (m *mockTargetGroups) CreateListener(input *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
+	arn := aws.StringValue(input.DefaultActions[0].TargetGroupArn)
+	for _, loadBalancerArn := range m.Associated[arn] {
+		if loadBalancerArn != aws.StringValue(input.LoadBalancerArn) {
+			return nil, awserr.New(elbv2.ErrCodeTargetGroupAssociationLimitException, "already associated", nil)
+		}
+	}
+	if m.CreateListenerErrResp != "" {
+		code := m.CreateListenerErrResp
+		m.CreateListenerErrResp = ""
+		return nil, awserr.New(code, code, nil)
+	}
+	if m.Associated == nil {
+		m.Associated = map[string][]string{}
+	}
+	m.Associated[arn] = append(m.Associated[arn], aws.StringValue(input.LoadBalancerArn))
+	m.Listeners = append(m.Listeners, arn)
+	return &elbv2.CreateListenerOutput{}, nil
+}
+
+// mockRoute53Zone has the public zone of the cluster, without records
+type mockRoute53Zone struct {
+	route53iface.Route53API
+	Changes []*route53.Change
+}
+
+func (m *mockRoute53Zone) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	return &route53.ListHostedZonesByNameOutput{HostedZones: []*route53.HostedZone{{Id: aws.String("/hostedzone/PUBLIC"), Name: input.DNSName}}}, nil
+}
+
+func (m *mockRoute53Zone) ListResourceRecordSetsPages(_ *route53.ListResourceRecordSetsInput, _ func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+	return nil
+}
+
+func (m *mockRoute53Zone) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	m.Changes = append(m.Changes, input.ChangeBatch.Changes...)
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+func TestSetDefaultAPIPublicTargetGroupAssociationLimit(t *testing.T) {
+	clusterName := "tg-limit-test"
+	infraObj := testutils.CreateInfraObject(clusterName, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	publicSubnets := mockEC2PublicSubnets{Subnets: []*ec2.Subnet{
+		publicSubnet("subnet-a", "us-east-1a", clusterName+"-public-us-east-1a"),
+		publicSubnet("subnet-b", "us-east-1b", clusterName+"-public-us-east-1b"),
+		publicSubnet("subnet-c", "us-east-1c", clusterName+"-public-us-east-1c"),
+	}}
+
+	tests := []struct {
+		Name               string
+		CreateTGErrResp    string
+		HideAssociations   bool
+		ExpectedErr        bool
+		ExpectedListeners  []string
+		ExpectedRegistered []string
+	}{
+		{
+			Name:               "Should forward to a dedicated target group with the control plane instances",
+			ExpectedListeners:  []string{"arn:" + clusterName + "-cext"},
+			ExpectedRegistered: []string{"i-master-0", "i-master-1", "i-master-2"},
+		},
+		{
+			Name:               "Should fall back to a dedicated target group when the listener hits the association limit",
+			HideAssociations:   true,
+			ExpectedListeners:  []string{"arn:" + clusterName + "-cext"},
+			ExpectedRegistered: []string{"i-master-0", "i-master-1", "i-master-2"},
+		},
+		{
+			Name:            "Should report the dedicated target group which couldn't be created",
+			CreateTGErrResp: "TooManyTargetGroups",
+			ExpectedErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		mocks := testutils.NewTestMock(t, []runtime.Object{infraObj, multiAZMasters(clusterName)})
+		elbv2Client := &mockTargetGroups{
+			TargetGroups:     map[string]string{clusterName + "-aext": "arn:installer-aext"},
+			Associated:       map[string][]string{"arn:installer-aext": {"arn:installer-int"}},
+			HideAssociations: test.HideAssociations,
+			Registered:       map[string][]string{},
+			CreateTGErrResp:  test.CreateTGErrResp,
+		}
+		route53Client := &mockRoute53Zone{}
+		client := &Client{elbv2Client: elbv2Client, ec2Client: publicSubnets, route53Client: route53Client}
+
+		err := client.setDefaultAPIPublic(context.TODO(), mocks.FakeKubeClient, nil)
+		if (err != nil) != test.ExpectedErr {
+			t.Fatalf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(elbv2Client.Listeners, test.ExpectedListeners) {
+			t.Errorf("Test [%v] FAILED: expected listeners to %v, got %v", test.Name, test.ExpectedListeners, elbv2Client.Listeners)
+		}
+		if test.ExpectedErr {
+			if len(route53Client.Changes) != 0 {
+				t.Errorf("Test [%v] FAILED: the api record shouldn't point to an NLB without listener", test.Name)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(elbv2Client.Registered["arn:"+clusterName+"-cext"], test.ExpectedRegistered) {
+			t.Errorf("Test [%v] FAILED: expected %v to be registered, got %v", test.Name, test.ExpectedRegistered, elbv2Client.Registered)
+		}
+		if len(route53Client.Changes) != 1 {
+			t.Errorf("Test [%v] FAILED: expected the api record to be updated, got %v", test.Name, route53Client.Changes)
+		}
+	}
+}
+
+func TestSetDefaultAPIPublicRetriesListener(t *testing.T) {
+	clusterName := "listener-retry-test"
+	infraObj := testutils.CreateInfraObject(clusterName, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj, multiAZMasters(clusterName)})
+	elbv2Client := &mockTargetGroups{
+		TargetGroups:          map[string]string{clusterName + "-aext": "arn:aext"},
+		Registered:            map[string][]string{},
+		CreateListenerErrResp: "Throttling",
+		SubnetZones:           map[string]string{"subnet-a": "us-east-1a", "subnet-b": "us-east-1b", "subnet-c": "us-east-1c"},
+	}
+	route53Client := &mockRoute53Zone{}
+	client := &Client{
+		elbv2Client: elbv2Client,
+		ec2Client: mockEC2PublicSubnets{Subnets: []*ec2.Subnet{
+			publicSubnet("subnet-a", "us-east-1a", clusterName+"-public-us-east-1a"),
+			publicSubnet("subnet-b", "us-east-1b", clusterName+"-public-us-east-1b"),
+			publicSubnet("subnet-c", "us-east-1c", clusterName+"-public-us-east-1c"),
+		}},
+		route53Client: route53Client,
+	}
+
+	// The NLB is created but its listener isn't
+	if err := client.setDefaultAPIPublic(context.TODO(), mocks.FakeKubeClient, nil); err == nil {
+		t.Fatal("Expected the listener creation to fail")
+	}
+	if len(elbv2Client.LoadBalancers) != 1 || len(elbv2Client.Listeners) != 0 || len(route53Client.Changes) != 0 {
+		t.Fatalf("Expected an external NLB without listener nor record, got %v, %v and %v", elbv2Client.LoadBalancers, elbv2Client.Listeners, route53Client.Changes)
+	}
+
+	// The next reconcile finds the NLB and completes it
+	if err := client.setDefaultAPIPublic(context.TODO(), mocks.FakeKubeClient, nil); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(elbv2Client.LoadBalancers) != 1 {
+		t.Errorf("Expected the external NLB to be reused, got %v", elbv2Client.LoadBalancers)
+	}
+	if !reflect.DeepEqual(elbv2Client.Listeners, []string{"arn:aext"}) {
+		t.Errorf("Expected a listener to arn:aext, got %v", elbv2Client.Listeners)
+	}
+	if len(route53Client.Changes) != 1 || aws.StringValue(route53Client.Changes[0].ResourceRecordSet.AliasTarget.DNSName) != "ext.elb.amazonaws.com." {
+		t.Errorf("Expected the api record to point to the external NLB, got %v", route53Client.Changes)
+	}
+
+	// Nothing left to do
+	if err := client.setDefaultAPIPublic(context.TODO(), mocks.FakeKubeClient, nil); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(elbv2Client.Listeners) != 1 {
+		t.Errorf("Expected a single listener, got %v", elbv2Client.Listeners)
+	}
+}
+
+func TestSyncTargetGroupInstances(t *testing.T) {
+	clusterName := "tg-sync-test"
+	infraObj := testutils.CreateInfraObject(clusterName, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj, multiAZMasters(clusterName)})
+
+	// master-2 replaced master-old
+	elbv2Client := &mockTargetGroups{
+		TargetGroups: map[string]string{clusterName + "-cext": "arn:cext"},
+		Registered:   map[string][]string{"arn:cext": {"i-master-0", "i-master-1", "i-master-old"}},
+	}
+	client := &Client{elbv2Client: elbv2Client}
+
+	if err := client.syncDedicatedExternalAPITargetGroup(context.TODO(), mocks.FakeKubeClient); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := []string{"i-master-0", "i-master-1", "i-master-2"}
+	if !reflect.DeepEqual(elbv2Client.Registered["arn:cext"], expected) {
+		t.Errorf("Expected %v to be registered, got %v", expected, elbv2Client.Registered["arn:cext"])
+	}
+
+	// Nothing to do without a dedicated target group
+	client = &Client{elbv2Client: &mockTargetGroups{TargetGroups: map[string]string{}}}
+	if err := client.syncDedicatedExternalAPITargetGroup(context.TODO(), mocks.FakeKubeClient); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+func TestDeleteDedicatedExternalAPITargetGroup(t *testing.T) {
+	clusterName := "tg-delete-test"
+	infraObj := testutils.CreateInfraObject(clusterName, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj})
+
+	tests := []struct {
+		Name            string
+		TargetGroups    map[string]string
+		DeleteTGErrResp string
+		ExpectedErr     bool
+		Expected        map[string]string
+	}{
+		{
+			Name:         "Should delete the dedicated target group and keep the installer's",
+			TargetGroups: map[string]string{clusterName + "-aext": "arn:aext", clusterName + "-cext": "arn:cext"},
+			Expected:     map[string]string{clusterName + "-aext": "arn:aext"},
+		},
+		{
+			Name:         "Should do nothing without a dedicated target group",
+			TargetGroups: map[string]string{clusterName + "-aext": "arn:aext"},
+			Expected:     map[string]string{clusterName + "-aext": "arn:aext"},
+		},
+		{
+			Name:            "Should report the target group still used by a listener",
+			TargetGroups:    map[string]string{clusterName + "-cext": "arn:cext"},
+			DeleteTGErrResp: elbv2.ErrCodeResourceInUseException,
+			ExpectedErr:     true,
+			Expected:        map[string]string{clusterName + "-cext": "arn:cext"},
+		},
+	}
+
+	for _, test := range tests {
+		elbv2Client := &mockTargetGroups{TargetGroups: test.TargetGroups, DeleteTGErrResp: test.DeleteTGErrResp}
+		client := &Client{elbv2Client: elbv2Client}
+
+		err := client.deleteDedicatedExternalAPITargetGroup(context.TODO(), mocks.FakeKubeClient)
+		if (err != nil) != test.ExpectedErr {
+			t.Errorf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(elbv2Client.TargetGroups, test.Expected) {
+			t.Errorf("Test [%v] FAILED: expected the target groups %v, got %v", test.Name, test.Expected, elbv2Client.TargetGroups)
+		}
+	}
+}
+
+func awsControlPlaneMachineSet(t *testing.T, lbList []machineapi.LoadBalancerReference) *machinev1.ControlPlaneMachineSet {
+	raw, err := baseutils.ConvertToRawBytes(machineapi.AWSMachineProviderConfig{LoadBalancers: lbList})
+	if err != nil {
+		t.Fatalf("Couldn't encode the provider spec: %v", err)
+	}
+	return &machinev1.ControlPlaneMachineSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "openshift-machine-api"},
+		Spec: machinev1.ControlPlaneMachineSetSpec{
+			State: machinev1.ControlPlaneMachineSetStateActive,
+			Template: machinev1.ControlPlaneMachineSetTemplate{
+				MachineType: machinev1.OpenShiftMachineV1Beta1MachineType,
+				OpenShiftMachineV1Beta1Machine: &machinev1.OpenShiftMachineV1Beta1MachineTemplate{
+					Spec: machineapi.MachineSpec{ProviderSpec: machineapi.ProviderSpec{Value: &runtime.RawExtension{Raw: raw}}},
+				},
+			},
+		},
+	}
+}
+
+func TestEnsureControlPlaneLoadBalancers(t *testing.T) {
+	infraName := "sut-" + testutils.ClusterTokenId
+	extNLB := machineapi.LoadBalancerReference{Name: infraName + "-ext", Type: machineapi.NetworkLoadBalancerType}
+	intNLB := machineapi.LoadBalancerReference{Name: infraName + "-int", Type: machineapi.NetworkLoadBalancerType}
+
+	tests := []struct {
+		Name            string
+		Listening       cloudingressv1alpha1.Listening
+		CPMSLBs         []machineapi.LoadBalancerReference
+		ExpectedLBs     []machineapi.LoadBalancerReference
+		ExpectedCPMSLBs []machineapi.LoadBalancerReference
+	}{
+		{
+			Name:            "Should keep the external NLB of a public API",
+			Listening:       cloudingressv1alpha1.External,
+			CPMSLBs:         []machineapi.LoadBalancerReference{extNLB, intNLB},
+			ExpectedLBs:     []machineapi.LoadBalancerReference{extNLB, intNLB},
+			ExpectedCPMSLBs: []machineapi.LoadBalancerReference{extNLB, intNLB},
+		},
+		{
+			Name:            "Should remove the external NLB of a private API",
+			Listening:       cloudingressv1alpha1.Internal,
+			CPMSLBs:         []machineapi.LoadBalancerReference{extNLB, intNLB},
+			ExpectedLBs:     []machineapi.LoadBalancerReference{intNLB},
+			ExpectedCPMSLBs: []machineapi.LoadBalancerReference{intNLB},
+		},
+		{
+			Name:            "Should add the external NLB back to the CPMS of a public API",
+			Listening:       cloudingressv1alpha1.External,
+			CPMSLBs:         []machineapi.LoadBalancerReference{intNLB},
+			ExpectedLBs:     []machineapi.LoadBalancerReference{extNLB, intNLB},
+			ExpectedCPMSLBs: []machineapi.LoadBalancerReference{intNLB, extNLB},
+		},
+	}
+
+	for _, test := range tests {
+		infraObj := testutils.CreateInfraObject(infraName, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		// The masters have both NLBs
+		machineList, _ := testutils.CreateMachineObjectList([]string{"master-0", "master-1", "master-2"}, "sut", "master", testutils.DefaultRegionName, testutils.DefaultAzName)
+		objs := []runtime.Object{infraObj, machineList, awsControlPlaneMachineSet(t, test.CPMSLBs)}
+		mocks := testutils.NewTestMock(t, objs)
+		client := &Client{}
+
+		if err := client.ensureControlPlaneLoadBalancers(context.TODO(), mocks.FakeKubeClient, test.Listening); err != nil {
+			t.Fatalf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+
+		masterList, err := baseutils.GetMasterMachines(mocks.FakeKubeClient)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: %v", test.Name, err)
+		}
+		for _, machine := range masterList.Items {
+			spec, err := getAWSDecodedProviderSpec(machine, mocks.Scheme)
+			if err != nil {
+				t.Fatalf("Test [%v] FAILED: %v", test.Name, err)
+			}
+			if !reflect.DeepEqual(spec.LoadBalancers, test.ExpectedLBs) {
+				t.Errorf("Test [%v] FAILED: expected machine %s to have %v, got %v", test.Name, machine.Name, test.ExpectedLBs, spec.LoadBalancers)
+			}
+		}
+		cpms, err := baseutils.GetControlPlaneMachineSet(mocks.FakeKubeClient)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: %v", test.Name, err)
+		}
+		spec, err := baseutils.ConvertFromRawExtension[machineapi.AWSMachineProviderConfig](cpms.Spec.Template.OpenShiftMachineV1Beta1Machine.Spec.ProviderSpec.Value)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(spec.LoadBalancers, test.ExpectedCPMSLBs) {
+			t.Errorf("Test [%v] FAILED: expected the CPMS to have %v, got %v", test.Name, test.ExpectedCPMSLBs, spec.LoadBalancers)
+		}
+	}
+}
+
+// mockRoute53Throttled throttles the changes to the throttled zone
+type mockRoute53Throttled struct {
+	route53iface.Route53API
+	throttled string
+	mu        sync.Mutex
+	changed   []string
+}
+
+func (m *mockRoute53Throttled) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	return &route53.ListHostedZonesByNameOutput{HostedZones: []*route53.HostedZone{{Id: aws.String("/hostedzone/" + *input.DNSName), Name: input.DNSName}}}, nil
+}
+
+func (m *mockRoute53Throttled) ListResourceRecordSetsPages(_ *route53.ListResourceRecordSetsInput, _ func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+	return nil
+}
+
+func (m *mockRoute53Throttled) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	if *input.HostedZoneId == m.throttled {
+		return nil, awserr.New("Throttling", "Rate exceeded", nil)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.changed = append(m.changed, *input.HostedZoneId)
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+func TestEnsureDNSRecordThrottled(t *testing.T) {
+	route53Client := &mockRoute53Throttled{throttled: "sut.example.com."}
+	client := &Client{route53Client: route53Client}
+	lb := &loadBalancer{endpointName: "rh-api", baseDomain: "cluster.sut.example.com"}
+	awsObj := &awsLoadBalancer{dnsName: "lb.elb.amazonaws.com", dnsZoneID: "ZONE"}
+
+	err := client.ensureDNSRecord(context.TODO(), lb, awsObj, "comment")
+	after, ok := retry.RequeueAfter(err)
+	if !ok || after <= 0 {
+		t.Fatalf("Expected the throttled change to be retried later, got %v", err)
+	}
+	// The private zone is updated even though the public one is throttled
+	if !reflect.DeepEqual(route53Client.changed, []string{"cluster.sut.example.com."}) {
+		t.Errorf("Expected only the private zone to be changed, got %v", route53Client.changed)
+	}
+
+	route53Client.throttled = ""
+	if err := client.ensureDNSRecord(context.TODO(), lb, awsObj, "comment"); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+// mockRoute53Records has one hosted zone per DNS name, with the given records
+type mockRoute53Records struct {
+	route53iface.Route53API
+	Records map[string][]*route53.ResourceRecordSet // by hosted zone ID
+	Changes []*route53.Change
+}
+
+func (m *mockRoute53Records) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	return &route53.ListHostedZonesByNameOutput{HostedZones: []*route53.HostedZone{{Id: aws.String("/hostedzone/" + *input.DNSName), Name: input.DNSName}}}, nil
+}
+
+func (m *mockRoute53Records) ListResourceRecordSetsPages(input *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+	fn(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: m.Records[*input.HostedZoneId]}, true)
+	return nil
+}
+
+func (m *mockRoute53Records) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	m.Changes = append(m.Changes, input.ChangeBatch.Changes...)
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+func TestServiceLoadBalancerNLB(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:        "rh-api",
+		Namespace:   "openshift-kube-apiserver",
+		UID:         "1234-5678",
+		Annotations: map[string]string{serviceLoadBalancerTypeAnnotation: "nlb"},
+	}}
+	found := mockDescribeELBv2LoadBalancers{Resp: elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{{
+		LoadBalancerName:      aws.String("a12345678"),
+		DNSName:               aws.String("a12345678.elb.us-east-1.amazonaws.com"),
+		CanonicalHostedZoneId: aws.String("NLBZONE"),
+	}}}}
+	client := &Client{elbv2Client: found}
+
+	lb, err := client.serviceLoadBalancer(svc)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if lb.elbName != "a12345678" || lb.dnsName != "a12345678.elb.us-east-1.amazonaws.com" || lb.dnsZoneID != "NLBZONE" {
+		t.Errorf("Unexpected load balancer %+v", lb)
+	}
+
+	// The NLB isn't created yet
+	client = &Client{elbv2Client: mockDescribeELBv2LoadBalancers{ErrResp: elbv2.ErrCodeLoadBalancerNotFoundException}}
+	_, err = client.serviceLoadBalancer(svc)
+	if _, ok := err.(*errors.LoadBalancerNotReadyError); !ok {
+		t.Errorf("Expected the load balancer not to be ready, got %v", err)
+	}
+}
+
+func TestEnsureDNSRecordInternal(t *testing.T) {
+	// Left by the external load balancer
+	public := &route53.ResourceRecordSet{
+		Name:        aws.String("rh-api.cluster.sut.example.com."),
+		Type:        aws.String("A"),
+		AliasTarget: &route53.AliasTarget{DNSName: aws.String("external.elb.amazonaws.com."), HostedZoneId: aws.String("ZONE")},
+	}
+	route53Client := &mockRoute53Records{Records: map[string][]*route53.ResourceRecordSet{"sut.example.com.": {public}}}
+	client := &Client{route53Client: route53Client}
+	lb := &loadBalancer{endpointName: "rh-api", baseDomain: "cluster.sut.example.com", internal: true}
+	awsObj := &awsLoadBalancer{dnsName: "internal.elb.amazonaws.com", dnsZoneID: "ZONE"}
+
+	if err := client.ensureDNSRecord(context.TODO(), lb, awsObj, "comment"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	var upserted, deleted int
+	for _, change := range route53Client.Changes {
+		switch aws.StringValue(change.Action) {
+		case "UPSERT":
+			upserted++
+		case "DELETE":
+			deleted++
+			if change.ResourceRecordSet != public {
+				t.Errorf("Expected only the public record to be deleted, got %v", change.ResourceRecordSet)
+			}
+		}
+	}
+	if upserted != 1 || deleted != 1 {
+		t.Errorf("Expected the private record to be upserted and the public one deleted, got %v", route53Client.Changes)
+	}
+}
+
+func TestCheckAliasRecord(t *testing.T) {
+	expected := aliasRecord{
+		zone:      cloudingressv1alpha1.DNSRecordZonePublic,
+		zoneName:  "sut.example.com",
+		name:      "rh-api.cluster.sut.example.com.",
+		dnsName:   "lb.elb.amazonaws.com",
+		dnsZoneID: "ZONE",
+		comment:   "RH API Endpoint",
+	}
+	alias := func(dnsName, dnsZoneID string) *route53.ResourceRecordSet {
+		return &route53.ResourceRecordSet{
+			Name:        aws.String(expected.name),
+			Type:        aws.String("A"),
+			AliasTarget: &route53.AliasTarget{DNSName: aws.String(dnsName), HostedZoneId: aws.String(dnsZoneID)},
+		}
+	}
+
+	tests := []struct {
+		Name           string
+		Record         *route53.ResourceRecordSet
+		Repair         bool
+		ExpectedActual string
+		ExpectedDrift  bool
+	}{
+		{
+			Name:   "Should not report a record aliasing the load balancer",
+			Record: alias("lb.elb.amazonaws.com.", "ZONE"),
+		},
+		{
+			Name:   "Should not report a record aliasing the dualstack name of the load balancer",
+			Record: alias("dualstack.LB.elb.amazonaws.com.", "ZONE"),
+		},
+		{
+			Name:           "Should report a record aliasing another load balancer",
+			Record:         alias("other.elb.amazonaws.com.", "ZONE"),
+			ExpectedActual: "ALIAS other.elb.amazonaws.com.",
+			ExpectedDrift:  true,
+		},
+		{
+			Name: "Should report a record with addresses",
+			Record: &route53.ResourceRecordSet{
+				Name:            aws.String(expected.name),
+				Type:            aws.String("A"),
+				TTL:             aws.Int64(60),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("192.0.2.1")}},
+			},
+			ExpectedActual: "A 192.0.2.1 TTL 60",
+			ExpectedDrift:  true,
+		},
+		{
+			Name:          "Should repair a missing record",
+			Repair:        true,
+			ExpectedDrift: true,
+		},
+	}
+
+	for _, test := range tests {
+		route53Client := &mockRoute53Records{Records: map[string][]*route53.ResourceRecordSet{}}
+		if test.Record != nil {
+			route53Client.Records["sut.example.com."] = []*route53.ResourceRecordSet{test.Record}
+		}
+		client := &Client{route53Client: route53Client}
+
+		drift, err := client.checkAliasRecord(context.TODO(), expected, test.Repair)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if (drift != nil) != test.ExpectedDrift {
+			t.Fatalf("Test [%v] FAILED: unexpected drift %+v", test.Name, drift)
+		}
+		if drift == nil {
+			continue
+		}
+		if drift.Expected != "ALIAS lb.elb.amazonaws.com." || drift.Actual != test.ExpectedActual || drift.Repaired != test.Repair {
+			t.Errorf("Test [%v] FAILED: unexpected drift %+v", test.Name, drift)
+		}
+		if test.Repair != (len(route53Client.Changes) == 1) {
+			t.Errorf("Test [%v] FAILED: unexpected changes %v", test.Name, route53Client.Changes)
+		}
+	}
+}
+
+func TestServiceLoadBalancerOrphaned(t *testing.T) {
+	lbService := func(hostname string) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		}
+		if hostname != "" {
+			svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: hostname}}
+		}
+		return svc
+	}
+	clusterIPService := lbService("")
+	clusterIPService.Spec.Type = corev1.ServiceTypeClusterIP
+
+	tests := []struct {
+		Name           string
+		ServiceName    string
+		Service        *corev1.Service
+		ExpectedReason string
+	}{
+		{
+			Name:        "Should not report the load balancer of the Service",
+			ServiceName: "openshift-kube-apiserver/rh-api",
+			Service:     lbService("RH-API-123.elb.amazonaws.com"),
+		},
+		{
+			Name:           "Should report the load balancer of a deleted Service",
+			ServiceName:    "openshift-kube-apiserver/rh-api",
+			ExpectedReason: "the Service openshift-kube-apiserver/rh-api doesn't exist",
+		},
+		{
+			Name:           "Should report the load balancer of a Service which isn't a LoadBalancer anymore",
+			ServiceName:    "openshift-kube-apiserver/rh-api",
+			Service:        clusterIPService,
+			ExpectedReason: "the Service openshift-kube-apiserver/rh-api isn't a LoadBalancer",
+		},
+		{
+			Name:           "Should report the load balancer replaced by another",
+			ServiceName:    "openshift-kube-apiserver/rh-api",
+			Service:        lbService("rh-api-456.elb.amazonaws.com"),
+			ExpectedReason: "the Service openshift-kube-apiserver/rh-api uses another load balancer",
+		},
+		{
+			Name:        "Should wait for the status of the Service",
+			ServiceName: "openshift-kube-apiserver/rh-api",
+			Service:     lbService(""),
+		},
+		{
+			Name:        "Should ignore the Services of other namespaces",
+			ServiceName: "default/router",
+		},
+	}
+
+	for _, test := range tests {
+		objs := []runtime.Object{}
+		if test.Service != nil {
+			objs = append(objs, test.Service)
+		}
+		mocks := testutils.NewTestMock(t, objs)
+
+		reason, err := serviceLoadBalancerOrphaned(context.TODO(), mocks.FakeKubeClient, test.ServiceName, "rh-api-123.elb.amazonaws.com")
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if reason != test.ExpectedReason {
+			t.Errorf("Test [%v] FAILED: expected %q, got %q", test.Name, test.ExpectedReason, reason)
+		}
+	}
+}
+
+func TestPermissionsReferencing(t *testing.T) {
+	permissions := []*ec2.IpPermission{
+		{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(6443),
+			ToPort:     aws.Int64(6443),
+			UserIdGroupPairs: []*ec2.UserIdGroupPair{
+				{GroupId: aws.String("sg-elb"), Description: aws.String("rh-api")},
+				{GroupId: aws.String("sg-other")},
+			},
+		},
+		{
+			IpProtocol: aws.String("-1"),
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}},
+		},
+	}
+	expected := []*ec2.IpPermission{{
+		IpProtocol:       aws.String("tcp"),
+		FromPort:         aws.Int64(6443),
+		ToPort:           aws.Int64(6443),
+		UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-elb")}},
+	}}
+
+	if got := permissionsReferencing(permissions, "sg-elb"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := permissionsReferencing(permissions, "sg-unknown"); got != nil {
+		t.Errorf("Expected no permissions, got %v", got)
+	}
+}
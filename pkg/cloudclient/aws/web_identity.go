@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// webIdentitySessionName names the sessions of the assumed role in CloudTrail
+	webIdentitySessionName = "cloud-ingress-operator"
+	// webIdentityExpiryWindow is how long before they expire the credentials
+	// are refreshed, so that a call never starts with credentials about to expire
+	webIdentityExpiryWindow = 5 * time.Minute
+)
+
+// webIdentity is the role the operator assumes with the service account
+// token projected in its pod, on STS clusters
+type webIdentity struct {
+	roleARN   string
+	tokenFile string
+}
+
+// webIdentityFromSecret returns the role and token file of the Secret minted
+// by the cloud-credential-operator on STS clusters. These are either in the
+// default profile of the credentials key, or in keys of their own. ok is false
+// for Secrets with static credentials.
+func webIdentityFromSecret(secret *corev1.Secret) (identity webIdentity, ok bool) {
+	identity = webIdentity{
+		roleARN:   string(secret.Data["role_arn"]),
+		tokenFile: string(secret.Data["web_identity_token_file"]),
+	}
+	if identity.roleARN == "" || identity.tokenFile == "" {
+		profile := defaultProfile(secret.Data["credentials"])
+		identity = webIdentity{
+			roleARN:   profile["role_arn"],
+			tokenFile: profile["web_identity_token_file"],
+		}
+	}
+	return identity, identity.roleARN != "" && identity.tokenFile != ""
+}
+
+// defaultProfile returns the settings of the default profile in a shared
+// credentials file
+func defaultProfile(data []byte) map[string]string {
+	profile := map[string]string{}
+	inDefault := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inDefault = strings.TrimSpace(line[1:len(line)-1]) == "default"
+			continue
+		}
+		if key, value, found := strings.Cut(line, "="); inDefault && found {
+			profile[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return profile
+}
+
+// newWebIdentitySession returns a session with credentials from assuming the
+// role with the token file. The token is read again whenever the credentials
+// are refreshed, which picks up the tokens the kubelet rotates, so the pod
+// doesn't need to restart. stsEndpoint overrides the regional STS endpoint,
+// it's only set in tests.
+func newWebIdentitySession(region string, identity webIdentity, stsEndpoint string) (*session.Session, error) {
+	s, err := session.NewSession(&aws.Config{
+		Region:              aws.String(region),
+		STSRegionalEndpoint: endpoints.RegionalSTSEndpoint,
+		// The assumed role is the only source of credentials
+		Credentials: credentials.AnonymousCredentials,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stsConfig := aws.NewConfig()
+	if stsEndpoint != "" {
+		stsConfig = stsConfig.WithEndpoint(stsEndpoint)
+	}
+	provider := stscreds.NewWebIdentityRoleProviderWithOptions(
+		sts.New(s, stsConfig),
+		identity.roleARN,
+		webIdentitySessionName,
+		stscreds.FetchTokenPath(identity.tokenFile),
+		func(p *stscreds.WebIdentityRoleProvider) {
+			p.ExpiryWindow = webIdentityExpiryWindow
+		},
+	)
+	return s.Copy(&aws.Config{Credentials: credentials.NewCredentials(provider)}), nil
+}
@@ -0,0 +1,160 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// fakeSTS is a stand-in STS endpoint answering AssumeRoleWithWebIdentity
+type fakeSTS struct {
+	mu     sync.Mutex
+	tokens []string
+	roles  []string
+}
+
+func (f *fakeSTS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Form.Get("Action") != "AssumeRoleWithWebIdentity" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.tokens = append(f.tokens, r.Form.Get("WebIdentityToken"))
+	f.roles = append(f.roles, r.Form.Get("RoleArn"))
+	call := len(f.tokens)
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/xml")
+	fmt.Fprintf(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleWithWebIdentityResult>
+    <Credentials>
+      <AccessKeyId>ASIA%d</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>session</SessionToken>
+      <Expiration>%s</Expiration>
+    </Credentials>
+  </AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`, call, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+}
+
+func TestWebIdentityFromSecret(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     map[string]string
+		expected webIdentity
+		ok       bool
+	}{{
+		name: "credentials minted by the cloud-credential-operator",
+		data: map[string]string{
+			"credentials": `[default]
+sts_regional_endpoints = regional
+role_arn = arn:aws:iam::123456789012:role/cloud-ingress-operator
+web_identity_token_file = /var/run/secrets/openshift/serviceaccount/token
+`,
+		},
+		expected: webIdentity{
+			roleARN:   "arn:aws:iam::123456789012:role/cloud-ingress-operator",
+			tokenFile: "/var/run/secrets/openshift/serviceaccount/token",
+		},
+		ok: true,
+	}, {
+		name: "keys of their own",
+		data: map[string]string{
+			"role_arn":                "arn:aws:iam::123456789012:role/cloud-ingress-operator",
+			"web_identity_token_file": "/var/run/secrets/openshift/serviceaccount/token",
+		},
+		expected: webIdentity{
+			roleARN:   "arn:aws:iam::123456789012:role/cloud-ingress-operator",
+			tokenFile: "/var/run/secrets/openshift/serviceaccount/token",
+		},
+		ok: true,
+	}, {
+		name: "role in another profile",
+		data: map[string]string{
+			"credentials": `[other]
+role_arn = arn:aws:iam::123456789012:role/cloud-ingress-operator
+web_identity_token_file = /var/run/secrets/openshift/serviceaccount/token
+`,
+		},
+	}, {
+		name: "static credentials",
+		data: map[string]string{
+			"aws_access_key_id":     "asdf",
+			"aws_secret_access_key": "asdf1234",
+		},
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			secret := &corev1.Secret{Data: map[string][]byte{}}
+			for k, v := range test.data {
+				secret.Data[k] = []byte(v)
+			}
+			identity, ok := webIdentityFromSecret(secret)
+			assert.Equal(t, test.ok, ok)
+			if test.ok {
+				assert.Equal(t, test.expected, identity)
+			}
+		})
+	}
+}
+
+func TestWebIdentitySessionRefreshesToken(t *testing.T) {
+	sts := &fakeSTS{}
+	server := httptest.NewServer(sts)
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("first-token"), 0600))
+
+	identity := webIdentity{roleARN: "arn:aws:iam::123456789012:role/cloud-ingress-operator", tokenFile: tokenFile}
+	s, err := newWebIdentitySession("us-east-1", identity, server.URL)
+	assert.NoError(t, err)
+
+	value, err := s.Config.Credentials.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "ASIA1", value.AccessKeyID)
+	assert.Equal(t, "session", value.SessionToken)
+
+	// Cached until they expire
+	_, err = s.Config.Credentials.Get()
+	assert.NoError(t, err)
+	assert.Len(t, sts.tokens, 1)
+
+	// The kubelet rotates the token, which is used for the next credentials
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("second-token"), 0600))
+	s.Config.Credentials.Expire()
+	value, err = s.Config.Credentials.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "ASIA2", value.AccessKeyID)
+
+	assert.Equal(t, []string{"first-token", "second-token"}, sts.tokens)
+	assert.Equal(t, identity.roleARN, sts.roles[0])
+}
+
+func TestNewSessionWithWebIdentity(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{
+		"credentials": []byte(`[default]
+role_arn = arn:aws:iam::123456789012:role/cloud-ingress-operator
+web_identity_token_file = /var/run/secrets/openshift/serviceaccount/token
+`),
+	}}
+	s, err := newSession("us-east-1", secret)
+	assert.NoError(t, err)
+	// The token file doesn't exist here, it's only read when the credentials are used
+	_, err = s.Config.Credentials.Get()
+	var awsErr awserr.Error
+	if assert.ErrorAs(t, err, &awsErr) {
+		assert.Equal(t, stscreds.ErrCodeWebIdentity, awsErr.Code())
+	}
+}
@@ -0,0 +1,269 @@
+package azure
+
+// Small interfaces over the Azure SDK clients for the calls the operator
+// needs. The load balancer and public IP address models are the SDK ones, a
+// load balancer read and written back keeps every sub-resource. The public
+// and private DNS APIs model record sets differently and are both mapped to
+// RecordSet.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+	"k8s.io/utils/ptr"
+)
+
+// Record types used by the client
+const (
+	RecordTypeA     = "A"
+	RecordTypeCNAME = "CNAME"
+)
+
+// defaultPollFrequency is how often a long-running operation is polled when
+// Azure doesn't send a Retry-After header
+const defaultPollFrequency = 5 * time.Second
+
+// DNSAPI is the subset of the Azure DNS and Azure Private DNS APIs used by the client.
+// Zones are identified by their resource ID, as found in the cluster DNS config.
+type DNSAPI interface {
+	GetRecordSet(ctx context.Context, zoneID, recordType, name string) (*RecordSet, error)
+	CreateOrUpdateRecordSet(ctx context.Context, zoneID, recordType, name string, recordSet *RecordSet) error
+	DeleteRecordSet(ctx context.Context, zoneID, recordType, name string) error
+}
+
+// LoadBalancersAPI is the subset of the Azure Load Balancer API used by the client.
+// CreateOrUpdate returns once the update is done.
+type LoadBalancersAPI interface {
+	Get(ctx context.Context, resourceGroup, name string) (*armnetwork.LoadBalancer, error)
+	CreateOrUpdate(ctx context.Context, resourceGroup, name string, lb *armnetwork.LoadBalancer) error
+}
+
+// PublicIPAddressesAPI is the subset of the Azure Public IP Address API used by the client
+type PublicIPAddressesAPI interface {
+	Get(ctx context.Context, resourceGroup, name string) (*armnetwork.PublicIPAddress, error)
+}
+
+// RecordSet is an A or CNAME record set of a public or private DNS zone
+type RecordSet struct {
+	TTL      int64
+	ARecords []string
+	CNAME    string
+}
+
+// isNotFound tells if err is an Azure API 404
+func isNotFound(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
+
+// loadBalancersClient implements LoadBalancersAPI
+type loadBalancersClient struct {
+	client        *armnetwork.LoadBalancersClient
+	pollFrequency time.Duration
+}
+
+func newLoadBalancersClient(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (*loadBalancersClient, error) {
+	client, err := armnetwork.NewLoadBalancersClient(subscriptionID, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	return &loadBalancersClient{client: client, pollFrequency: defaultPollFrequency}, nil
+}
+
+func (c *loadBalancersClient) Get(ctx context.Context, resourceGroup, name string) (*armnetwork.LoadBalancer, error) {
+	resp, err := c.client.Get(ctx, resourceGroup, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.LoadBalancer, nil
+}
+
+func (c *loadBalancersClient) CreateOrUpdate(ctx context.Context, resourceGroup, name string, lb *armnetwork.LoadBalancer) error {
+	poller, err := c.client.BeginCreateOrUpdate(ctx, resourceGroup, name, *lb, nil)
+	if err != nil {
+		return err
+	}
+	_, err = poller.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{Frequency: c.pollFrequency})
+	return err
+}
+
+// publicIPAddressesClient implements PublicIPAddressesAPI
+type publicIPAddressesClient struct {
+	client *armnetwork.PublicIPAddressesClient
+}
+
+func newPublicIPAddressesClient(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (*publicIPAddressesClient, error) {
+	client, err := armnetwork.NewPublicIPAddressesClient(subscriptionID, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	return &publicIPAddressesClient{client: client}, nil
+}
+
+func (c *publicIPAddressesClient) Get(ctx context.Context, resourceGroup, name string) (*armnetwork.PublicIPAddress, error) {
+	resp, err := c.client.Get(ctx, resourceGroup, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.PublicIPAddress, nil
+}
+
+// dnsClient implements DNSAPI for both public and private zones. The
+// resource ID of the zone tells which API it belongs to, and the
+// subscription the record set clients are created for.
+type dnsClient struct {
+	credential azcore.TokenCredential
+	options    *arm.ClientOptions
+}
+
+// dnsZone is a public or private DNS zone parsed from its resource ID
+type dnsZone struct {
+	subscriptionID string
+	resourceGroup  string
+	name           string
+	private        bool
+}
+
+func parseZoneID(zoneID string) (*dnsZone, error) {
+	id, err := arm.ParseResourceID(zoneID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse the DNS zone ID %s: %w", zoneID, err)
+	}
+	return &dnsZone{
+		subscriptionID: id.SubscriptionID,
+		resourceGroup:  id.ResourceGroupName,
+		name:           id.Name,
+		private:        strings.EqualFold(id.ResourceType.Type, "privateDnsZones"),
+	}, nil
+}
+
+func (c *dnsClient) GetRecordSet(ctx context.Context, zoneID, recordType, name string) (*RecordSet, error) {
+	zone, err := parseZoneID(zoneID)
+	if err != nil {
+		return nil, err
+	}
+	if zone.private {
+		client, err := armprivatedns.NewRecordSetsClient(zone.subscriptionID, c.credential, c.options)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Get(ctx, zone.resourceGroup, zone.name, armprivatedns.RecordType(recordType), name, nil)
+		if err != nil {
+			return nil, err
+		}
+		return fromPrivateDNSRecordSet(&resp.RecordSet), nil
+	}
+	client, err := armdns.NewRecordSetsClient(zone.subscriptionID, c.credential, c.options)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Get(ctx, zone.resourceGroup, zone.name, name, armdns.RecordType(recordType), nil)
+	if err != nil {
+		return nil, err
+	}
+	return fromDNSRecordSet(&resp.RecordSet), nil
+}
+
+func (c *dnsClient) CreateOrUpdateRecordSet(ctx context.Context, zoneID, recordType, name string, recordSet *RecordSet) error {
+	zone, err := parseZoneID(zoneID)
+	if err != nil {
+		return err
+	}
+	if zone.private {
+		client, err := armprivatedns.NewRecordSetsClient(zone.subscriptionID, c.credential, c.options)
+		if err != nil {
+			return err
+		}
+		_, err = client.CreateOrUpdate(ctx, zone.resourceGroup, zone.name, armprivatedns.RecordType(recordType), name, toPrivateDNSRecordSet(recordSet), nil)
+		return err
+	}
+	client, err := armdns.NewRecordSetsClient(zone.subscriptionID, c.credential, c.options)
+	if err != nil {
+		return err
+	}
+	_, err = client.CreateOrUpdate(ctx, zone.resourceGroup, zone.name, name, armdns.RecordType(recordType), toDNSRecordSet(recordSet), nil)
+	return err
+}
+
+func (c *dnsClient) DeleteRecordSet(ctx context.Context, zoneID, recordType, name string) error {
+	zone, err := parseZoneID(zoneID)
+	if err != nil {
+		return err
+	}
+	if zone.private {
+		client, err := armprivatedns.NewRecordSetsClient(zone.subscriptionID, c.credential, c.options)
+		if err != nil {
+			return err
+		}
+		_, err = client.Delete(ctx, zone.resourceGroup, zone.name, armprivatedns.RecordType(recordType), name, nil)
+		return err
+	}
+	client, err := armdns.NewRecordSetsClient(zone.subscriptionID, c.credential, c.options)
+	if err != nil {
+		return err
+	}
+	_, err = client.Delete(ctx, zone.resourceGroup, zone.name, name, armdns.RecordType(recordType), nil)
+	return err
+}
+
+func fromDNSRecordSet(rs *armdns.RecordSet) *RecordSet {
+	recordSet := &RecordSet{}
+	if rs.Properties == nil {
+		return recordSet
+	}
+	recordSet.TTL = ptr.Deref(rs.Properties.TTL, 0)
+	for _, a := range rs.Properties.ARecords {
+		recordSet.ARecords = append(recordSet.ARecords, ptr.Deref(a.IPv4Address, ""))
+	}
+	if rs.Properties.CnameRecord != nil {
+		recordSet.CNAME = ptr.Deref(rs.Properties.CnameRecord.Cname, "")
+	}
+	return recordSet
+}
+
+func toDNSRecordSet(recordSet *RecordSet) armdns.RecordSet {
+	properties := &armdns.RecordSetProperties{TTL: ptr.To(recordSet.TTL)}
+	for _, ip := range recordSet.ARecords {
+		properties.ARecords = append(properties.ARecords, &armdns.ARecord{IPv4Address: ptr.To(ip)})
+	}
+	if recordSet.CNAME != "" {
+		properties.CnameRecord = &armdns.CnameRecord{Cname: ptr.To(recordSet.CNAME)}
+	}
+	return armdns.RecordSet{Properties: properties}
+}
+
+func fromPrivateDNSRecordSet(rs *armprivatedns.RecordSet) *RecordSet {
+	recordSet := &RecordSet{}
+	if rs.Properties == nil {
+		return recordSet
+	}
+	recordSet.TTL = ptr.Deref(rs.Properties.TTL, 0)
+	for _, a := range rs.Properties.ARecords {
+		recordSet.ARecords = append(recordSet.ARecords, ptr.Deref(a.IPv4Address, ""))
+	}
+	if rs.Properties.CnameRecord != nil {
+		recordSet.CNAME = ptr.Deref(rs.Properties.CnameRecord.Cname, "")
+	}
+	return recordSet
+}
+
+func toPrivateDNSRecordSet(recordSet *RecordSet) armprivatedns.RecordSet {
+	properties := &armprivatedns.RecordSetProperties{TTL: ptr.To(recordSet.TTL)}
+	for _, ip := range recordSet.ARecords {
+		properties.ARecords = append(properties.ARecords, &armprivatedns.ARecord{IPv4Address: ptr.To(ip)})
+	}
+	if recordSet.CNAME != "" {
+		properties.CnameRecord = &armprivatedns.CnameRecord{Cname: ptr.To(recordSet.CNAME)}
+	}
+	return armprivatedns.RecordSet{Properties: properties}
+}
@@ -0,0 +1,171 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	dnsfake "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	networkfake "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+	privatednsfake "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns/fake"
+	"k8s.io/utils/ptr"
+)
+
+func fakeClientOptions(transport policy.Transporter) *arm.ClientOptions {
+	return &arm.ClientOptions{ClientOptions: azcore.ClientOptions{Transport: transport}}
+}
+
+func newFakeLoadBalancersClient(t *testing.T, server *networkfake.LoadBalancersServer) *loadBalancersClient {
+	client, err := newLoadBalancersClient("sub", &azfake.TokenCredential{}, fakeClientOptions(networkfake.NewLoadBalancersServerTransport(server)))
+	if err != nil {
+		t.Fatalf("Couldn't create the load balancers client: %v", err)
+	}
+	client.pollFrequency = time.Millisecond
+	return client
+}
+
+func TestLoadBalancerCreateOrUpdate(t *testing.T) {
+	var updated *armnetwork.LoadBalancer
+	server := &networkfake.LoadBalancersServer{
+		BeginCreateOrUpdate: func(ctx context.Context, resourceGroupName, loadBalancerName string, parameters armnetwork.LoadBalancer, options *armnetwork.LoadBalancersClientBeginCreateOrUpdateOptions) (resp azfake.PollerResponder[armnetwork.LoadBalancersClientCreateOrUpdateResponse], errResp azfake.ErrorResponder) {
+			if resourceGroupName != "rg" || loadBalancerName != "sut" {
+				t.Errorf("Unexpected load balancer %s/%s", resourceGroupName, loadBalancerName)
+			}
+			updated = &parameters
+			resp.AddNonTerminalResponse(http.StatusCreated, nil)
+			resp.AddNonTerminalResponse(http.StatusOK, nil)
+			resp.SetTerminalResponse(http.StatusOK, armnetwork.LoadBalancersClientCreateOrUpdateResponse{LoadBalancer: parameters}, nil)
+			return
+		},
+	}
+	lbClient := newFakeLoadBalancersClient(t, server)
+
+	// The outbound rules aren't changed by the client and must be written back as they were read
+	lb := &armnetwork.LoadBalancer{
+		Name: ptr.To("sut"),
+		Properties: &armnetwork.LoadBalancerPropertiesFormat{
+			OutboundRules: []*armnetwork.OutboundRule{{Name: ptr.To("outbound-rule-v4")}},
+		},
+	}
+	if err := lbClient.CreateOrUpdate(context.TODO(), "rg", "sut", lb); err != nil {
+		t.Fatalf("Couldn't update the load balancer: %v", err)
+	}
+	if updated == nil || len(updated.Properties.OutboundRules) != 1 || *updated.Properties.OutboundRules[0].Name != "outbound-rule-v4" {
+		t.Errorf("The outbound rules weren't kept: %+v", updated)
+	}
+}
+
+func TestLoadBalancerCreateOrUpdateFailed(t *testing.T) {
+	server := &networkfake.LoadBalancersServer{
+		BeginCreateOrUpdate: func(ctx context.Context, resourceGroupName, loadBalancerName string, parameters armnetwork.LoadBalancer, options *armnetwork.LoadBalancersClientBeginCreateOrUpdateOptions) (resp azfake.PollerResponder[armnetwork.LoadBalancersClientCreateOrUpdateResponse], errResp azfake.ErrorResponder) {
+			resp.AddNonTerminalResponse(http.StatusCreated, nil)
+			resp.SetTerminalError(http.StatusConflict, "AnotherOperationInProgress")
+			return
+		},
+	}
+	lbClient := newFakeLoadBalancersClient(t, server)
+
+	err := lbClient.CreateOrUpdate(context.TODO(), "rg", "sut", &armnetwork.LoadBalancer{})
+	if err == nil || !strings.Contains(err.Error(), "AnotherOperationInProgress") {
+		t.Errorf("Expected the operation to fail, got %v", err)
+	}
+}
+
+// zoneTransport sends the requests for private zones to the Azure Private DNS
+// fake and the others to the Azure DNS fake
+type zoneTransport struct {
+	public  policy.Transporter
+	private policy.Transporter
+}
+
+func (z *zoneTransport) Do(req *http.Request) (*http.Response, error) {
+	if strings.Contains(strings.ToLower(req.URL.Path), "/privatednszones/") {
+		return z.private.Do(req)
+	}
+	return z.public.Do(req)
+}
+
+func TestRecordSetZones(t *testing.T) {
+	var publicRecordSet *armdns.RecordSet
+	var privateRecordSet *armprivatedns.RecordSet
+	publicServer := &dnsfake.RecordSetsServer{
+		CreateOrUpdate: func(ctx context.Context, resourceGroupName, zoneName, relativeRecordSetName string, recordType armdns.RecordType, parameters armdns.RecordSet, options *armdns.RecordSetsClientCreateOrUpdateOptions) (resp azfake.Responder[armdns.RecordSetsClientCreateOrUpdateResponse], errResp azfake.ErrorResponder) {
+			if resourceGroupName != "dns-rg" || zoneName != "example.com" || relativeRecordSetName != "rh-api.sut" || recordType != armdns.RecordTypeA {
+				t.Errorf("Unexpected public record set %s/%s/%s/%s", resourceGroupName, zoneName, recordType, relativeRecordSetName)
+			}
+			publicRecordSet = &parameters
+			resp.SetResponse(http.StatusOK, armdns.RecordSetsClientCreateOrUpdateResponse{RecordSet: parameters}, nil)
+			return
+		},
+	}
+	privateServer := &privatednsfake.RecordSetsServer{
+		CreateOrUpdate: func(ctx context.Context, resourceGroupName, privateZoneName string, recordType armprivatedns.RecordType, relativeRecordSetName string, parameters armprivatedns.RecordSet, options *armprivatedns.RecordSetsClientCreateOrUpdateOptions) (resp azfake.Responder[armprivatedns.RecordSetsClientCreateOrUpdateResponse], errResp azfake.ErrorResponder) {
+			if resourceGroupName != "sut-rg" || privateZoneName != "sut.example.com" || relativeRecordSetName != "rh-api" || recordType != armprivatedns.RecordTypeA {
+				t.Errorf("Unexpected private record set %s/%s/%s/%s", resourceGroupName, privateZoneName, recordType, relativeRecordSetName)
+			}
+			privateRecordSet = &parameters
+			resp.SetResponse(http.StatusOK, armprivatedns.RecordSetsClientCreateOrUpdateResponse{RecordSet: parameters}, nil)
+			return
+		},
+	}
+	dns := &dnsClient{
+		credential: &azfake.TokenCredential{},
+		options: fakeClientOptions(&zoneTransport{
+			public:  dnsfake.NewRecordSetsServerTransport(publicServer),
+			private: privatednsfake.NewRecordSetsServerTransport(privateServer),
+		}),
+	}
+
+	desired := &RecordSet{TTL: 30, ARecords: []string{"10.0.0.4"}}
+	if err := dns.CreateOrUpdateRecordSet(context.TODO(), testPublicZoneID, RecordTypeA, "rh-api.sut", desired); err != nil {
+		t.Fatalf("Couldn't update the public record set: %v", err)
+	}
+	if err := dns.CreateOrUpdateRecordSet(context.TODO(), testPrivateZoneID, RecordTypeA, "rh-api", desired); err != nil {
+		t.Fatalf("Couldn't update the private record set: %v", err)
+	}
+	if publicRecordSet == nil || !reflect.DeepEqual(fromDNSRecordSet(publicRecordSet), desired) {
+		t.Errorf("Unexpected public record set %+v", publicRecordSet)
+	}
+	if privateRecordSet == nil || !reflect.DeepEqual(fromPrivateDNSRecordSet(privateRecordSet), desired) {
+		t.Errorf("Unexpected private record set %+v", privateRecordSet)
+	}
+}
+
+func TestGetRecordSet(t *testing.T) {
+	server := &dnsfake.RecordSetsServer{
+		Get: func(ctx context.Context, resourceGroupName, zoneName, relativeRecordSetName string, recordType armdns.RecordType, options *armdns.RecordSetsClientGetOptions) (resp azfake.Responder[armdns.RecordSetsClientGetResponse], errResp azfake.ErrorResponder) {
+			if recordType != armdns.RecordTypeCNAME {
+				errResp.SetResponseError(http.StatusNotFound, "NotFound")
+				return
+			}
+			resp.SetResponse(http.StatusOK, armdns.RecordSetsClientGetResponse{RecordSet: armdns.RecordSet{
+				Properties: &armdns.RecordSetProperties{TTL: ptr.To[int64](300), CnameRecord: &armdns.CnameRecord{Cname: ptr.To(testPublicFQDN)}},
+			}}, nil)
+			return
+		},
+	}
+	dns := &dnsClient{credential: &azfake.TokenCredential{}, options: fakeClientOptions(dnsfake.NewRecordSetsServerTransport(server))}
+
+	rs, err := dns.GetRecordSet(context.TODO(), testPublicZoneID, RecordTypeCNAME, "api.sut")
+	if err != nil {
+		t.Fatalf("Couldn't get the record set: %v", err)
+	}
+	if rs.TTL != 300 || rs.CNAME != testPublicFQDN {
+		t.Errorf("Unexpected record set %+v", rs)
+	}
+
+	_, err = dns.GetRecordSet(context.TODO(), testPublicZoneID, RecordTypeA, "api.sut")
+	if !isNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
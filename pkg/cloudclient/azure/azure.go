@@ -0,0 +1,215 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	configv1 "github.com/openshift/api/config/v1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ClientIdentifier is what kind of cloud this implement supports
+const ClientIdentifier configv1.PlatformType = configv1.AzurePlatformType
+
+var (
+	log = logf.Log.WithName("azure_cloudclient")
+)
+
+// Client represents an Azure cloud Client
+type Client struct {
+	resourceGroup  string
+	clusterName    string
+	baseDomain     string
+	dnsClient      DNSAPI
+	lbClient       LoadBalancersAPI
+	publicIPClient PublicIPAddressesAPI
+}
+
+// EnsureAdminAPIDNS implements cloudclient.CloudClient
+func (az *Client) EnsureAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return az.ensureAdminAPIDNS(ctx, kclient, instance, svc)
+}
+
+// DeleteAdminAPIDNS implements cloudclient.CloudClient
+func (az *Client) DeleteAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return az.deleteAdminAPIDNS(ctx, kclient, instance, svc)
+}
+
+// DeleteAdminAPIDNSWithoutService implements cloudclient.CloudClient
+func (az *Client) DeleteAdminAPIDNSWithoutService(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return az.deleteAdminAPIDNSWithoutService(ctx, kclient, instance)
+}
+
+// CheckAdminAPIDNS implements cloudclient.CloudClient
+// The records aren't checked for drift on Azure, none are reported.
+func (az *Client) CheckAdminAPIDNS(_ context.Context, _ k8s.Client, _ *cloudingressv1alpha1.APIScheme, _ *corev1.Service, _ bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	return nil, nil
+}
+
+// SetDefaultAPIPrivate implements cloudclient.CloudClient
+func (az *Client) SetDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return az.setDefaultAPIPrivate(ctx, kclient, instance)
+}
+
+// SetDefaultAPIPublic implements cloudclient.CloudClient
+func (az *Client) SetDefaultAPIPublic(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return az.setDefaultAPIPublic(ctx, kclient, instance)
+}
+
+// PlanDefaultAPIPrivate implements cloudclient.CloudClient
+func (az *Client) PlanDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	return az.planDefaultAPIPrivate(ctx, kclient, instance)
+}
+
+// PlanDefaultAPIPublic implements cloudclient.CloudClient
+func (az *Client) PlanDefaultAPIPublic(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	return az.planDefaultAPIPublic(ctx, kclient, instance)
+}
+
+// ReactivateControlPlaneMachineSet implements cloudclient.CloudClient
+// The load balancers aren't removed from the ControlPlaneMachineSet on Azure, it is never deleted.
+func (az *Client) ReactivateControlPlaneMachineSet(_ context.Context, _ k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) error {
+	return nil
+}
+
+// EnsureControlPlaneLoadBalancers implements cloudclient.CloudClient
+// The control plane Machines stay in the public load balancer on Azure, it is used for outbound traffic.
+func (az *Client) EnsureControlPlaneLoadBalancers(_ context.Context, _ k8s.Client, _ cloudingressv1alpha1.Listening) error {
+	return nil
+}
+
+// GetDefaultAPIListening implements cloudclient.CloudClient
+func (az *Client) GetDefaultAPIListening(ctx context.Context, kclient k8s.Client) (cloudingressv1alpha1.Listening, error) {
+	return az.getDefaultAPIListening(ctx, kclient)
+}
+
+// CheckDefaultAPIDNS implements cloudclient.CloudClient
+// The records aren't checked for drift on Azure, none are reported.
+func (az *Client) CheckDefaultAPIDNS(_ context.Context, _ k8s.Client, _ cloudingressv1alpha1.Listening, _ bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	return nil, nil
+}
+
+// FindOrphanedResources implements cloudclient.CloudClient
+// The operator doesn't create load balancers on Azure, none are reported.
+func (az *Client) FindOrphanedResources(_ context.Context, _ k8s.Client, _ cloudingressv1alpha1.Listening) ([]cloudingressv1alpha1.OrphanedResource, error) {
+	return nil, nil
+}
+
+// DeleteOrphanedResource implements cloudclient.CloudClient
+func (az *Client) DeleteOrphanedResource(_ context.Context, _ k8s.Client, resource cloudingressv1alpha1.OrphanedResource) error {
+	return fmt.Errorf("orphaned %s %s can't be deleted on Azure", resource.Kind, resource.Name)
+}
+
+// Healthcheck performs basic calls to make sure client is healthy
+func (az *Client) Healthcheck(ctx context.Context, kclient k8s.Client) error {
+	_, err := az.lbClient.Get(ctx, az.resourceGroup, az.clusterName)
+	return err
+}
+
+// cloudConfiguration returns the Azure AD and Resource Manager endpoints of an Azure cloud
+func cloudConfiguration(cloudName configv1.AzureCloudEnvironment) (cloud.Configuration, error) {
+	switch cloudName {
+	case "", configv1.AzurePublicCloud:
+		return cloud.AzurePublic, nil
+	case configv1.AzureUSGovernmentCloud:
+		return cloud.AzureGovernment, nil
+	case configv1.AzureChinaCloud:
+		return cloud.AzureChina, nil
+	}
+	return cloud.Configuration{}, fmt.Errorf("unsupported Azure cloud %s", cloudName)
+}
+
+func newClient(cloudName configv1.AzureCloudEnvironment, tenantID, clientID, clientSecret, subscriptionID string) (*Client, error) {
+	cloudConfig, err := cloudConfiguration(cloudName)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret,
+		&azidentity.ClientSecretCredentialOptions{ClientOptions: azcore.ClientOptions{Cloud: cloudConfig}})
+	if err != nil {
+		return nil, err
+	}
+	return newAPIClients(subscriptionID, credential, &arm.ClientOptions{ClientOptions: azcore.ClientOptions{Cloud: cloudConfig}})
+}
+
+// newAPIClients creates the clients of the Azure APIs used by the Client
+func newAPIClients(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (*Client, error) {
+	lbClient, err := newLoadBalancersClient(subscriptionID, credential, options)
+	if err != nil {
+		return nil, err
+	}
+	publicIPClient, err := newPublicIPAddressesClient(subscriptionID, credential, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		dnsClient:      &dnsClient{credential: credential, options: options},
+		lbClient:       lbClient,
+		publicIPClient: publicIPClient,
+	}, nil
+}
+
+// NewClient creates a new CloudClient for use with Azure.
+func NewClient(kclient k8s.Client) (*Client, error) {
+	ctx := context.Background()
+	secret := &corev1.Secret{}
+	err := kclient.Get(
+		ctx,
+		types.NamespacedName{
+			Name:      operatorconfig.Get().AzureSecretName,
+			Namespace: config.OperatorNamespace,
+		},
+		secret)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get Secret with credentials %w", err)
+	}
+	credentials := map[string]string{}
+	for _, key := range []string{"azure_tenant_id", "azure_client_id", "azure_client_secret", "azure_subscription_id"} {
+		value, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("access credentials missing %s", key)
+		}
+		credentials[key] = string(value)
+	}
+
+	infra, err := baseutils.GetInfrastructureObject(kclient)
+	if err != nil {
+		return nil, err
+	}
+	if infra.Status.PlatformStatus == nil || infra.Status.PlatformStatus.Azure == nil {
+		return nil, fmt.Errorf("the Infrastructure has no Azure platform status")
+	}
+
+	// initialize actual client
+	c, err := newClient(infra.Status.PlatformStatus.Azure.CloudName,
+		credentials["azure_tenant_id"], credentials["azure_client_id"],
+		credentials["azure_client_secret"], credentials["azure_subscription_id"])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create Azure client %s", err)
+	}
+
+	// enchant the client with params required
+	c.resourceGroup = infra.Status.PlatformStatus.Azure.ResourceGroupName
+	c.clusterName = infra.Status.InfrastructureName
+	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return nil, err
+	}
+	c.baseDomain = baseDomain
+
+	return c, nil
+}
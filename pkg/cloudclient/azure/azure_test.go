@@ -0,0 +1,81 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func azureCredentialsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      config.AzureSecretName,
+			Namespace: config.OperatorNamespace,
+		},
+		Data: map[string][]byte{
+			"azure_tenant_id":       []byte("tenant"),
+			"azure_client_id":       []byte("client"),
+			"azure_client_secret":   []byte("secret"), // #nosec G101
+			"azure_subscription_id": []byte("subscription"),
+			"azure_region":          []byte("eastus"),
+		},
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	infra := testutils.CreateAzureInfraObject("sut", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, "sut-rg")
+
+	objs := []runtime.Object{infra, azureCredentialsSecret()}
+	mocks := testutils.NewTestMock(t, objs)
+	cli, err := NewClient(mocks.FakeKubeClient)
+
+	if err != nil {
+		t.Fatal("err occurred while creating cli:", err)
+	}
+
+	if cli.resourceGroup != "sut-rg" || cli.clusterName != "sut" || cli.baseDomain != "unit.test" {
+		t.Errorf("cli wasn't initialized from the Infrastructure: %+v", cli)
+	}
+}
+
+func TestNewClientMissingCredentials(t *testing.T) {
+	infra := testutils.CreateAzureInfraObject("sut", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, "sut-rg")
+	secret := azureCredentialsSecret()
+	delete(secret.Data, "azure_client_secret")
+
+	objs := []runtime.Object{infra, secret}
+	mocks := testutils.NewTestMock(t, objs)
+	_, err := NewClient(mocks.FakeKubeClient)
+	if err == nil {
+		t.Error("expected an error for the missing client secret")
+	}
+}
+
+func TestCloudConfiguration(t *testing.T) {
+	tests := []struct {
+		cloudName        configv1.AzureCloudEnvironment
+		expectedEndpoint string
+		expectErr        bool
+	}{
+		{cloudName: "", expectedEndpoint: "https://management.azure.com"},
+		{cloudName: configv1.AzurePublicCloud, expectedEndpoint: "https://management.azure.com"},
+		{cloudName: configv1.AzureUSGovernmentCloud, expectedEndpoint: "https://management.usgovcloudapi.net"},
+		{cloudName: configv1.AzureChinaCloud, expectedEndpoint: "https://management.chinacloudapi.cn"},
+		{cloudName: configv1.AzureStackCloud, expectErr: true},
+	}
+	for _, test := range tests {
+		cloudConfig, err := cloudConfiguration(test.cloudName)
+		if (err != nil) != test.expectErr {
+			t.Errorf("Test [%v] FAILED: unexpected error %v", test.cloudName, err)
+		}
+		if endpoint := cloudConfig.Services[cloud.ResourceManager].Endpoint; endpoint != test.expectedEndpoint {
+			t.Errorf("Test [%v] FAILED: expected %s, got %s", test.cloudName, test.expectedEndpoint, endpoint)
+		}
+	}
+}
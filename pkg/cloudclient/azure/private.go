@@ -0,0 +1,619 @@
+package azure
+
+// "Private" or non-interface conforming methods
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	configv1 "github.com/openshift/api/config/v1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
+)
+
+// Names given by the installer to the API load balancer resources, see
+// https://github.com/openshift/installer/tree/master/data/data/azure
+const (
+	publicFrontendName   = "public-lb-ip-v4"
+	internalFrontendName = "internal-lb-ip-v4"
+	apiRuleName          = "api-v4"
+	apiProbeName         = "api-probe"
+	apiPort              = 6443
+	dnsRecordTTL         = 30
+)
+
+// ensureAdminAPIDNS ensures the DNS record for the "admin API" Service
+// LoadBalancer is accurately set
+func (az *Client) ensureAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	internal := instance.Spec.ManagementAPIServerIngress.Listening == cloudingressv1alpha1.Internal
+	return az.ensureDNSForService(ctx, kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName, internal)
+}
+
+// deleteAdminAPIDNS removes the DNS record for the "admin API" Service
+// LoadBalancer
+func (az *Client) deleteAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return az.removeDNSForName(ctx, kclient, instance.Spec.ManagementAPIServerIngress.DNSName)
+}
+
+// deleteAdminAPIDNSWithoutService removes the DNS record for the "admin API"
+// when its Service is already gone. The record is removed by name so this is
+// the same as deleteAdminAPIDNS.
+func (az *Client) deleteAdminAPIDNSWithoutService(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return az.removeDNSForName(ctx, kclient, instance.Spec.ManagementAPIServerIngress.DNSName)
+}
+
+// setDefaultAPIPrivate sets the default API (api.<cluster-domain>) to private
+// scope. The API rule is removed from the public load balancer, whose frontend
+// is kept for the outbound traffic of the cluster, and the api record of the
+// public zone is pointed to the internal load balancer.
+func (az *Client) setDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) error {
+	publicLB, err := az.lbClient.Get(ctx, az.resourceGroup, az.clusterName)
+	if err != nil {
+		return err
+	}
+	var rules []*armnetwork.LoadBalancingRule
+	for _, rule := range publicLB.Properties.LoadBalancingRules {
+		if !isAPIRule(rule) {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) != len(publicLB.Properties.LoadBalancingRules) {
+		publicLB.Properties.LoadBalancingRules = rules
+		err = az.lbClient.CreateOrUpdate(ctx, az.resourceGroup, az.clusterName, publicLB)
+		if err != nil {
+			cioevents.Warning(ctx, cioevents.ReasonLoadBalancerUpdateFailed, cioevents.ActionUpdate, "Couldn't remove the API rule from the public load balancer %s: %v", az.clusterName, err)
+			return err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonLoadBalancerUpdated, cioevents.ActionUpdate, "Removed the API rule from the public load balancer %s", az.clusterName)
+	}
+
+	intIPAddress, err := az.getInternalAPIIPAddress(ctx)
+	if err != nil {
+		return err
+	}
+	apiDNSName := "api." + az.baseDomain
+	err = az.updateAPIRecord(ctx, kclient, apiDNSName, &RecordSet{TTL: dnsRecordTTL, ARecords: []string{intIPAddress}})
+	if err != nil {
+		return err
+	}
+	log.Info("Successfully set default API to private", "URL", apiDNSName, "IP Address", intIPAddress)
+	return nil
+}
+
+// setDefaultAPIPublic sets the default API (api.<cluster-domain>) to public
+// scope by restoring the API rule of the public load balancer and pointing the
+// api record of the public zone back to its public IP address
+func (az *Client) setDefaultAPIPublic(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) error {
+	publicLB, err := az.lbClient.Get(ctx, az.resourceGroup, az.clusterName)
+	if err != nil {
+		return err
+	}
+	frontend, err := findFrontend(publicLB, publicFrontendName)
+	if err != nil {
+		return err
+	}
+	if !hasAPIRule(publicLB) {
+		err = az.addAPIRule(publicLB, frontend)
+		if err != nil {
+			return err
+		}
+		err = az.lbClient.CreateOrUpdate(ctx, az.resourceGroup, az.clusterName, publicLB)
+		if err != nil {
+			cioevents.Warning(ctx, cioevents.ReasonLoadBalancerUpdateFailed, cioevents.ActionUpdate, "Couldn't add the API rule to the public load balancer %s: %v", az.clusterName, err)
+			return err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonLoadBalancerUpdated, cioevents.ActionUpdate, "Added the API rule to the public load balancer %s", az.clusterName)
+	}
+
+	publicRecord, err := az.getPublicAPIRecord(ctx, frontend)
+	if err != nil {
+		return err
+	}
+	apiDNSName := "api." + az.baseDomain
+	err = az.updateAPIRecord(ctx, kclient, apiDNSName, publicRecord)
+	if err != nil {
+		return err
+	}
+	log.Info("Successfully set default API load balancer to external", "URL", apiDNSName)
+	return nil
+}
+
+// getDefaultAPIListening reports the default API as external when the public
+// load balancer has a rule for the API port
+func (az *Client) getDefaultAPIListening(ctx context.Context, kclient k8s.Client) (cloudingressv1alpha1.Listening, error) {
+	publicLB, err := az.lbClient.Get(ctx, az.resourceGroup, az.clusterName)
+	if err != nil {
+		return "", err
+	}
+	if hasAPIRule(publicLB) {
+		return cloudingressv1alpha1.External, nil
+	}
+	return cloudingressv1alpha1.Internal, nil
+}
+
+// planDefaultAPIPrivate lists the changes setDefaultAPIPrivate would make: the
+// API rule it removes from the public load balancer and the api record pointed
+// to the internal load balancer
+func (az *Client) planDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	publicLB, err := az.lbClient.Get(ctx, az.resourceGroup, az.clusterName)
+	if err != nil {
+		return nil, err
+	}
+	var actions []cloudingressv1alpha1.PlannedAction
+	for _, rule := range publicLB.Properties.LoadBalancingRules {
+		if isAPIRule(rule) {
+			actions = append(actions, cloudingressv1alpha1.PlannedAction{
+				Action:      cloudingressv1alpha1.PlannedActionUpdate,
+				Kind:        cloudingressv1alpha1.PlannedResourceLoadBalancer,
+				Name:        az.clusterName,
+				Description: fmt.Sprintf("Remove the rule %s for TCP port %d from the public load balancer", ptr.Deref(rule.Name, ""), apiPort),
+			})
+		}
+	}
+
+	intIPAddress, err := az.getInternalAPIIPAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dnsAction, err := az.planAPIRecord(ctx, kclient, &RecordSet{TTL: dnsRecordTTL, ARecords: []string{intIPAddress}},
+		fmt.Sprintf("the internal load balancer %s-internal (%s)", az.clusterName, intIPAddress))
+	if err != nil {
+		return nil, err
+	}
+	if dnsAction != nil {
+		actions = append(actions, *dnsAction)
+	}
+	return actions, nil
+}
+
+// planDefaultAPIPublic lists the changes setDefaultAPIPublic would make: the
+// API rule it adds to the public load balancer and the api record pointed to
+// its public IP address
+func (az *Client) planDefaultAPIPublic(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	publicLB, err := az.lbClient.Get(ctx, az.resourceGroup, az.clusterName)
+	if err != nil {
+		return nil, err
+	}
+	frontend, err := findFrontend(publicLB, publicFrontendName)
+	if err != nil {
+		return nil, err
+	}
+	var actions []cloudingressv1alpha1.PlannedAction
+	if !hasAPIRule(publicLB) {
+		actions = append(actions, cloudingressv1alpha1.PlannedAction{
+			Action:      cloudingressv1alpha1.PlannedActionUpdate,
+			Kind:        cloudingressv1alpha1.PlannedResourceLoadBalancer,
+			Name:        az.clusterName,
+			Description: fmt.Sprintf("Add the rule %s for TCP port %d on frontend %s to the public load balancer", apiRuleName, apiPort, ptr.Deref(frontend.Name, "")),
+		})
+	}
+
+	publicRecord, err := az.getPublicAPIRecord(ctx, frontend)
+	if err != nil {
+		return nil, err
+	}
+	target := publicRecord.CNAME
+	if target == "" {
+		target = strings.Join(publicRecord.ARecords, ",")
+	}
+	dnsAction, err := az.planAPIRecord(ctx, kclient, publicRecord, "the public IP address "+target)
+	if err != nil {
+		return nil, err
+	}
+	if dnsAction != nil {
+		actions = append(actions, *dnsAction)
+	}
+	return actions, nil
+}
+
+// isAPIRule tells if the load balancing rule forwards the API port
+func isAPIRule(rule *armnetwork.LoadBalancingRule) bool {
+	return rule.Properties != nil && ptr.Deref(rule.Properties.FrontendPort, 0) == apiPort
+}
+
+// hasAPIRule tells if the load balancer forwards the API port
+func hasAPIRule(lb *armnetwork.LoadBalancer) bool {
+	for _, rule := range lb.Properties.LoadBalancingRules {
+		if isAPIRule(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func findFrontend(lb *armnetwork.LoadBalancer, name string) (*armnetwork.FrontendIPConfiguration, error) {
+	for _, frontend := range lb.Properties.FrontendIPConfigurations {
+		if ptr.Deref(frontend.Name, "") == name {
+			return frontend, nil
+		}
+	}
+	return nil, fmt.Errorf("frontend %s not found in load balancer %s", name, ptr.Deref(lb.Name, ""))
+}
+
+// addAPIRule adds the API rule the installer creates to the public load
+// balancer, along with its health probe when it was removed as well
+func (az *Client) addAPIRule(lb *armnetwork.LoadBalancer, frontend *armnetwork.FrontendIPConfiguration) error {
+	var backendPool *armnetwork.BackendAddressPool
+	for _, pool := range lb.Properties.BackendAddressPools {
+		if ptr.Deref(pool.Name, "") == az.clusterName {
+			backendPool = pool
+		}
+	}
+	if backendPool == nil {
+		return fmt.Errorf("backend pool %s not found in load balancer %s", az.clusterName, ptr.Deref(lb.Name, ""))
+	}
+
+	probeID := ""
+	for _, probe := range lb.Properties.Probes {
+		if ptr.Deref(probe.Name, "") == apiProbeName {
+			probeID = ptr.Deref(probe.ID, "")
+		}
+	}
+	if probeID == "" {
+		probeID = ptr.Deref(lb.ID, "") + "/probes/" + apiProbeName
+		lb.Properties.Probes = append(lb.Properties.Probes, &armnetwork.Probe{
+			ID:   ptr.To(probeID),
+			Name: ptr.To(apiProbeName),
+			Properties: &armnetwork.ProbePropertiesFormat{
+				Protocol:          ptr.To(armnetwork.ProbeProtocolHTTPS),
+				Port:              ptr.To[int32](apiPort),
+				RequestPath:       ptr.To("/readyz"),
+				IntervalInSeconds: ptr.To[int32](5),
+				NumberOfProbes:    ptr.To[int32](2),
+			},
+		})
+	}
+
+	lb.Properties.LoadBalancingRules = append(lb.Properties.LoadBalancingRules, &armnetwork.LoadBalancingRule{
+		ID:   ptr.To(ptr.Deref(lb.ID, "") + "/loadBalancingRules/" + apiRuleName),
+		Name: ptr.To(apiRuleName),
+		Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+			FrontendIPConfiguration: &armnetwork.SubResource{ID: frontend.ID},
+			BackendAddressPool:      &armnetwork.SubResource{ID: backendPool.ID},
+			Probe:                   &armnetwork.SubResource{ID: ptr.To(probeID)},
+			Protocol:                ptr.To(armnetwork.TransportProtocolTCP),
+			LoadDistribution:        ptr.To(armnetwork.LoadDistributionDefault),
+			FrontendPort:            ptr.To[int32](apiPort),
+			BackendPort:             ptr.To[int32](apiPort),
+			IdleTimeoutInMinutes:    ptr.To[int32](30),
+			EnableFloatingIP:        ptr.To(false),
+			// Outbound rules can't be used with a frontend which also SNATs through its load balancing rules
+			DisableOutboundSnat: ptr.To(len(lb.Properties.OutboundRules) > 0),
+		},
+	})
+	return nil
+}
+
+// getInternalAPIIPAddress returns the private IP address of the internal API load balancer
+func (az *Client) getInternalAPIIPAddress(ctx context.Context) (string, error) {
+	intLBName := az.clusterName + "-internal"
+	internalLB, err := az.lbClient.Get(ctx, az.resourceGroup, intLBName)
+	if err != nil {
+		return "", err
+	}
+	frontend, err := findFrontend(internalLB, internalFrontendName)
+	if err != nil {
+		return "", err
+	}
+	ipAddress := ""
+	if frontend.Properties != nil {
+		ipAddress = ptr.Deref(frontend.Properties.PrivateIPAddress, "")
+	}
+	if ipAddress == "" {
+		return "", fmt.Errorf("frontend %s of load balancer %s has no private IP address", internalFrontendName, intLBName)
+	}
+	return ipAddress, nil
+}
+
+// getPublicAPIRecord returns the record the installer creates for the API in
+// the public zone: a CNAME to the DNS name of the public IP address of the
+// frontend, or an A record when the address has no DNS name
+func (az *Client) getPublicAPIRecord(ctx context.Context, frontend *armnetwork.FrontendIPConfiguration) (*RecordSet, error) {
+	if frontend.Properties == nil || frontend.Properties.PublicIPAddress == nil {
+		return nil, fmt.Errorf("frontend %s has no public IP address", ptr.Deref(frontend.Name, ""))
+	}
+	resourceGroup, name, err := parseResourceID(ptr.Deref(frontend.Properties.PublicIPAddress.ID, ""))
+	if err != nil {
+		return nil, err
+	}
+	publicIP, err := az.publicIPClient.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return nil, err
+	}
+	properties := ptr.Deref(publicIP.Properties, armnetwork.PublicIPAddressPropertiesFormat{})
+	if properties.DNSSettings != nil && ptr.Deref(properties.DNSSettings.Fqdn, "") != "" {
+		return &RecordSet{TTL: dnsRecordTTL, CNAME: *properties.DNSSettings.Fqdn}, nil
+	}
+	if ptr.Deref(properties.IPAddress, "") == "" {
+		return nil, cioerrors.NewLoadBalancerNotReadyError()
+	}
+	return &RecordSet{TTL: dnsRecordTTL, ARecords: []string{*properties.IPAddress}}, nil
+}
+
+// getAPIRecord returns the A or CNAME record named name in the zone, and its type. It is nil if there is none.
+func (az *Client) getAPIRecord(ctx context.Context, zoneID, name string) (*RecordSet, string, error) {
+	for _, recordType := range []string{RecordTypeA, RecordTypeCNAME} {
+		rs, err := az.dnsClient.GetRecordSet(ctx, zoneID, recordType, name)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, "", err
+		}
+		return rs, recordType, nil
+	}
+	return nil, "", nil
+}
+
+// updateAPIRecord points fqdn in the public zone to the A or CNAME record.
+// An A and a CNAME record can't have the same name, the one of the other type
+// is deleted first.
+func (az *Client) updateAPIRecord(ctx context.Context, kclient k8s.Client, fqdn string, desired *RecordSet) error {
+	clusterDNS, err := getClusterDNS(kclient)
+	if err != nil {
+		return err
+	}
+	if clusterDNS.Spec.PublicZone == nil {
+		// Private clusters have no public record
+		return nil
+	}
+	zoneID := clusterDNS.Spec.PublicZone.ID
+	name, err := relativeRecordName(fqdn, zoneID)
+	if err != nil {
+		return err
+	}
+	current, currentType, err := az.getAPIRecord(ctx, zoneID, name)
+	if err != nil {
+		return err
+	}
+	desiredType := recordType(desired)
+	if current != nil && currentType == desiredType && sameRecord(current, desired) {
+		return nil
+	}
+	if current != nil && currentType != desiredType {
+		err = az.dnsClient.DeleteRecordSet(ctx, zoneID, currentType, name)
+		if err != nil && !isNotFound(err) {
+			cioevents.Warning(ctx, cioevents.ReasonDNSUpdateFailed, cioevents.ActionUpdate, "Couldn't delete the %s record for %s from Azure DNS zone %s: %v", currentType, fqdn, zoneID, err)
+			return err
+		}
+	}
+	log.Info("Submitting DNS changes:", "Zone", zoneID, "Name", name, "Type", desiredType, "Record", desired)
+	err = az.dnsClient.CreateOrUpdateRecordSet(ctx, zoneID, desiredType, name, desired)
+	if err != nil {
+		cioevents.Warning(ctx, cioevents.ReasonDNSUpdateFailed, cioevents.ActionUpdate, "Couldn't update the %s record for %s in Azure DNS zone %s: %v", desiredType, fqdn, zoneID, err)
+		return err
+	}
+	cioevents.Normal(ctx, cioevents.ReasonDNSRecordUpdated, cioevents.ActionUpdate, "Pointed %s to %s in Azure DNS zone %s", fqdn, recordTarget(desired), zoneID)
+	return nil
+}
+
+// planAPIRecord returns the change updateAPIRecord would make, nil if the record is already as desired
+func (az *Client) planAPIRecord(ctx context.Context, kclient k8s.Client, desired *RecordSet, targetDescription string) (*cloudingressv1alpha1.PlannedAction, error) {
+	clusterDNS, err := getClusterDNS(kclient)
+	if err != nil {
+		return nil, err
+	}
+	if clusterDNS.Spec.PublicZone == nil {
+		return nil, nil
+	}
+	fqdn := "api." + az.baseDomain
+	zoneID := clusterDNS.Spec.PublicZone.ID
+	name, err := relativeRecordName(fqdn, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	current, currentType, err := az.getAPIRecord(ctx, zoneID, name)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && currentType == recordType(desired) && sameRecord(current, desired) {
+		return nil, nil
+	}
+	description := fmt.Sprintf("Point to %s in Azure DNS zone %s", targetDescription, zoneNameFromID(zoneID))
+	if current != nil {
+		description = fmt.Sprintf("Point to %s instead of %s in Azure DNS zone %s", targetDescription, recordTarget(current), zoneNameFromID(zoneID))
+	}
+	return &cloudingressv1alpha1.PlannedAction{
+		Action:      cloudingressv1alpha1.PlannedActionUpdate,
+		Kind:        cloudingressv1alpha1.PlannedResourceDNSRecord,
+		Name:        fqdn,
+		Description: description,
+	}, nil
+}
+
+// ensureDNSForService points the A record of dnsName in the public and
+// private zones to the load balancer of the Service. When internal, the record
+// is only in the private zone.
+func (az *Client) ensureDNSForService(ctx context.Context, kclient k8s.Client, svc *corev1.Service, dnsName string, internal bool) error {
+	svcIPs, err := getIPAddressesFromService(svc)
+	if err != nil {
+		return err
+	}
+	FQDN := dnsName + "." + az.baseDomain
+	desired := &RecordSet{TTL: dnsRecordTTL, ARecords: svcIPs}
+
+	clusterDNS, err := getClusterDNS(kclient)
+	if err != nil {
+		return err
+	}
+	var zones []configv1.DNSZone
+	if clusterDNS.Spec.PublicZone != nil {
+		if internal {
+			// Left by an external load balancer the endpoint was moved from
+			if err := az.removeRecordInZone(ctx, clusterDNS.Spec.PublicZone.ID, FQDN); err != nil {
+				return err
+			}
+		} else {
+			zones = append(zones, *clusterDNS.Spec.PublicZone)
+		}
+	}
+	if clusterDNS.Spec.PrivateZone != nil {
+		zones = append(zones, *clusterDNS.Spec.PrivateZone)
+	}
+	for _, zone := range zones {
+		name, err := relativeRecordName(FQDN, zone.ID)
+		if err != nil {
+			return err
+		}
+		current, err := az.dnsClient.GetRecordSet(ctx, zone.ID, RecordTypeA, name)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		if current != nil && sameRecord(current, desired) {
+			continue
+		}
+		log.Info("Submitting DNS changes:", "Zone", zone.ID, "Name", name, "Record", desired)
+		err = az.dnsClient.CreateOrUpdateRecordSet(ctx, zone.ID, RecordTypeA, name, desired)
+		if err != nil {
+			return err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonDNSRecordUpdated, cioevents.ActionUpdate, "Pointed %s to %s in Azure DNS zone %s", FQDN, strings.Join(svcIPs, ","), zoneNameFromID(zone.ID))
+	}
+	return nil
+}
+
+// removeDNSForName removes the A record for dnsName from the public and private zones
+func (az *Client) removeDNSForName(ctx context.Context, kclient k8s.Client, dnsName string) error {
+	FQDN := dnsName + "." + az.baseDomain
+
+	zones, err := getClusterDNSZones(kclient)
+	if err != nil {
+		return err
+	}
+	for _, zone := range zones {
+		if err := az.removeRecordInZone(ctx, zone.ID, FQDN); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeRecordInZone removes the A record for FQDN from the zone, if it exists
+func (az *Client) removeRecordInZone(ctx context.Context, zoneID, FQDN string) error {
+	name, err := relativeRecordName(FQDN, zoneID)
+	if err != nil {
+		return err
+	}
+	_, err = az.dnsClient.GetRecordSet(ctx, zoneID, RecordTypeA, name)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	log.Info("Deleting DNS record:", "Zone", zoneID, "Name", name)
+	err = az.dnsClient.DeleteRecordSet(ctx, zoneID, RecordTypeA, name)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	cioevents.Normal(ctx, cioevents.ReasonDNSRecordDeleted, cioevents.ActionDelete, "Deleted the A record for %s from Azure DNS zone %s", FQDN, zoneNameFromID(zoneID))
+	return nil
+}
+
+// getIPAddressesFromService returns the IPv4 addresses of the Service
+// LoadBalancer, a dual-stack one has IPv6 addresses too which an A record can't
+// hold
+func getIPAddressesFromService(svc *corev1.Service) ([]string, error) {
+	var ips []string
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ip := net.ParseIP(ingress.IP); ip != nil && ip.To4() != nil {
+			ips = append(ips, ingress.IP)
+		}
+	}
+
+	if len(ips) == 0 {
+		return nil, cioerrors.NewLoadBalancerNotReadyError()
+	}
+
+	return ips, nil
+}
+
+func recordType(rs *RecordSet) string {
+	if rs.CNAME != "" {
+		return RecordTypeCNAME
+	}
+	return RecordTypeA
+}
+
+func recordTarget(rs *RecordSet) string {
+	if rs.CNAME != "" {
+		return rs.CNAME
+	}
+	return strings.Join(rs.ARecords, ",")
+}
+
+// sameRecord compares the targets of two record sets of the same type. The
+// TTL and the order of the A records don't matter.
+func sameRecord(a, b *RecordSet) bool {
+	aRecords := append([]string{}, a.ARecords...)
+	bRecords := append([]string{}, b.ARecords...)
+	sort.Strings(aRecords)
+	sort.Strings(bRecords)
+	return a.CNAME == b.CNAME && reflect.DeepEqual(aRecords, bRecords)
+}
+
+func getClusterDNS(kclient k8s.Client) (*configv1.DNS, error) {
+	dns := &configv1.DNS{}
+	err := kclient.Get(context.TODO(), types.NamespacedName{Name: "cluster"}, dns)
+	if err != nil {
+		return nil, err
+	}
+	return dns, nil
+}
+
+// getClusterDNSZones returns the public zone, if any, and the private zone of the cluster
+func getClusterDNSZones(kclient k8s.Client) ([]configv1.DNSZone, error) {
+	clusterDNS, err := getClusterDNS(kclient)
+	if err != nil {
+		return nil, err
+	}
+	var zones []configv1.DNSZone
+	if clusterDNS.Spec.PublicZone != nil {
+		zones = append(zones, *clusterDNS.Spec.PublicZone)
+	}
+	if clusterDNS.Spec.PrivateZone != nil {
+		zones = append(zones, *clusterDNS.Spec.PrivateZone)
+	}
+	return zones, nil
+}
+
+// zoneNameFromID returns the name of the zone from its resource ID, eg
+// /subscriptions/<id>/resourceGroups/<rg>/providers/Microsoft.Network/dnszones/example.com
+func zoneNameFromID(zoneID string) string {
+	return zoneID[strings.LastIndex(zoneID, "/")+1:]
+}
+
+// relativeRecordName returns the name of fqdn relative to the zone, as used by the Azure DNS APIs
+func relativeRecordName(fqdn string, zoneID string) (string, error) {
+	fqdn = strings.TrimSuffix(fqdn, ".")
+	zoneName := zoneNameFromID(zoneID)
+	if fqdn == zoneName {
+		return "@", nil
+	}
+	if !strings.HasSuffix(fqdn, "."+zoneName) {
+		return "", fmt.Errorf("%s is not in the DNS zone %s", fqdn, zoneName)
+	}
+	return strings.TrimSuffix(fqdn, "."+zoneName), nil
+}
+
+// parseResourceID returns the resource group and the name of an Azure resource from its ID, eg
+// /subscriptions/<id>/resourceGroups/<rg>/providers/Microsoft.Network/publicIPAddresses/<name>
+func parseResourceID(id string) (string, string, error) {
+	resourceID, err := arm.ParseResourceID(id)
+	if err != nil || resourceID.ResourceGroupName == "" {
+		return "", "", fmt.Errorf("couldn't parse the Azure resource ID %s", id)
+	}
+	return resourceID.ResourceGroupName, resourceID.Name, nil
+}
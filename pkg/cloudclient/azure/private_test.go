@@ -0,0 +1,389 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	configv1 "github.com/openshift/api/config/v1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testPublicZoneID  = "/subscriptions/sub/resourceGroups/dns-rg/providers/Microsoft.Network/dnszones/example.com"
+	testPrivateZoneID = "/subscriptions/sub/resourceGroups/sut-rg/providers/Microsoft.Network/privateDnsZones/sut.example.com"
+	testLBID          = "/subscriptions/sub/resourceGroups/sut-rg/providers/Microsoft.Network/loadBalancers/sut"
+	testPublicIPID    = "/subscriptions/sub/resourceGroups/sut-rg/providers/Microsoft.Network/publicIPAddresses/sut-pip-v4"
+	testPublicFQDN    = "sut.eastus.cloudapp.azure.com"
+)
+
+// fakeDNS implements DNSAPI
+type fakeDNS struct {
+	records map[string]*RecordSet
+}
+
+func dnsKey(zoneID, recordType, name string) string {
+	return zoneID + "/" + recordType + "/" + name
+}
+
+func (f *fakeDNS) GetRecordSet(ctx context.Context, zoneID, recordType, name string) (*RecordSet, error) {
+	rs, ok := f.records[dnsKey(zoneID, recordType, name)]
+	if !ok {
+		return nil, &azcore.ResponseError{StatusCode: http.StatusNotFound, ErrorCode: "NotFound"}
+	}
+	copied := *rs
+	return &copied, nil
+}
+
+func (f *fakeDNS) CreateOrUpdateRecordSet(ctx context.Context, zoneID, recordType, name string, recordSet *RecordSet) error {
+	copied := *recordSet
+	f.records[dnsKey(zoneID, recordType, name)] = &copied
+	return nil
+}
+
+func (f *fakeDNS) DeleteRecordSet(ctx context.Context, zoneID, recordType, name string) error {
+	delete(f.records, dnsKey(zoneID, recordType, name))
+	return nil
+}
+
+// fakeLoadBalancers implements LoadBalancersAPI
+type fakeLoadBalancers struct {
+	lbs     map[string]*armnetwork.LoadBalancer
+	updates int
+}
+
+func copyLoadBalancer(lb *armnetwork.LoadBalancer) *armnetwork.LoadBalancer {
+	b, _ := json.Marshal(lb)
+	copied := &armnetwork.LoadBalancer{}
+	_ = json.Unmarshal(b, copied)
+	return copied
+}
+
+func (f *fakeLoadBalancers) Get(ctx context.Context, resourceGroup, name string) (*armnetwork.LoadBalancer, error) {
+	lb, ok := f.lbs[resourceGroup+"/"+name]
+	if !ok {
+		return nil, &azcore.ResponseError{StatusCode: http.StatusNotFound, ErrorCode: "NotFound"}
+	}
+	return copyLoadBalancer(lb), nil
+}
+
+func (f *fakeLoadBalancers) CreateOrUpdate(ctx context.Context, resourceGroup, name string, lb *armnetwork.LoadBalancer) error {
+	f.lbs[resourceGroup+"/"+name] = copyLoadBalancer(lb)
+	f.updates++
+	return nil
+}
+
+// fakePublicIPAddresses implements PublicIPAddressesAPI
+type fakePublicIPAddresses struct {
+	ips map[string]*armnetwork.PublicIPAddress
+}
+
+func (f *fakePublicIPAddresses) Get(ctx context.Context, resourceGroup, name string) (*armnetwork.PublicIPAddress, error) {
+	ip, ok := f.ips[resourceGroup+"/"+name]
+	if !ok {
+		return nil, &azcore.ResponseError{StatusCode: http.StatusNotFound, ErrorCode: "NotFound"}
+	}
+	return ip, nil
+}
+
+// testCluster is an installer provisioned cluster with a public API
+type testCluster struct {
+	client *Client
+	dns    *fakeDNS
+	lbs    *fakeLoadBalancers
+	ips    *fakePublicIPAddresses
+}
+
+func newTestCluster() *testCluster {
+	publicLB := &armnetwork.LoadBalancer{
+		ID:   ptr.To(testLBID),
+		Name: ptr.To("sut"),
+		Properties: &armnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{{
+				ID:   ptr.To(testLBID + "/frontendIPConfigurations/public-lb-ip-v4"),
+				Name: ptr.To("public-lb-ip-v4"),
+				Properties: &armnetwork.FrontendIPConfigurationPropertiesFormat{
+					PublicIPAddress: &armnetwork.PublicIPAddress{ID: ptr.To(testPublicIPID)},
+				},
+			}},
+			BackendAddressPools: []*armnetwork.BackendAddressPool{{ID: ptr.To(testLBID + "/backendAddressPools/sut"), Name: ptr.To("sut")}},
+			Probes: []*armnetwork.Probe{{
+				ID:   ptr.To(testLBID + "/probes/api-probe"),
+				Name: ptr.To("api-probe"),
+				Properties: &armnetwork.ProbePropertiesFormat{
+					Protocol:    ptr.To(armnetwork.ProbeProtocolHTTPS),
+					Port:        ptr.To[int32](6443),
+					RequestPath: ptr.To("/readyz"),
+				},
+			}},
+			LoadBalancingRules: []*armnetwork.LoadBalancingRule{{
+				ID:   ptr.To(testLBID + "/loadBalancingRules/api-v4"),
+				Name: ptr.To("api-v4"),
+				Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+					FrontendIPConfiguration: &armnetwork.SubResource{ID: ptr.To(testLBID + "/frontendIPConfigurations/public-lb-ip-v4")},
+					BackendAddressPool:      &armnetwork.SubResource{ID: ptr.To(testLBID + "/backendAddressPools/sut")},
+					Probe:                   &armnetwork.SubResource{ID: ptr.To(testLBID + "/probes/api-probe")},
+					Protocol:                ptr.To(armnetwork.TransportProtocolTCP),
+					FrontendPort:            ptr.To[int32](6443),
+					BackendPort:             ptr.To[int32](6443),
+				},
+			}},
+			OutboundRules: []*armnetwork.OutboundRule{{Name: ptr.To("outbound-rule-v4")}},
+		},
+	}
+	internalLB := &armnetwork.LoadBalancer{
+		Name: ptr.To("sut-internal"),
+		Properties: &armnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{{
+				Name:       ptr.To("internal-lb-ip-v4"),
+				Properties: &armnetwork.FrontendIPConfigurationPropertiesFormat{PrivateIPAddress: ptr.To("10.0.0.4")},
+			}},
+		},
+	}
+	c := &testCluster{
+		dns: &fakeDNS{records: map[string]*RecordSet{
+			dnsKey(testPublicZoneID, RecordTypeCNAME, "api.sut"): {TTL: 300, CNAME: testPublicFQDN},
+			dnsKey(testPrivateZoneID, RecordTypeA, "api"):        {TTL: 60, ARecords: []string{"10.0.0.4"}},
+		}},
+		lbs: &fakeLoadBalancers{lbs: map[string]*armnetwork.LoadBalancer{
+			"sut-rg/sut":          publicLB,
+			"sut-rg/sut-internal": internalLB,
+		}},
+		ips: &fakePublicIPAddresses{ips: map[string]*armnetwork.PublicIPAddress{
+			"sut-rg/sut-pip-v4": {
+				Name: ptr.To("sut-pip-v4"),
+				Properties: &armnetwork.PublicIPAddressPropertiesFormat{
+					IPAddress:   ptr.To("20.0.0.1"),
+					DNSSettings: &armnetwork.PublicIPAddressDNSSettings{DomainNameLabel: ptr.To("sut"), Fqdn: ptr.To(testPublicFQDN)},
+				},
+			},
+		}},
+	}
+	c.client = &Client{
+		resourceGroup:  "sut-rg",
+		clusterName:    "sut",
+		baseDomain:     "sut.example.com",
+		dnsClient:      c.dns,
+		lbClient:       c.lbs,
+		publicIPClient: c.ips,
+	}
+	return c
+}
+
+func clusterDNS(publicZone bool) *configv1.DNS {
+	dns := &configv1.DNS{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: configv1.DNSSpec{
+			BaseDomain:  "sut.example.com",
+			PrivateZone: &configv1.DNSZone{ID: testPrivateZoneID},
+		},
+	}
+	if publicZone {
+		dns.Spec.PublicZone = &configv1.DNSZone{ID: testPublicZoneID}
+	}
+	return dns
+}
+
+func TestSetDefaultAPIPrivateAndPublic(t *testing.T) {
+	mocks := testutils.NewTestMock(t, []runtime.Object{clusterDNS(true)})
+	c := newTestCluster()
+	ctx := context.TODO()
+
+	listening, err := c.client.getDefaultAPIListening(ctx, mocks.FakeKubeClient)
+	if err != nil || listening != cloudingressv1alpha1.External {
+		t.Fatalf("Expected the API to be external, got %v (%v)", listening, err)
+	}
+
+	err = c.client.setDefaultAPIPrivate(ctx, mocks.FakeKubeClient, &cloudingressv1alpha1.PublishingStrategy{})
+	if err != nil {
+		t.Fatalf("Couldn't set the API private: %v", err)
+	}
+	publicLB := c.lbs.lbs["sut-rg/sut"]
+	if len(publicLB.Properties.LoadBalancingRules) != 0 {
+		t.Errorf("Expected the API rule to be removed, got %+v", publicLB.Properties.LoadBalancingRules)
+	}
+	if len(publicLB.Properties.FrontendIPConfigurations) != 1 || len(publicLB.Properties.Probes) != 1 {
+		t.Errorf("Expected the frontend and the probe to be kept, got %+v", publicLB.Properties)
+	}
+	if _, ok := c.dns.records[dnsKey(testPublicZoneID, RecordTypeCNAME, "api.sut")]; ok {
+		t.Errorf("Expected the CNAME record to be deleted")
+	}
+	if rs := c.dns.records[dnsKey(testPublicZoneID, RecordTypeA, "api.sut")]; rs == nil || !reflect.DeepEqual(rs.ARecords, []string{"10.0.0.4"}) {
+		t.Errorf("Expected api.sut to point to the internal load balancer, got %+v", rs)
+	}
+	listening, err = c.client.getDefaultAPIListening(ctx, mocks.FakeKubeClient)
+	if err != nil || listening != cloudingressv1alpha1.Internal {
+		t.Fatalf("Expected the API to be internal, got %v (%v)", listening, err)
+	}
+
+	// Nothing left to do
+	updates := c.lbs.updates
+	err = c.client.setDefaultAPIPrivate(ctx, mocks.FakeKubeClient, &cloudingressv1alpha1.PublishingStrategy{})
+	if err != nil || c.lbs.updates != updates {
+		t.Errorf("Expected setDefaultAPIPrivate to be idempotent, got %d updates (%v)", c.lbs.updates-updates, err)
+	}
+
+	err = c.client.setDefaultAPIPublic(ctx, mocks.FakeKubeClient, &cloudingressv1alpha1.PublishingStrategy{})
+	if err != nil {
+		t.Fatalf("Couldn't set the API public: %v", err)
+	}
+	publicLB = c.lbs.lbs["sut-rg/sut"]
+	if len(publicLB.Properties.LoadBalancingRules) != 1 {
+		t.Fatalf("Expected the API rule to be restored, got %+v", publicLB.Properties.LoadBalancingRules)
+	}
+	rule := publicLB.Properties.LoadBalancingRules[0].Properties
+	if *rule.FrontendPort != 6443 || *rule.Probe.ID != testLBID+"/probes/api-probe" || *rule.BackendAddressPool.ID != testLBID+"/backendAddressPools/sut" || !*rule.DisableOutboundSnat {
+		t.Errorf("Unexpected API rule %+v", rule)
+	}
+	if len(publicLB.Properties.Probes) != 1 {
+		t.Errorf("Expected the existing probe to be reused, got %+v", publicLB.Properties.Probes)
+	}
+	if _, ok := c.dns.records[dnsKey(testPublicZoneID, RecordTypeA, "api.sut")]; ok {
+		t.Errorf("Expected the A record to be deleted")
+	}
+	if rs := c.dns.records[dnsKey(testPublicZoneID, RecordTypeCNAME, "api.sut")]; rs == nil || rs.CNAME != testPublicFQDN {
+		t.Errorf("Expected api.sut to point to the public IP address, got %+v", rs)
+	}
+	if rs := c.dns.records[dnsKey(testPrivateZoneID, RecordTypeA, "api")]; rs == nil || rs.TTL != 60 {
+		t.Errorf("Expected the private zone to be left alone, got %+v", rs)
+	}
+}
+
+func TestSetDefaultAPIPublicWithoutProbe(t *testing.T) {
+	mocks := testutils.NewTestMock(t, []runtime.Object{clusterDNS(false)})
+	c := newTestCluster()
+	publicLB := c.lbs.lbs["sut-rg/sut"]
+	publicLB.Properties.LoadBalancingRules = nil
+	publicLB.Properties.Probes = nil
+	publicLB.Properties.OutboundRules = nil
+
+	err := c.client.setDefaultAPIPublic(context.TODO(), mocks.FakeKubeClient, &cloudingressv1alpha1.PublishingStrategy{})
+	if err != nil {
+		t.Fatalf("Couldn't set the API public: %v", err)
+	}
+	publicLB = c.lbs.lbs["sut-rg/sut"]
+	if len(publicLB.Properties.Probes) != 1 || *publicLB.Properties.Probes[0].Properties.RequestPath != "/readyz" {
+		t.Errorf("Expected the API probe to be created, got %+v", publicLB.Properties.Probes)
+	}
+	if rule := publicLB.Properties.LoadBalancingRules[0].Properties; *rule.Probe.ID != testLBID+"/probes/api-probe" || *rule.DisableOutboundSnat {
+		t.Errorf("Unexpected API rule %+v", rule)
+	}
+	// There's no public zone to update
+	if rs := c.dns.records[dnsKey(testPublicZoneID, RecordTypeCNAME, "api.sut")]; rs == nil || rs.TTL != 300 {
+		t.Errorf("Expected the public zone to be left alone, got %+v", rs)
+	}
+}
+
+func TestPlanDefaultAPIPrivate(t *testing.T) {
+	mocks := testutils.NewTestMock(t, []runtime.Object{clusterDNS(true)})
+	c := newTestCluster()
+
+	actions, err := c.client.planDefaultAPIPrivate(context.TODO(), mocks.FakeKubeClient, &cloudingressv1alpha1.PublishingStrategy{})
+	if err != nil {
+		t.Fatalf("Couldn't plan: %v", err)
+	}
+	expected := []cloudingressv1alpha1.PlannedAction{
+		{
+			Action:      cloudingressv1alpha1.PlannedActionUpdate,
+			Kind:        cloudingressv1alpha1.PlannedResourceLoadBalancer,
+			Name:        "sut",
+			Description: "Remove the rule api-v4 for TCP port 6443 from the public load balancer",
+		},
+		{
+			Action:      cloudingressv1alpha1.PlannedActionUpdate,
+			Kind:        cloudingressv1alpha1.PlannedResourceDNSRecord,
+			Name:        "api.sut.example.com",
+			Description: "Point to the internal load balancer sut-internal (10.0.0.4) instead of " + testPublicFQDN + " in Azure DNS zone example.com",
+		},
+	}
+	if !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, actions)
+	}
+	if c.lbs.updates != 0 || len(c.dns.records) != 2 {
+		t.Errorf("Planning must not change anything")
+	}
+
+	// Nothing is planned for the current scope
+	actions, err = c.client.planDefaultAPIPublic(context.TODO(), mocks.FakeKubeClient, &cloudingressv1alpha1.PublishingStrategy{})
+	if err != nil || len(actions) != 0 {
+		t.Errorf("Expected no actions, got %+v (%v)", actions, err)
+	}
+}
+
+func TestEnsureAndRemoveAdminAPIDNS(t *testing.T) {
+	mocks := testutils.NewTestMock(t, []runtime.Object{clusterDNS(true)})
+	c := newTestCluster()
+	instance := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	svc := &corev1.Service{}
+
+	err := c.client.ensureAdminAPIDNS(context.TODO(), mocks.FakeKubeClient, instance, svc)
+	var notReady *cioerrors.LoadBalancerNotReadyError
+	if !errors.As(err, &notReady) {
+		t.Fatalf("Expected LoadBalancerNotReadyError, got %v", err)
+	}
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "20.0.0.2"}}
+	err = c.client.ensureAdminAPIDNS(context.TODO(), mocks.FakeKubeClient, instance, svc)
+	if err != nil {
+		t.Fatalf("Couldn't ensure the admin API DNS: %v", err)
+	}
+	for _, key := range []string{dnsKey(testPublicZoneID, RecordTypeA, "rh-api.sut"), dnsKey(testPrivateZoneID, RecordTypeA, "rh-api")} {
+		if rs := c.dns.records[key]; rs == nil || !reflect.DeepEqual(rs.ARecords, []string{"20.0.0.2"}) {
+			t.Errorf("Expected %s to point to the Service, got %+v", key, rs)
+		}
+	}
+
+	err = c.client.deleteAdminAPIDNSWithoutService(context.TODO(), mocks.FakeKubeClient, instance)
+	if err != nil {
+		t.Fatalf("Couldn't delete the admin API DNS: %v", err)
+	}
+	if len(c.dns.records) != 2 {
+		t.Errorf("Expected only the api records to be left, got %v", c.dns.records)
+	}
+	// Already gone
+	err = c.client.deleteAdminAPIDNS(context.TODO(), mocks.FakeKubeClient, instance, svc)
+	if err != nil {
+		t.Errorf("Expected no error when the records are already gone, got %v", err)
+	}
+}
+
+func TestRelativeRecordName(t *testing.T) {
+	tests := []struct {
+		fqdn      string
+		zoneID    string
+		expected  string
+		expectErr bool
+	}{
+		{fqdn: "api.sut.example.com", zoneID: testPublicZoneID, expected: "api.sut"},
+		{fqdn: "api.sut.example.com.", zoneID: testPrivateZoneID, expected: "api"},
+		{fqdn: "example.com", zoneID: testPublicZoneID, expected: "@"},
+		{fqdn: "api.sut.example.org", zoneID: testPublicZoneID, expectErr: true},
+	}
+	for _, test := range tests {
+		name, err := relativeRecordName(test.fqdn, test.zoneID)
+		if (err != nil) != test.expectErr || name != test.expected {
+			t.Errorf("Test [%v] FAILED: expected %q, got %q (%v)", test.fqdn, test.expected, name, err)
+		}
+	}
+}
+
+func TestParseResourceID(t *testing.T) {
+	resourceGroup, name, err := parseResourceID(testPublicIPID)
+	if err != nil || resourceGroup != "sut-rg" || name != "sut-pip-v4" {
+		t.Errorf("Unexpected %s %s (%v)", resourceGroup, name, err)
+	}
+	if _, _, err := parseResourceID("sut-pip-v4"); err == nil {
+		t.Errorf("Expected an error for a bare name")
+	}
+}
@@ -0,0 +1,89 @@
+package cloudclient
+
+import (
+	"context"
+	"sync"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	log = logf.Log.WithName("cloudclient")
+
+	credentialsSecrets = map[configv1.PlatformType]func() string{}
+	clients            = &clientCache{entries: map[configv1.PlatformType]cachedClient{}}
+)
+
+// cachedClient is a CloudClient along with the version of the credentials it
+// was created from
+type cachedClient struct {
+	client          CloudClient
+	secretName      string
+	resourceVersion string
+}
+
+// clientCache keeps one CloudClient per cloud provider, shared by the
+// controllers and the health check. The credentials Secret is read with the
+// manager's client, which serves it from a watch, so comparing its
+// resourceVersion is cheap and notices rotated credentials right away.
+type clientCache struct {
+	mu      sync.Mutex
+	entries map[configv1.PlatformType]cachedClient
+}
+
+// get returns the cached client of the cloud provider, or creates it with the
+// factory when there's none yet or the credentials Secret changed since
+func (c *clientCache) get(kclient client.Client, cloudID configv1.PlatformType, factory Factory) (CloudClient, error) {
+	secretName, resourceVersion, err := credentialsVersion(kclient, cloudID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[cloudID]; ok && entry.secretName == secretName && entry.resourceVersion == resourceVersion {
+		return entry.client, nil
+	}
+	// Holding the lock means concurrent reconciles wait for one client to be created
+	delete(c.entries, cloudID)
+	cli, err := factory(kclient)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Created a cloud client", "platform", cloudID, "secret", secretName, "resourceVersion", resourceVersion)
+	c.entries[cloudID] = cachedClient{client: cli, secretName: secretName, resourceVersion: resourceVersion}
+	return cli, nil
+}
+
+// drop forgets the cached client of the cloud provider
+func (c *clientCache) drop(cloudID configv1.PlatformType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, cloudID)
+}
+
+// credentialsVersion returns the name and the resourceVersion of the
+// credentials Secret of the cloud provider. A missing Secret has an empty
+// resourceVersion and is left to the factory to report.
+func credentialsVersion(kclient client.Client, cloudID configv1.PlatformType) (string, string, error) {
+	secretNameFunc, ok := credentialsSecrets[cloudID]
+	if !ok {
+		return "", "", nil
+	}
+	secretName := secretNameFunc()
+	secret := &corev1.Secret{}
+	err := kclient.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: config.OperatorNamespace}, secret)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return secretName, "", nil
+		}
+		return "", "", err
+	}
+	return secretName, secret.ResourceVersion, nil
+}
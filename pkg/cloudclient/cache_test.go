@@ -0,0 +1,92 @@
+package cloudclient
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/mock_cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testPlatform configv1.PlatformType = "UnitTest"
+
+// registerTestPlatform registers a factory counting the clients it creates
+func registerTestPlatform(t *testing.T, mocks *testutils.Mocks) *int {
+	created := 0
+	Register(testPlatform, func(kclient client.Client) (CloudClient, error) {
+		created++
+		return mock_cloudclient.NewMockCloudClient(mocks.MockCtrl), nil
+	})
+	RegisterCredentialsSecret(testPlatform, func() string { return "unit-test-credentials" })
+	t.Cleanup(func() {
+		delete(controllerMapping, testPlatform)
+		delete(credentialsSecrets, testPlatform)
+		clients.drop(testPlatform)
+	})
+	return &created
+}
+
+func TestGetClientForCachesUntilCredentialsChange(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "unit-test-credentials", Namespace: config.OperatorNamespace},
+		Data:       map[string][]byte{"key": []byte("old")},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{secret})
+	created := registerTestPlatform(t, mocks)
+
+	first, err := GetClientFor(mocks.FakeKubeClient, testPlatform)
+	if err != nil {
+		t.Fatalf("Couldn't get a client: %v", err)
+	}
+	second, err := GetClientFor(mocks.FakeKubeClient, testPlatform)
+	if err != nil {
+		t.Fatalf("Couldn't get a client: %v", err)
+	}
+	if first != second || *created != 1 {
+		t.Fatalf("Expected the client to be reused, %d were created", *created)
+	}
+
+	// Rotate the credentials
+	if err := mocks.FakeKubeClient.Get(context.TODO(), client.ObjectKeyFromObject(secret), secret); err != nil {
+		t.Fatalf("Couldn't get the Secret: %v", err)
+	}
+	secret.Data["key"] = []byte("new")
+	if err := mocks.FakeKubeClient.Update(context.TODO(), secret); err != nil {
+		t.Fatalf("Couldn't update the Secret: %v", err)
+	}
+	third, err := GetClientFor(mocks.FakeKubeClient, testPlatform)
+	if err != nil {
+		t.Fatalf("Couldn't get a client: %v", err)
+	}
+	if third == first || *created != 2 {
+		t.Fatalf("Expected a new client once the credentials changed, %d were created", *created)
+	}
+}
+
+func TestGetClientForDeletedCredentials(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "unit-test-credentials", Namespace: config.OperatorNamespace},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{secret})
+	created := registerTestPlatform(t, mocks)
+
+	if _, err := GetClientFor(mocks.FakeKubeClient, testPlatform); err != nil {
+		t.Fatalf("Couldn't get a client: %v", err)
+	}
+	if err := mocks.FakeKubeClient.Delete(context.TODO(), secret); err != nil {
+		t.Fatalf("Couldn't delete the Secret: %v", err)
+	}
+	// The factory is asked again, and reports the missing credentials itself
+	if _, err := GetClientFor(mocks.FakeKubeClient, testPlatform); err != nil {
+		t.Fatalf("Couldn't get a client: %v", err)
+	}
+	if *created != 2 {
+		t.Fatalf("Expected a new client once the credentials were deleted, %d were created", *created)
+	}
+}
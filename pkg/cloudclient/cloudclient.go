@@ -2,11 +2,11 @@ package cloudclient
 
 import (
 	"context"
-	"fmt"
 
 	configv1 "github.com/openshift/api/config/v1"
 
 	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -23,6 +23,14 @@ type CloudClient interface {
 	// DeleteAdminAPIDNS will ensure that the A record for the admin API (rh-api) is removed
 	DeleteAdminAPIDNS(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
 
+	// DeleteAdminAPIDNSWithoutService removes the admin API (rh-api) records when the Service,
+	// and so its load balancer, is already gone. The records are looked up by name in the cluster's zones.
+	DeleteAdminAPIDNSWithoutService(context.Context, client.Client, *cloudingressv1alpha1.APIScheme) error
+
+	// CheckAdminAPIDNS compares the admin API (rh-api) records in the cluster's zones with the Service's
+	// load balancer and returns those which have drifted. They are set back when repair is true.
+	CheckAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error)
+
 	/* Publishing Strategy */
 	// SetDefaultAPIPrivate ensures that the default API is private, per user configure
 	SetDefaultAPIPrivate(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) error
@@ -30,25 +38,72 @@ type CloudClient interface {
 	// SetDefaultAPIPublic ensures that the default API is public, per user configure
 	SetDefaultAPIPublic(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) error
 
+	// PlanDefaultAPIPrivate returns the changes SetDefaultAPIPrivate would make, without making them
+	PlanDefaultAPIPrivate(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error)
+
+	// PlanDefaultAPIPublic returns the changes SetDefaultAPIPublic would make, without making them
+	PlanDefaultAPIPublic(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error)
+
+	// ReactivateControlPlaneMachineSet advances the reactivation of the ControlPlaneMachineSet recorded in the
+	// PublishingStrategy status by SetDefaultAPIPrivate, the progress is recorded in the status
+	ReactivateControlPlaneMachineSet(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) error
+
+	// EnsureControlPlaneLoadBalancers makes the load balancers of the control plane Machines and of the
+	// ControlPlaneMachineSet match the default API scope, so replaced masters are attached to the right ones
+	EnsureControlPlaneLoadBalancers(context.Context, client.Client, cloudingressv1alpha1.Listening) error
+
+	// GetDefaultAPIListening returns the scope of the default API as currently configured on the cloud provider
+	GetDefaultAPIListening(context.Context, client.Client) (cloudingressv1alpha1.Listening, error)
+
+	// CheckDefaultAPIDNS compares the default API (api) records in the cluster's zones with the load balancers
+	// of the given scope and returns those which have drifted. They are set back when repair is true.
+	CheckDefaultAPIDNS(ctx context.Context, kclient client.Client, listening cloudingressv1alpha1.Listening, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error)
+
+	/* Orphaned resources */
+	// FindOrphanedResources returns the cloud resources of the cluster, created by the operator or for a Service,
+	// which nothing uses anymore given the default API scope and the Services and APISchemes of the cluster
+	FindOrphanedResources(ctx context.Context, kclient client.Client, listening cloudingressv1alpha1.Listening) ([]cloudingressv1alpha1.OrphanedResource, error)
+
+	// DeleteOrphanedResource deletes a resource returned by FindOrphanedResources
+	DeleteOrphanedResource(ctx context.Context, kclient client.Client, resource cloudingressv1alpha1.OrphanedResource) error
+
 	// Perform healthcheck
 	Healthcheck(context.Context, client.Client) error
 }
 
 var controllerMapping = map[configv1.PlatformType]Factory{}
 
-type Factory func(client.Client) CloudClient
+// Factory creates the CloudClient of a cloud provider
+type Factory func(client.Client) (CloudClient, error)
 
+// Register sets the Factory of a cloud provider. A client cached for the
+// provider is dropped, so the next GetClientFor uses the new Factory.
 func Register(name configv1.PlatformType, factoryFunc Factory) {
 	controllerMapping[name] = factoryFunc
+	clients.drop(name)
+}
+
+// RegisterCredentialsSecret sets the function returning the name of the Secret
+// the cloud provider's client is created from. The cached client is rebuilt
+// whenever this Secret changes.
+func RegisterCredentialsSecret(name configv1.PlatformType, secretName func() string) {
+	credentialsSecrets[name] = secretName
 }
 
 // GetClientFor returns the CloudClient for the given cloud provider, identified
 // by the provider's ID, eg aws for AWS's cloud client, gcp for GCP's cloud
-// client.
-func GetClientFor(kclient client.Client, cloudID configv1.PlatformType) CloudClient {
-	if _, ok := controllerMapping[cloudID]; ok {
-		return controllerMapping[cloudID](kclient)
+// client. Clients are cached, and only created again once the credentials
+// Secret of the provider changes. A CloudClientError is returned when the
+// client couldn't be created. For a provider without a client, the returned
+// client doesn't do anything and comes with an UnsupportedPlatformError.
+func GetClientFor(kclient client.Client, cloudID configv1.PlatformType) (CloudClient, error) {
+	factory, ok := controllerMapping[cloudID]
+	if !ok {
+		return &unsupportedClient{platform: cloudID}, cioerrors.NewUnsupportedPlatformError(string(cloudID))
+	}
+	cli, err := clients.get(kclient, cloudID, factory)
+	if err != nil {
+		return nil, cioerrors.NewCloudClientError(string(cloudID), err)
 	}
-	// TODO: Return a minimal interface?
-	panic(fmt.Sprintf("Couldn't find a client matching %s", cloudID))
+	return cli, nil
 }
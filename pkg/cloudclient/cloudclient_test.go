@@ -0,0 +1,47 @@
+package cloudclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClientForUnsupportedPlatform(t *testing.T) {
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	cli, err := GetClientFor(mocks.FakeKubeClient, configv1.PlatformType("Unknown"))
+
+	var unsupported *cioerrors.UnsupportedPlatformError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("Expected an UnsupportedPlatformError, got %v", err)
+	}
+	if cli == nil {
+		t.Fatal("Expected a client which doesn't do anything")
+	}
+	if err := cli.Healthcheck(context.TODO(), mocks.FakeKubeClient); err != nil {
+		t.Errorf("Expected the health check to pass, got %v", err)
+	}
+	if err := cli.SetDefaultAPIPrivate(context.TODO(), mocks.FakeKubeClient, nil); !errors.As(err, &unsupported) {
+		t.Errorf("Expected an UnsupportedPlatformError, got %v", err)
+	}
+	if _, err := cli.GetDefaultAPIListening(context.TODO(), mocks.FakeKubeClient); !errors.As(err, &unsupported) {
+		t.Errorf("Expected an UnsupportedPlatformError, got %v", err)
+	}
+}
+
+func TestGetClientForMissingCredentials(t *testing.T) {
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	cli, err := GetClientFor(mocks.FakeKubeClient, configv1.GCPPlatformType)
+
+	var clientErr *cioerrors.CloudClientError
+	if !errors.As(err, &clientErr) {
+		t.Fatalf("Expected a CloudClientError, got %v", err)
+	}
+	if cli != nil {
+		t.Errorf("Expected no client, got %v", cli)
+	}
+}
@@ -2,8 +2,11 @@ package gcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	computev1 "google.golang.org/api/compute/v1"
 	dnsv1 "google.golang.org/api/dns/v1"
@@ -13,6 +16,7 @@ import (
 	machineapi "github.com/openshift/api/machine/v1beta1"
 	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
 	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -49,6 +53,16 @@ func (gc *Client) DeleteAdminAPIDNS(ctx context.Context, kclient k8s.Client, ins
 	return gc.deleteAdminAPIDNS(ctx, kclient, instance, svc)
 }
 
+// DeleteAdminAPIDNSWithoutService implements cloudclient.CloudClient
+func (gc *Client) DeleteAdminAPIDNSWithoutService(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return gc.deleteAdminAPIDNSWithoutService(ctx, kclient, instance)
+}
+
+// CheckAdminAPIDNS implements cloudclient.CloudClient
+func (gc *Client) CheckAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	return gc.checkAdminAPIDNS(ctx, kclient, instance, svc, repair)
+}
+
 // SetDefaultAPIPrivate implements cloudclient.CloudClient
 func (gc *Client) SetDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
 	return gc.setDefaultAPIPrivate(ctx, kclient, instance)
@@ -59,27 +73,82 @@ func (gc *Client) SetDefaultAPIPublic(ctx context.Context, kclient k8s.Client, i
 	return gc.setDefaultAPIPublic(ctx, kclient, instance)
 }
 
+// PlanDefaultAPIPrivate implements cloudclient.CloudClient
+func (gc *Client) PlanDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	return gc.planDefaultAPIPrivate(ctx, kclient, instance)
+}
+
+// PlanDefaultAPIPublic implements cloudclient.CloudClient
+func (gc *Client) PlanDefaultAPIPublic(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	return gc.planDefaultAPIPublic(ctx, kclient, instance)
+}
+
+// ReactivateControlPlaneMachineSet implements cloudclient.CloudClient
+func (gc *Client) ReactivateControlPlaneMachineSet(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return baseutils.ResumeCPMSReactivation(ctx, kclient, instance, removeLoadBalancerCPMS)
+}
+
+// EnsureControlPlaneLoadBalancers implements cloudclient.CloudClient
+func (gc *Client) EnsureControlPlaneLoadBalancers(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening) error {
+	return gc.ensureControlPlaneLoadBalancers(ctx, kclient, listening)
+}
+
+// GetDefaultAPIListening implements cloudclient.CloudClient
+func (gc *Client) GetDefaultAPIListening(ctx context.Context, kclient k8s.Client) (cloudingressv1alpha1.Listening, error) {
+	return gc.getDefaultAPIListening(ctx, kclient)
+}
+
+// CheckDefaultAPIDNS implements cloudclient.CloudClient
+func (gc *Client) CheckDefaultAPIDNS(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	return gc.checkDefaultAPIDNS(ctx, kclient, listening, repair)
+}
+
+// FindOrphanedResources implements cloudclient.CloudClient
+func (gc *Client) FindOrphanedResources(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening) ([]cloudingressv1alpha1.OrphanedResource, error) {
+	return gc.findOrphanedResources(ctx, kclient, listening)
+}
+
+// DeleteOrphanedResource implements cloudclient.CloudClient
+func (gc *Client) DeleteOrphanedResource(ctx context.Context, kclient k8s.Client, resource cloudingressv1alpha1.OrphanedResource) error {
+	return gc.deleteOrphanedResource(ctx, kclient, resource)
+}
+
 // Healthcheck performs basic calls to make sure client is healthy
 func (gc *Client) Healthcheck(ctx context.Context, kclient k8s.Client) error {
 	_, err := gc.computeService.RegionBackendServices.List(gc.projectID, gc.region).Do()
 	return err
 }
 
-func newClient(ctx context.Context, serviceAccountJSON []byte) (*Client, error) {
-	credentials, err := google.CredentialsFromJSON(
-		ctx, serviceAccountJSON,
+// newClient creates the client from a service account key, or from a Workload
+// Identity Federation config which exchanges the projected service account
+// token of the pod for Google credentials
+func newClient(ctx context.Context, credentialsJSON []byte) (*Client, error) {
+	credentialsType, err := getCredentialsType(credentialsJSON)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := google.CredentialsFromJSONWithType(
+		ctx, credentialsJSON, credentialsType,
 		dnsv1.NdevClouddnsReadwriteScope,
 		computev1.ComputeScope)
 	if err != nil {
 		return nil, err
 	}
 
-	dnsService, err := dnsv1.NewService(ctx, option.WithCredentials(credentials))
+	// The services share a client which records the calls in the metrics
+	httpClient := &http.Client{
+		Transport: &oauth2.Transport{
+			Source: credentials.TokenSource,
+			Base:   &metricsTransport{base: http.DefaultTransport},
+		},
+	}
+
+	dnsService, err := dnsv1.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
 		return nil, err
 	}
 
-	computeService, err := computev1.NewService(ctx, option.WithCredentials(credentials))
+	computeService, err := computev1.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
 		return nil, err
 	}
@@ -98,20 +167,20 @@ func NewClient(kclient k8s.Client) (*Client, error) {
 	err := kclient.Get(
 		ctx,
 		types.NamespacedName{
-			Name:      config.GCPSecretName,
+			Name:      operatorconfig.Get().GCPSecretName,
 			Namespace: config.OperatorNamespace,
 		},
 		secret)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get Secret with credentials %w", err)
 	}
-	serviceAccountJSON, ok := secret.Data["service_account.json"]
+	credentialsJSON, ok := secret.Data["service_account.json"]
 	if !ok {
 		return nil, fmt.Errorf("access credentials missing service account")
 	}
 
 	// initialize actual client
-	c, err := newClient(ctx, serviceAccountJSON)
+	c, err := newClient(ctx, credentialsJSON)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create GCP client %s", err)
 	}
@@ -122,6 +191,18 @@ func NewClient(kclient k8s.Client) (*Client, error) {
 		return nil, err
 	}
 	c.region = region
+	// Workload Identity Federation configs don't have a project, the
+	// Infrastructure has the cluster's one
+	projectID, err := getClusterProjectID(kclient)
+	if err != nil {
+		return nil, err
+	}
+	if projectID != "" {
+		c.projectID = projectID
+	}
+	if c.projectID == "" {
+		return nil, fmt.Errorf("couldn't find the GCP project in the Infrastructure nor in the credentials")
+	}
 
 	masterList, err := baseutils.GetMasterMachines(kclient)
 	if err != nil {
@@ -142,6 +223,23 @@ func NewClient(kclient k8s.Client) (*Client, error) {
 	return c, nil
 }
 
+// getCredentialsType returns the type of the credentials JSON. Only service
+// account keys and Workload Identity Federation configs are accepted, these
+// are what the cloud-credential-operator writes in the Secret.
+func getCredentialsType(credentialsJSON []byte) (google.CredentialsType, error) {
+	var f struct {
+		Type google.CredentialsType `json:"type"`
+	}
+	if err := json.Unmarshal(credentialsJSON, &f); err != nil {
+		return "", fmt.Errorf("couldn't parse the credentials: %w", err)
+	}
+	switch f.Type {
+	case google.ServiceAccount, google.ExternalAccount:
+		return f.Type, nil
+	}
+	return "", fmt.Errorf("unsupported credentials type %q", f.Type)
+}
+
 func getClusterRegion(kclient k8s.Client) (string, error) {
 	infra, err := baseutils.GetInfrastructureObject(kclient)
 	if err != nil {
@@ -149,3 +247,11 @@ func getClusterRegion(kclient k8s.Client) (string, error) {
 	}
 	return infra.Status.PlatformStatus.GCP.Region, nil
 }
+
+func getClusterProjectID(kclient k8s.Client) (string, error) {
+	infra, err := baseutils.GetInfrastructureObject(kclient)
+	if err != nil {
+		return "", err
+	}
+	return infra.Status.PlatformStatus.GCP.ProjectID, nil
+}
@@ -1,8 +1,17 @@
 package gcp
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"golang.org/x/oauth2/google"
+	computev1 "google.golang.org/api/compute/v1"
+
 	"github.com/openshift/cloud-ingress-operator/config"
 	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
 	corev1 "k8s.io/api/core/v1"
@@ -44,3 +53,107 @@ func TestNewClient(t *testing.T) {
 		t.Error("cli should have been initialized")
 	}
 }
+
+// externalAccountJSON is a Workload Identity Federation config, as written by
+// the cloud-credential-operator, exchanging the token in tokenFile at tokenURL
+func externalAccountJSON(tokenURL, tokenFile string) string {
+	return fmt.Sprintf(`{
+		"type": "external_account",
+		"audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/sut/providers/sut",
+		"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+		"token_url": %q,
+		"credential_source": {
+			"file": %q,
+			"format": {"type": "text"}
+		}
+	}`, tokenURL, tokenFile)
+}
+
+func TestNewClientWorkloadIdentityFederation(t *testing.T) {
+	// stand-in for the Security Token Service of Google
+	var subjectTokens []string
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		subjectTokens = append(subjectTokens, r.Form.Get("subject_token"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "federated-token", "issued_token_type": "urn:ietf:params:oauth:token-type:access_token", "token_type": "Bearer", "expires_in": 3600}`)
+	}))
+	defer sts.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("projected-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	infra := testutils.CreateGCPInfraObject("sut", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	fakeSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      config.GCPSecretName,
+			Namespace: config.OperatorNamespace,
+		},
+		Data: map[string][]byte{"service_account.json": []byte(externalAccountJSON(sts.URL, tokenFile))},
+	}
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{infra, fakeSecret})
+	cli, err := NewClient(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatal("err occurred while creating cli:", err)
+	}
+	if cli.projectID != testutils.DefaultProjectID {
+		t.Errorf("expected the project %s of the Infrastructure, got %q", testutils.DefaultProjectID, cli.projectID)
+	}
+
+	credentials, err := google.CredentialsFromJSONWithType(context.TODO(), []byte(externalAccountJSON(sts.URL, tokenFile)), google.ExternalAccount, computev1.ComputeScope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := credentials.TokenSource.Token()
+	if err != nil {
+		t.Fatal("couldn't exchange the projected token:", err)
+	}
+	if token.AccessToken != "federated-token" || len(subjectTokens) != 1 || subjectTokens[0] != "projected-token" {
+		t.Errorf("unexpected token exchange: %v, %v", token.AccessToken, subjectTokens)
+	}
+}
+
+func TestGetCredentialsType(t *testing.T) {
+	tests := []struct {
+		credentials string
+		expected    google.CredentialsType
+		expectErr   bool
+	}{
+		{credentials: `{"type": "service_account"}`, expected: google.ServiceAccount},
+		{credentials: `{"type": "external_account"}`, expected: google.ExternalAccount},
+		{credentials: `{"type": "authorized_user"}`, expectErr: true},
+		{credentials: `not json`, expectErr: true},
+	}
+	for _, test := range tests {
+		credentialsType, err := getCredentialsType([]byte(test.credentials))
+		if (err != nil) != test.expectErr {
+			t.Errorf("Test [%v] FAILED: unexpected error %v", test.credentials, err)
+		}
+		if credentialsType != test.expected {
+			t.Errorf("Test [%v] FAILED: expected %s, got %s", test.credentials, test.expected, credentialsType)
+		}
+	}
+}
+
+func TestNewClientWithoutProject(t *testing.T) {
+	infra := testutils.CreateGCPInfraObject("sut", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	infra.Status.PlatformStatus.GCP.ProjectID = ""
+	fakeSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      config.GCPSecretName,
+			Namespace: config.OperatorNamespace,
+		},
+		Data: map[string][]byte{"service_account.json": []byte(externalAccountJSON("https://sts.googleapis.com/v1/token", "/var/run/secrets/openshift/serviceaccount/token"))},
+	}
+
+	mocks := testutils.NewTestMock(t, []runtime.Object{infra, fakeSecret})
+	if _, err := NewClient(mocks.FakeKubeClient); err == nil {
+		t.Error("expected an error without a project")
+	}
+}
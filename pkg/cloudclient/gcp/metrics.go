@@ -0,0 +1,101 @@
+package gcp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/retry"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+)
+
+// customVerbPrefixes start the custom methods of the REST APIs, such as
+// forwardingRules/{name}/setTarget, which can't be told apart from a
+// collection by the shape of the path
+var customVerbPrefixes = []string{"set", "add", "remove", "get", "wait"}
+
+// metricsTransport records the calls to the compute and dns APIs in the cloud
+// API metrics
+type metricsTransport struct {
+	base http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	operation := gcpOperation(req.Method, req.URL.Path)
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	localmetrics.MetricCloudAPIRequests.WithLabelValues("gcp", operation).Inc()
+	localmetrics.MetricCloudAPIDuration.WithLabelValues("gcp", operation).Observe(time.Since(start).Seconds())
+	switch {
+	case err != nil:
+		localmetrics.MetricCloudAPIErrors.WithLabelValues("gcp", operation, "RequestError").Inc()
+	case resp.StatusCode >= http.StatusBadRequest:
+		localmetrics.MetricCloudAPIErrors.WithLabelValues("gcp", operation, strconv.Itoa(resp.StatusCode)).Inc()
+		if throttled(resp) {
+			localmetrics.MetricCloudAPIThrottled.WithLabelValues("gcp", operation).Inc()
+		}
+	}
+	return resp, err
+}
+
+// throttled tells whether an error response would be retried as throttled. GCE and Cloud DNS mostly report
+// rate limits and quotas as a 403 with the reason in the body, so the body is read and put back for the caller.
+func throttled(resp *http.Response) bool {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp.StatusCode == http.StatusTooManyRequests
+	}
+	checked := *resp
+	checked.Body = io.NopCloser(bytes.NewReader(body))
+	return retry.Classify(googleapi.CheckResponse(&checked)) == retry.Throttled
+}
+
+// gcpOperation names the API method from the REST path, skipping the IDs.
+// e.g. GET /compute/v1/projects/p/regions/r/forwardingRules -> compute:forwardingRules.list
+func gcpOperation(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 3 {
+		return "unknown"
+	}
+	service := segments[0]
+	// Skip the service and the version, the rest alternates between collections and IDs
+	segments = segments[2:]
+	last := segments[len(segments)-1]
+
+	if len(segments)%2 == 0 {
+		// Ends with an ID
+		verb := map[string]string{
+			http.MethodGet:    "get",
+			http.MethodDelete: "delete",
+			http.MethodPatch:  "patch",
+			http.MethodPut:    "update",
+		}[method]
+		if verb == "" {
+			verb = strings.ToLower(method)
+		}
+		return service + ":" + segments[len(segments)-2] + "." + verb
+	}
+	if len(segments) >= 3 && isCustomVerb(last) {
+		return service + ":" + segments[len(segments)-3] + "." + last
+	}
+	if method == http.MethodPost {
+		return service + ":" + last + ".insert"
+	}
+	return service + ":" + last + ".list"
+}
+
+func isCustomVerb(segment string) bool {
+	for _, prefix := range customVerbPrefixes {
+		if strings.HasPrefix(segment, prefix) {
+			return true
+		}
+	}
+	return false
+}
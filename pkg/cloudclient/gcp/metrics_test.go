@@ -0,0 +1,98 @@
+package gcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+)
+
+func TestGCPOperation(t *testing.T) {
+	tests := []struct {
+		Method   string
+		Path     string
+		Expected string
+	}{
+		{http.MethodGet, "/compute/v1/projects/p/regions/r/forwardingRules", "compute:forwardingRules.list"},
+		{http.MethodPost, "/compute/v1/projects/p/regions/r/forwardingRules", "compute:forwardingRules.insert"},
+		{http.MethodDelete, "/compute/v1/projects/p/regions/r/forwardingRules/api", "compute:forwardingRules.delete"},
+		{http.MethodGet, "/compute/v1/projects/p/regions/r/targetPools/api", "compute:targetPools.get"},
+		{http.MethodPost, "/compute/v1/projects/p/regions/r/operations/op/wait", "compute:operations.wait"},
+		{http.MethodPost, "/compute/v1/projects/p/regions/r/forwardingRules/api/setTarget", "compute:forwardingRules.setTarget"},
+		{http.MethodGet, "/dns/v1/projects/p/managedZones/z/rrsets", "dns:rrsets.list"},
+		{http.MethodPost, "/dns/v1/projects/p/managedZones/z/changes", "dns:changes.insert"},
+		{http.MethodGet, "/", "unknown"},
+	}
+
+	for _, test := range tests {
+		if operation := gcpOperation(test.Method, test.Path); operation != test.Expected {
+			t.Errorf("Test [%v %v] FAILED: expected %s, got %s", test.Method, test.Path, test.Expected, operation)
+		}
+	}
+}
+
+func TestMetricsTransport(t *testing.T) {
+	tests := []struct {
+		Name              string
+		Status            int
+		Body              string
+		ExpectedThrottled float64
+	}{
+		{
+			Name:              "Should count a 429 as throttled",
+			Status:            http.StatusTooManyRequests,
+			ExpectedThrottled: 1,
+		},
+		{
+			Name:              "Should count a rate limit reported as a 403 as throttled",
+			Status:            http.StatusForbidden,
+			Body:              `{"error": {"code": 403, "message": "Rate Limit Exceeded", "errors": [{"reason": "rateLimitExceeded"}]}}`,
+			ExpectedThrottled: 1,
+		},
+		{
+			Name:   "Should not count a permission error as throttled",
+			Status: http.StatusForbidden,
+			Body:   `{"error": {"code": 403, "message": "Forbidden", "errors": [{"reason": "forbidden"}]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.Status)
+			_, _ = w.Write([]byte(test.Body))
+		}))
+
+		operation := "dns:rrsets.list"
+		code := strconv.Itoa(test.Status)
+		requests := testutil.ToFloat64(localmetrics.MetricCloudAPIRequests.WithLabelValues("gcp", operation))
+		errors := testutil.ToFloat64(localmetrics.MetricCloudAPIErrors.WithLabelValues("gcp", operation, code))
+		throttled := testutil.ToFloat64(localmetrics.MetricCloudAPIThrottled.WithLabelValues("gcp", operation))
+
+		client := &http.Client{Transport: &metricsTransport{base: http.DefaultTransport}}
+		resp, err := client.Get(server.URL + "/dns/v1/projects/p/managedZones/z/rrsets")
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		server.Close()
+
+		if string(body) != test.Body {
+			t.Errorf("Test [%v] FAILED: expected the body to be passed on, got %q", test.Name, body)
+		}
+		if got := testutil.ToFloat64(localmetrics.MetricCloudAPIRequests.WithLabelValues("gcp", operation)) - requests; got != 1 {
+			t.Errorf("Test [%v] FAILED: expected 1 request, got %v", test.Name, got)
+		}
+		if got := testutil.ToFloat64(localmetrics.MetricCloudAPIErrors.WithLabelValues("gcp", operation, code)) - errors; got != 1 {
+			t.Errorf("Test [%v] FAILED: expected 1 error, got %v", test.Name, got)
+		}
+		if got := testutil.ToFloat64(localmetrics.MetricCloudAPIThrottled.WithLabelValues("gcp", operation)) - throttled; got != test.ExpectedThrottled {
+			t.Errorf("Test [%v] FAILED: expected %v throttled requests, got %v", test.Name, test.ExpectedThrottled, got)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+)
+
+// findOrphanedResources returns the external API forwarding rule while the
+// default API is internal, the static IP addresses of the cluster which no
+// forwarding rule uses, and the records of the admin API names which no
+// APIScheme uses
+func (gc *Client) findOrphanedResources(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening) ([]cloudingressv1alpha1.OrphanedResource, error) {
+	var orphans []cloudingressv1alpha1.OrphanedResource
+
+	// setDefaultAPIPrivate may have failed after the DNS record was moved
+	if listening == cloudingressv1alpha1.Internal {
+		forwardingRules, err := gc.computeService.ForwardingRules.List(gc.projectID, gc.region).Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, lb := range forwardingRules.Items {
+			if lb.LoadBalancingScheme == "EXTERNAL" && lb.PortRange == "6443-6443" && lb.Name == gc.clusterName+"-api" {
+				orphans = append(orphans, cloudingressv1alpha1.OrphanedResource{
+					Kind:   cloudingressv1alpha1.OrphanedForwardingRule,
+					Name:   lb.Name,
+					Reason: "the default API is internal",
+				})
+			}
+		}
+	}
+
+	// createExternalIP may have succeeded while the forwarding rule couldn't
+	// be created, a reserved address is one no forwarding rule uses
+	addresses, err := gc.computeService.Addresses.List(gc.projectID, gc.region).Do()
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range addresses.Items {
+		owned := address.Labels["kubernetes-io-cluster-"+gc.clusterName] == "owned" || address.Name == gc.clusterName+"-cluster-public-ip"
+		if owned && address.Status == "RESERVED" {
+			orphans = append(orphans, cloudingressv1alpha1.OrphanedResource{
+				Kind:   cloudingressv1alpha1.OrphanedIPAddress,
+				Name:   address.Name,
+				Reason: fmt.Sprintf("no forwarding rule uses %s", address.Address),
+			})
+		}
+	}
+
+	records, err := gc.findOrphanedAdminAPIRecords(ctx, kclient)
+	if err != nil {
+		return nil, err
+	}
+	return append(orphans, records...), nil
+}
+
+// findOrphanedAdminAPIRecords returns the A records of the admin API names
+// which no APIScheme uses, from the zones of the cluster
+func (gc *Client) findOrphanedAdminAPIRecords(ctx context.Context, kclient k8s.Client) ([]cloudingressv1alpha1.OrphanedResource, error) {
+	names, err := baseutils.UnusedAdminAPINames(ctx, kclient)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+	zones, err := getClusterZones(kclient)
+	if err != nil {
+		return nil, err
+	}
+	var orphans []cloudingressv1alpha1.OrphanedResource
+	for _, zone := range zones {
+		for _, name := range names {
+			FQDN := name + "." + gc.baseDomain + "."
+			response, err := gc.dnsService.ResourceRecordSets.List(gc.projectID, zone.id).Name(FQDN).Type("A").Do()
+			if err != nil {
+				return nil, err
+			}
+			if len(response.Rrsets) > 0 {
+				orphans = append(orphans, cloudingressv1alpha1.OrphanedResource{
+					Kind:   cloudingressv1alpha1.OrphanedDNSRecord,
+					Name:   FQDN,
+					Zone:   zone.zone,
+					Reason: fmt.Sprintf("no APIScheme uses %s", name),
+				})
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// deleteOrphanedResource deletes a resource found by findOrphanedResources
+func (gc *Client) deleteOrphanedResource(ctx context.Context, kclient k8s.Client, resource cloudingressv1alpha1.OrphanedResource) error {
+	switch resource.Kind {
+	case cloudingressv1alpha1.OrphanedForwardingRule:
+		_, err := gc.computeService.ForwardingRules.Delete(gc.projectID, gc.region, resource.Name).Do()
+		return err
+	case cloudingressv1alpha1.OrphanedIPAddress:
+		return gc.releaseExternalIP(resource.Name)
+	case cloudingressv1alpha1.OrphanedDNSRecord:
+		zones, err := getClusterZones(kclient)
+		if err != nil {
+			return err
+		}
+		for _, zone := range zones {
+			if zone.zone == resource.Zone {
+				return gc.deleteRecordInZone(ctx, zone.id, resource.Name)
+			}
+		}
+		return fmt.Errorf("the cluster has no %s zone", resource.Zone)
+	}
+	return fmt.Errorf("can't delete a %s on GCP", resource.Kind)
+}
@@ -5,11 +5,13 @@ package gcp
 import (
 	"bytes"
 	"context"
+	goerrors "errors"
 	"fmt"
+	"net"
 	"net/http"
 	"reflect"
+	"slices"
 	"strings"
-	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	jsonserializer "k8s.io/apimachinery/pkg/runtime/serializer/json"
@@ -31,30 +33,78 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient/retry"
 	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
 )
 
+// defaultAPIRecordTTL is the TTL the installer gives the api records, used
+// when one has to be created again
+const defaultAPIRecordTTL = 60
+
+// cloudDNSBackoff keeps the failed attempts of the DNS changes. It outlives the
+// Client, which is created again when the credentials are rotated
+var cloudDNSBackoff = retry.NewBackoff("gcp")
+
 // ensureAdminAPIDNS ensures the DNS record for the "admin API" Service
 // LoadBalancer is accurately set
 func (gc *Client) ensureAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return gc.ensureDNSForService(kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName)
+	internal := instance.Spec.ManagementAPIServerIngress.Listening == cloudingressv1alpha1.Internal
+	return gc.ensureDNSForService(ctx, kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName, internal)
 }
 
 // deleteAdminAPIDNS ensures the DNS record for the "admin API" Service
 // LoadBalancer is deleted
 func (gc *Client) deleteAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return gc.removeDNSForService(kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName)
+	return gc.removeDNSForName(ctx, kclient, instance.Spec.ManagementAPIServerIngress.DNSName)
+}
+
+// deleteAdminAPIDNSWithoutService ensures the DNS record for the "admin API"
+// is deleted once the Service is gone. The records are found by name, so this
+// is the same as deleteAdminAPIDNS.
+func (gc *Client) deleteAdminAPIDNSWithoutService(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return gc.removeDNSForName(ctx, kclient, instance.Spec.ManagementAPIServerIngress.DNSName)
+}
+
+// checkAdminAPIDNS compares the "admin API" records in the public and private
+// zones with the addresses of the Service LoadBalancer
+func (gc *Client) checkAdminAPIDNS(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	svcIPs, err := getIPAddressesFromService(svc)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := getClusterZones(kclient)
+	if err != nil {
+		return nil, err
+	}
+	FQDN := instance.Spec.ManagementAPIServerIngress.DNSName + "." + gc.baseDomain + "."
+	var drifts []cloudingressv1alpha1.DNSRecordDrift
+	var errs []error
+	for _, zone := range zones {
+		if zone.zone == cloudingressv1alpha1.DNSRecordZonePublic && instance.Spec.ManagementAPIServerIngress.Listening == cloudingressv1alpha1.Internal {
+			// An internal APIScheme has no public record
+			continue
+		}
+		drift, err := gc.checkRecord(ctx, zone, newAdminAPIRecordSet(FQDN, "A", svcIPs), repair)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not check %s in zone %s: %w", FQDN, zone.id, err))
+		}
+		if drift != nil {
+			drifts = append(drifts, *drift)
+		}
+	}
+	return drifts, goerrors.Join(errs...)
 }
 
 // setDefaultAPIPrivate sets the default api (api.<cluster-domain>) to private
 // scope
-func (gc *Client) setDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) error {
-	intIPAddress, err := gc.removeLoadBalancerFromMasterNodes(ctx, kclient)
+func (gc *Client) setDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	intIPAddress, err := gc.removeLoadBalancerFromMasterNodes(ctx, kclient, instance)
 	if err != nil {
 		return fmt.Errorf("failed to remove load balancer from master nodes: %v", err)
 	}
 	apiDNSName := fmt.Sprintf("api.%s.", gc.baseDomain)
-	oldIP, err := gc.updateAPIARecord(kclient, apiDNSName, intIPAddress)
+	oldIP, err := gc.updateAPIARecord(ctx, kclient, apiDNSName, intIPAddress)
 	if err != nil {
 		return err
 	}
@@ -67,6 +117,7 @@ func (gc *Client) setDefaultAPIPrivate(ctx context.Context, kclient k8s.Client,
 	if err != nil {
 		return err
 	}
+	cioevents.Normal(ctx, cioevents.ReasonIPAddressReleased, cioevents.ActionDelete, "Released the external IP address %s", staticIPName)
 	log.Info("Succcessfully set default API to private", "URL", apiDNSName, "IP Address", intIPAddress)
 	return nil
 }
@@ -96,12 +147,15 @@ func (gc *Client) setDefaultAPIPublic(ctx context.Context, kclient k8s.Client, i
 	if err != nil {
 		return err
 	}
+	cioevents.Normal(ctx, cioevents.ReasonIPAddressReserved, cioevents.ActionCreate, "Reserved the external IP address %s (%s)", staticIPName, staticIPAddress)
 	err = gc.createNetworkLoadBalancer(extNLBName, "EXTERNAL", extNLBName, staticIPAddress)
 	if err != nil {
+		cioevents.Warning(ctx, cioevents.ReasonLoadBalancerCreateFailed, cioevents.ActionCreate, "Couldn't create the external load balancer %s: %v", extNLBName, err)
 		return err
 	}
+	cioevents.Normal(ctx, cioevents.ReasonLoadBalancerCreated, cioevents.ActionCreate, "Created the external load balancer %s", extNLBName)
 	apiDNSName := fmt.Sprintf("api.%s.", gc.baseDomain)
-	_, err = gc.updateAPIARecord(kclient, apiDNSName, staticIPAddress)
+	_, err = gc.updateAPIARecord(ctx, kclient, apiDNSName, staticIPAddress)
 	if err != nil {
 		return err
 	}
@@ -109,91 +163,400 @@ func (gc *Client) setDefaultAPIPublic(ctx context.Context, kclient k8s.Client, i
 	return nil
 }
 
-func (gc *Client) ensureDNSForService(kclient k8s.Client, svc *corev1.Service, dnsName string) error {
+// getDefaultAPIListening reports the default API as external when the
+// external forwarding rule created by setDefaultAPIPublic exists
+func (gc *Client) getDefaultAPIListening(ctx context.Context, kclient k8s.Client) (cloudingressv1alpha1.Listening, error) {
+	response, err := gc.computeService.ForwardingRules.List(gc.projectID, gc.region).Do()
+	if err != nil {
+		return "", err
+	}
+	extNLBName := gc.clusterName + "-api"
+	for _, lb := range response.Items {
+		if lb.LoadBalancingScheme == "EXTERNAL" && lb.PortRange == "6443-6443" && lb.Name == extNLBName {
+			return cloudingressv1alpha1.External, nil
+		}
+	}
+	return cloudingressv1alpha1.Internal, nil
+}
+
+// checkDefaultAPIDNS compares the api records with the forwarding rules of the
+// scope. The public zone points to the external forwarding rule when the API
+// is external and to the internal one otherwise, the private zone always
+// points to the internal one. The TTL set by the installer is kept.
+func (gc *Client) checkDefaultAPIDNS(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening, repair bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	response, err := gc.computeService.ForwardingRules.List(gc.projectID, gc.region).Do()
+	if err != nil {
+		return nil, err
+	}
+	extNLBName := gc.clusterName + "-api"
+	intLBName := gc.clusterName + "-api-internal"
+	var extIPAddress, intIPAddress string
+	for _, lb := range response.Items {
+		if lb.LoadBalancingScheme == "EXTERNAL" && lb.PortRange == "6443-6443" && lb.Name == extNLBName {
+			extIPAddress = lb.IPAddress
+		}
+		if lb.LoadBalancingScheme == "INTERNAL" && lb.BackendService != "" && lb.Name == intLBName {
+			intIPAddress = lb.IPAddress
+		}
+	}
+	if intIPAddress == "" {
+		return nil, fmt.Errorf("could not find the internal API forwarding rule %s", intLBName)
+	}
+	publicIPAddress := intIPAddress
+	if listening == cloudingressv1alpha1.External {
+		if extIPAddress == "" {
+			return nil, fmt.Errorf("could not find the external API forwarding rule %s", extNLBName)
+		}
+		publicIPAddress = extIPAddress
+	}
+
+	zones, err := getClusterZones(kclient)
+	if err != nil {
+		return nil, err
+	}
+	apiDNSName := fmt.Sprintf("api.%s.", gc.baseDomain)
+	var drifts []cloudingressv1alpha1.DNSRecordDrift
+	var errs []error
+	for _, zone := range zones {
+		ipAddress := intIPAddress
+		if zone.zone == cloudingressv1alpha1.DNSRecordZonePublic {
+			ipAddress = publicIPAddress
+		}
+		expected := &gdnsv1.ResourceRecordSet{
+			Kind:             "dns#resourceRecordSet",
+			Name:             apiDNSName,
+			Rrdatas:          []string{ipAddress},
+			SignatureRrdatas: []string{},
+			Type:             "A",
+		}
+		drift, err := gc.checkRecord(ctx, zone, expected, repair)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not check %s in zone %s: %w", apiDNSName, zone.id, err))
+		}
+		if drift != nil {
+			drifts = append(drifts, *drift)
+		}
+	}
+	return drifts, goerrors.Join(errs...)
+}
+
+// planDefaultAPIPrivate lists the changes setDefaultAPIPrivate would make: the
+// external forwarding rule it deletes, the ControlPlaneMachineSet and Machines
+// it updates to stop referencing the target pool, the api A record pointed to
+// the internal load balancer and the external IP address it releases
+func (gc *Client) planDefaultAPIPrivate(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	response, err := gc.computeService.ForwardingRules.List(gc.projectID, gc.region).Do()
+	if err != nil {
+		return nil, err
+	}
+	masterList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return nil, err
+	}
+	cpms, err := baseutils.GetControlPlaneMachineSet(kclient)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		cpms = nil
+	}
+
+	extNLBName := gc.clusterName + "-api"
+	intLBName := gc.clusterName + "-api-internal"
+	var actions []cloudingressv1alpha1.PlannedAction
+	var intIPAddress string
+	for _, lb := range response.Items {
+		if lb.LoadBalancingScheme == "EXTERNAL" && lb.PortRange == "6443-6443" && lb.Name == extNLBName {
+			actions = append(actions, cloudingressv1alpha1.PlannedAction{
+				Action:      cloudingressv1alpha1.PlannedActionDelete,
+				Kind:        cloudingressv1alpha1.PlannedResourceLoadBalancer,
+				Name:        lb.Name,
+				Description: "Delete the forwarding rule of the external API load balancer",
+			})
+			if cpms != nil && cpms.Spec.State == machinev1.ControlPlaneMachineSetStateActive {
+				actions = append(actions,
+					cloudingressv1alpha1.PlannedAction{
+						Action:      cloudingressv1alpha1.PlannedActionDelete,
+						Kind:        cloudingressv1alpha1.PlannedResourceControlPlaneMachineSet,
+						Name:        cpms.Name,
+						Description: "Delete the active ControlPlaneMachineSet so the Machines can be updated",
+					},
+					cloudingressv1alpha1.PlannedAction{
+						Action:      cloudingressv1alpha1.PlannedActionUpdate,
+						Kind:        cloudingressv1alpha1.PlannedResourceControlPlaneMachineSet,
+						Name:        cpms.Name,
+						Description: fmt.Sprintf("Remove target pool %s from the recreated ControlPlaneMachineSet and set it active again", lb.Name),
+					})
+			}
+			machineActions, err := planGCPLBRemovalFromMasterMachines(kclient, lb.Name, masterList)
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, machineActions...)
+		}
+		if lb.LoadBalancingScheme == "INTERNAL" && lb.BackendService != "" && lb.Name == intLBName {
+			intIPAddress = lb.IPAddress
+		}
+	}
+
+	apiDNSName := fmt.Sprintf("api.%s.", gc.baseDomain)
+	apiRRSet, zoneID, err := gc.getAPIARecord(kclient, apiDNSName)
+	if err != nil {
+		return nil, err
+	}
+	// setDefaultAPIPrivate only releases the external IP address when the A record changes
+	if apiRRSet.Rrdatas[0] != intIPAddress {
+		actions = append(actions,
+			cloudingressv1alpha1.PlannedAction{
+				Action:      cloudingressv1alpha1.PlannedActionUpdate,
+				Kind:        cloudingressv1alpha1.PlannedResourceDNSRecord,
+				Name:        apiDNSName,
+				Description: fmt.Sprintf("Point to the internal load balancer %s (%s) instead of %s in Cloud DNS zone %s", intLBName, intIPAddress, apiRRSet.Rrdatas[0], zoneID),
+			},
+			cloudingressv1alpha1.PlannedAction{
+				Action:      cloudingressv1alpha1.PlannedActionDelete,
+				Kind:        cloudingressv1alpha1.PlannedResourceIPAddress,
+				Name:        gc.clusterName + "-cluster-public-ip",
+				Description: "Release the external IP address of the API",
+			})
+	}
+	return actions, nil
+}
+
+// planDefaultAPIPublic lists the changes setDefaultAPIPublic would make: the
+// external IP address it reserves, the forwarding rule it creates and the api
+// A record pointed to it. Nothing is planned when the forwarding rule exists.
+func (gc *Client) planDefaultAPIPublic(ctx context.Context, kclient k8s.Client, _ *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	response, err := gc.computeService.ForwardingRules.List(gc.projectID, gc.region).Do()
+	if err != nil {
+		return nil, err
+	}
+	extNLBName := gc.clusterName + "-api"
+	for _, lb := range response.Items {
+		if lb.LoadBalancingScheme == "EXTERNAL" && lb.PortRange == "6443-6443" && lb.Name == extNLBName {
+			return nil, nil
+		}
+	}
+	staticIPName := gc.clusterName + "-cluster-public-ip"
+	return []cloudingressv1alpha1.PlannedAction{
+		{
+			Action:      cloudingressv1alpha1.PlannedActionCreate,
+			Kind:        cloudingressv1alpha1.PlannedResourceIPAddress,
+			Name:        staticIPName,
+			Description: "Reserve an external IP address for the API",
+		},
+		{
+			Action:      cloudingressv1alpha1.PlannedActionCreate,
+			Kind:        cloudingressv1alpha1.PlannedResourceLoadBalancer,
+			Name:        extNLBName,
+			Description: fmt.Sprintf("Create an external forwarding rule for TCP port 6443 on %s to target pool %s", staticIPName, extNLBName),
+		},
+		{
+			Action:      cloudingressv1alpha1.PlannedActionUpdate,
+			Kind:        cloudingressv1alpha1.PlannedResourceDNSRecord,
+			Name:        fmt.Sprintf("api.%s.", gc.baseDomain),
+			Description: fmt.Sprintf("Point to the external IP address %s", staticIPName),
+		},
+	}, nil
+}
+
+// planGCPLBRemovalFromMasterMachines lists the control plane Machines which
+// removeGCPLBFromMasterMachines would update
+func planGCPLBRemovalFromMasterMachines(kclient k8s.Client, lbName string, masterNodes *machineapi.MachineList) ([]cloudingressv1alpha1.PlannedAction, error) {
+	var actions []cloudingressv1alpha1.PlannedAction
+	for _, machine := range masterNodes.Items {
+		providerSpecDecoded, err := getGCPDecodedProviderSpec(machine, kclient.Scheme())
+		if err != nil {
+			return nil, err
+		}
+		for _, lb := range providerSpecDecoded.TargetPools {
+			if lb == lbName {
+				actions = append(actions, cloudingressv1alpha1.PlannedAction{
+					Action:      cloudingressv1alpha1.PlannedActionUpdate,
+					Kind:        cloudingressv1alpha1.PlannedResourceMachine,
+					Name:        machine.Name,
+					Description: fmt.Sprintf("Remove target pool %s from the providerSpec", lbName),
+				})
+				break
+			}
+		}
+	}
+	return actions, nil
+}
+
+// ensureDNSForService points dnsName to the load balancer of the Service in the
+// cluster zones, only in the private one when internal
+func (gc *Client) ensureDNSForService(ctx context.Context, kclient k8s.Client, svc *corev1.Service, dnsName string, internal bool) error {
 	// google.golang.org/api/dns/v1.Service is a struct, not an interface, which
 	// will make this all but impossible to write unit tests for
 
 	// Forwarding rule is necessary for rh-api lb setup
 	// Check forwarding rule exists first
-	ingressList := svc.Status.LoadBalancer.Ingress
-	if len(ingressList) == 0 {
+	svcIPs, err := getIPAddressesFromService(svc)
+	if err != nil {
 		// the LB doesn't exist
-		return cioerrors.NewLoadBalancerNotReadyError()
+		return err
 	}
-	rhapiLbIP := ingressList[0].IP
+	rhapiLbIP := svcIPs[0]
 	// ensure forwarding rule exists in GCP for service
-	err := gc.ensureGCPForwardingRuleForExtIP(rhapiLbIP)
+	err = gc.ensureGCPForwardingRuleForExtIP(rhapiLbIP)
 	if err != nil {
 		return cioerrors.ForwardingRuleNotFound(err.Error())
 	}
 
-	svcIPs, err := getIPAddressesFromService(svc)
+	FQDN := dnsName + "." + gc.baseDomain + "."
+	newRRSet := newAdminAPIRecordSet(FQDN, "A", svcIPs)
+	// A dual-stack Service has a second forwarding rule with the IPv6 address
+	svcIPv6s := getIPv6AddressesFromService(svc)
+
+	zones, err := getClusterZones(kclient)
 	if err != nil {
 		return err
 	}
 
-	FQDN := dnsName + "." + gc.baseDomain + "."
+	for _, zone := range zones {
+		if internal && zone.zone == cloudingressv1alpha1.DNSRecordZonePublic {
+			// Left by an external load balancer the endpoint was moved from
+			err := cloudDNSBackoff.Do("clouddns:DeleteRecord", zone.id+"/"+FQDN, func() error {
+				return gc.deleteRecordInZone(ctx, zone.id, FQDN)
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		err := cloudDNSBackoff.Do("clouddns:UpsertRecord", zone.id+"/"+FQDN, func() error {
+			if err := gc.upsertRecordInZone(ctx, zone.id, newRRSet); err != nil {
+				return err
+			}
+			if len(svcIPv6s) > 0 {
+				return gc.upsertRecordInZone(ctx, zone.id, newAdminAPIRecordSet(FQDN, "AAAA", svcIPv6s))
+			}
+			// Left by a dual-stack Service
+			return gc.deleteRecordTypeInZone(ctx, zone.id, FQDN, "AAAA")
+		})
+		if err != nil {
+			return err
+		}
+	}
 
-	// The resource record set to add.
-	// Kind and SignatureRrdatas are set as
-	// they are to satisfy reflect.DeepEqual.
-	newRRSet := &gdnsv1.ResourceRecordSet{
+	return nil
+}
+
+// newAdminAPIRecordSet returns the "admin API" A or AAAA record set pointing
+// FQDN to the addresses of the Service LoadBalancer. Kind and SignatureRrdatas
+// are set as they are to satisfy reflect.DeepEqual.
+func newAdminAPIRecordSet(FQDN, recordType string, svcIPs []string) *gdnsv1.ResourceRecordSet {
+	return &gdnsv1.ResourceRecordSet{
 		Kind:             "dns#resourceRecordSet",
 		Name:             FQDN,
 		Rrdatas:          svcIPs,
 		SignatureRrdatas: []string{},
-		Type:             "A",
+		Type:             recordType,
 		Ttl:              30,
 	}
+}
 
-	clusterDNS, err := getClusterDNS(kclient)
+// checkRecord returns the drift of the A record named like expected, nil when
+// it has the expected addresses and TTL. An expected TTL of 0 matches any TTL.
+// A repaired record is still returned, with Repaired set.
+func (gc *Client) checkRecord(ctx context.Context, zone clusterZone, expected *gdnsv1.ResourceRecordSet, repair bool) (*cloudingressv1alpha1.DNSRecordDrift, error) {
+	response, err := gc.dnsService.ResourceRecordSets.List(gc.projectID, zone.id).Name(expected.Name).Type("A").Do()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var actual *gdnsv1.ResourceRecordSet
+	if len(response.Rrsets) > 0 {
+		actual = response.Rrsets[0]
+	}
+	want := *expected
+	if want.Ttl == 0 {
+		want.Ttl = defaultAPIRecordTTL
+		if actual != nil {
+			want.Ttl = actual.Ttl
+		}
+	}
+	if recordSetMatches(actual, &want) {
+		return nil, nil
 	}
 
-	var zones []configv1.DNSZone
-	if clusterDNS.Spec.PublicZone != nil {
-		zones = append(zones, *clusterDNS.Spec.PublicZone)
+	drift := &cloudingressv1alpha1.DNSRecordDrift{
+		Name:     want.Name,
+		Zone:     zone.zone,
+		Expected: describeRecordSet(&want),
+		Actual:   describeRecordSet(actual),
 	}
-	if clusterDNS.Spec.PrivateZone != nil {
-		zones = append(zones, *clusterDNS.Spec.PrivateZone)
+	if !repair {
+		return drift, nil
+	}
+	// Only the A record is replaced, the other record sets with the same name are left alone
+	dnsChange := &gdnsv1.Change{Additions: []*gdnsv1.ResourceRecordSet{&want}}
+	if actual != nil {
+		dnsChange.Deletions = []*gdnsv1.ResourceRecordSet{actual}
+	}
+	err = cloudDNSBackoff.Do("clouddns:UpsertRecord", zone.id+"/"+want.Name, func() error {
+		_, err := gc.dnsService.Changes.Create(gc.projectID, zone.id, dnsChange).Do()
+		return err
+	})
+	if err != nil {
+		return drift, err
 	}
+	cioevents.Normal(ctx, cioevents.ReasonDNSRecordUpdated, cioevents.ActionUpdate, "Pointed %s to %s in Cloud DNS zone %s", want.Name, strings.Join(want.Rrdatas, ","), zone.id)
+	drift.Repaired = true
+	return drift, nil
+}
 
-	for _, zone := range zones {
-		dnsChange := &gdnsv1.Change{
-			Additions: []*gdnsv1.ResourceRecordSet{newRRSet},
-		}
+// recordSetMatches compares the addresses, in any order, and the TTL of two A record sets
+func recordSetMatches(actual, expected *gdnsv1.ResourceRecordSet) bool {
+	if actual == nil || actual.Type != "A" || actual.Ttl != expected.Ttl {
+		return false
+	}
+	return slices.Equal(slices.Sorted(slices.Values(actual.Rrdatas)), slices.Sorted(slices.Values(expected.Rrdatas)))
+}
 
-		zone.ID = sanitizeZoneID(zone.ID)
+// describeRecordSet returns the addresses and TTL of a record set for the
+// status, empty when there is no record set
+func describeRecordSet(rrset *gdnsv1.ResourceRecordSet) string {
+	if rrset == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %s TTL %d", rrset.Type, strings.Join(slices.Sorted(slices.Values(rrset.Rrdatas)), ","), rrset.Ttl)
+}
 
-		// Look for an existing resource record set in the zone.
-		listCall := gc.dnsService.ResourceRecordSets.List(gc.projectID, zone.ID)
-		response, err := listCall.Name(FQDN).Do()
-		if err != nil {
-			return err
-		}
+// upsertRecordInZone replaces the record set with the name of newRRSet in the zone, unless it is already up to date
+func (gc *Client) upsertRecordInZone(ctx context.Context, zoneID string, newRRSet *gdnsv1.ResourceRecordSet) error {
+	dnsChange := &gdnsv1.Change{
+		Additions: []*gdnsv1.ResourceRecordSet{newRRSet},
+	}
 
-		// There will be at most one result but loop anyway.
-		// An empty slice will proceed directly to Create.
-		for _, rrset := range response.Rrsets {
-			if reflect.DeepEqual(newRRSet, rrset) {
-				dnsChange.Additions = []*gdnsv1.ResourceRecordSet{}
-			} else {
-				dnsChange.Deletions = append(dnsChange.Deletions, rrset)
-			}
-		}
+	// Look for an existing resource record set of the type in the zone, the
+	// A and AAAA records of a name are separate record sets.
+	listCall := gc.dnsService.ResourceRecordSets.List(gc.projectID, zoneID)
+	response, err := listCall.Name(newRRSet.Name).Type(newRRSet.Type).Do()
+	if err != nil {
+		return err
+	}
 
-		if len(dnsChange.Additions) > 0 {
-			log.Info("Submitting DNS changes:", "Zone", zone.ID,
-				"Additions", dnsChange.Additions, "Deletions", dnsChange.Deletions)
-			changesCall := gc.dnsService.Changes.Create(gc.projectID, zone.ID, dnsChange)
-			_, err = changesCall.Do()
-			if err != nil {
-				return err
-			}
+	// There will be at most one result but loop anyway.
+	// An empty slice will proceed directly to Create.
+	for _, rrset := range response.Rrsets {
+		if reflect.DeepEqual(newRRSet, rrset) {
+			dnsChange.Additions = []*gdnsv1.ResourceRecordSet{}
+		} else {
+			dnsChange.Deletions = append(dnsChange.Deletions, rrset)
 		}
 	}
 
+	if len(dnsChange.Additions) > 0 {
+		log.Info("Submitting DNS changes:", "Zone", zoneID,
+			"Additions", dnsChange.Additions, "Deletions", dnsChange.Deletions)
+		changesCall := gc.dnsService.Changes.Create(gc.projectID, zoneID, dnsChange)
+		_, err = changesCall.Do()
+		if err != nil {
+			return err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonDNSRecordUpdated, cioevents.ActionUpdate, "Pointed %s to %s in Cloud DNS zone %s", newRRSet.Name, strings.Join(newRRSet.Rrdatas, ","), zoneID)
+	}
 	return nil
 }
 
@@ -214,7 +577,8 @@ func (gc *Client) ensureGCPForwardingRuleForExtIP(rhapiLbIP string) error {
 
 }
 
-func (gc *Client) removeDNSForService(kclient k8s.Client, svc *corev1.Service, dnsName string) error {
+// removeDNSForName removes the A record for dnsName from the public and private zones
+func (gc *Client) removeDNSForName(ctx context.Context, kclient k8s.Client, dnsName string) error {
 	// google.golang.org/api/dns/v1.Service is a struct, not an interface, which
 	// will make this all but impossible to write unit tests for
 	FQDN := dnsName + "." + gc.baseDomain + "."
@@ -233,40 +597,65 @@ func (gc *Client) removeDNSForService(kclient k8s.Client, svc *corev1.Service, d
 	}
 
 	for _, zone := range zones {
-		dnsChange := &gdnsv1.Change{}
-
-		zone.ID = sanitizeZoneID(zone.ID)
-
-		// Look for an existing resource record set in the zone.
-		listCall := gc.dnsService.ResourceRecordSets.List(gc.projectID, zone.ID)
-		response, err := listCall.Name(FQDN).Do()
+		zoneID := sanitizeZoneID(zone.ID)
+		err := cloudDNSBackoff.Do("clouddns:DeleteRecord", zoneID+"/"+FQDN, func() error {
+			return gc.deleteRecordInZone(ctx, zoneID, FQDN)
+		})
 		if err != nil {
 			return err
 		}
+	}
 
-		// There will be at most one result but append anyway.
-		dnsChange.Deletions = append(dnsChange.Deletions, response.Rrsets...)
+	return nil
+}
 
-		if len(dnsChange.Deletions) > 0 {
-			log.Info("Submitting DNS changes:", "Zone", zone.ID, "Deletions", dnsChange.Deletions)
-			call := gc.dnsService.Changes.Create(gc.projectID, zone.ID, dnsChange)
-			_, err = call.Do()
-			if err != nil {
-				dnsError, ok := err.(*googleapi.Error)
-				if !ok || dnsError.Code != http.StatusNotFound {
-					return err
-				}
+// deleteRecordInZone deletes the record sets named FQDN from the zone
+func (gc *Client) deleteRecordInZone(ctx context.Context, zoneID, FQDN string) error {
+	return gc.deleteRecordTypeInZone(ctx, zoneID, FQDN, "")
+}
+
+// deleteRecordTypeInZone deletes the record sets of the type named FQDN from
+// the zone, of every type when recordType is empty
+func (gc *Client) deleteRecordTypeInZone(ctx context.Context, zoneID, FQDN, recordType string) error {
+	dnsChange := &gdnsv1.Change{}
+
+	// Look for an existing resource record set in the zone.
+	listCall := gc.dnsService.ResourceRecordSets.List(gc.projectID, zoneID).Name(FQDN)
+	if recordType != "" {
+		listCall = listCall.Type(recordType)
+	}
+	response, err := listCall.Do()
+	if err != nil {
+		return err
+	}
+
+	// There will be at most one result but append anyway.
+	dnsChange.Deletions = append(dnsChange.Deletions, response.Rrsets...)
+
+	if len(dnsChange.Deletions) > 0 {
+		log.Info("Submitting DNS changes:", "Zone", zoneID, "Deletions", dnsChange.Deletions)
+		call := gc.dnsService.Changes.Create(gc.projectID, zoneID, dnsChange)
+		_, err = call.Do()
+		if err != nil {
+			dnsError, ok := err.(*googleapi.Error)
+			if !ok || dnsError.Code != http.StatusNotFound {
+				return err
 			}
+		} else {
+			cioevents.Normal(ctx, cioevents.ReasonDNSRecordDeleted, cioevents.ActionDelete, "Deleted the records for %s from Cloud DNS zone %s", FQDN, zoneID)
 		}
 	}
-
 	return nil
 }
 
+// getIPAddressesFromService returns the IPv4 addresses of the Service
+// LoadBalancer, for the A records
 func getIPAddressesFromService(svc *corev1.Service) ([]string, error) {
 	var ips []string
 	for _, ingress := range svc.Status.LoadBalancer.Ingress {
-		ips = append(ips, ingress.IP)
+		if ip := net.ParseIP(ingress.IP); ip != nil && ip.To4() != nil {
+			ips = append(ips, ingress.IP)
+		}
 	}
 
 	if len(ips) == 0 {
@@ -276,7 +665,19 @@ func getIPAddressesFromService(svc *corev1.Service) ([]string, error) {
 	return ips, nil
 }
 
-func (gc *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient k8s.Client) (string, error) {
+// getIPv6AddressesFromService returns the IPv6 addresses of a dual-stack
+// Service LoadBalancer, for the AAAA records
+func getIPv6AddressesFromService(svc *corev1.Service) []string {
+	var ips []string
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ip := net.ParseIP(ingress.IP); ip != nil && ip.To4() == nil {
+			ips = append(ips, ingress.IP)
+		}
+	}
+	return ips
+}
+
+func (gc *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy) (string, error) {
 	listCall := gc.computeService.ForwardingRules.List(gc.projectID, gc.region)
 	response, err := listCall.Do()
 	if err != nil {
@@ -305,7 +706,7 @@ func (gc *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 			},
 		}
 	}
-	removalClosure := getLoadBalancerRemovalFunc(ctx, kclient, masterList, cpms)
+	removalClosure := getLoadBalancerRemovalFunc(ctx, kclient, instance, masterList, cpms)
 	extNLBName := gc.clusterName + "-api"
 	intLBName := gc.clusterName + "-api-internal"
 	var intIPAddress, lbName string
@@ -318,8 +719,10 @@ func (gc *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 			lbName = lb.Name
 			_, err := gc.computeService.ForwardingRules.Delete(gc.projectID, gc.region, lbName).Do()
 			if err != nil {
+				cioevents.Warning(ctx, cioevents.ReasonLoadBalancerDeleteFailed, cioevents.ActionDelete, "Couldn't delete the external load balancer %s: %v", lbName, err)
 				return "", fmt.Errorf("failed to delete ForwardingRule for external load balancer %v: %v", lb.Name, err)
 			}
+			cioevents.Normal(ctx, cioevents.ReasonLoadBalancerDeleted, cioevents.ActionDelete, "Deleted the external load balancer %s", lbName)
 			err = removalClosure(lbName)
 			if err != nil {
 				return "", err
@@ -406,6 +809,88 @@ func updateGCPLBList(kclient k8s.Client, oldLBList []string, newLBList []string,
 	return nil
 }
 
+// ensureControlPlaneLoadBalancers adds the external API target pool to the
+// targetPools of the master machines and of the CPMS template when the
+// default API is external, and removes it when it is internal. Machines being
+// deleted are left alone, the machines are updated before the CPMS so it
+// doesn't see them as outdated.
+func (gc *Client) ensureControlPlaneLoadBalancers(ctx context.Context, kclient k8s.Client, listening cloudingressv1alpha1.Listening) error {
+	// GCP ForwardingRule and TargetPool share the same name
+	extTargetPool := gc.clusterName + "-api"
+	desiredLBList := func(lbList []string) []string {
+		newLBList := []string{}
+		for _, lb := range lbList {
+			if lb == extTargetPool {
+				if listening == cloudingressv1alpha1.External {
+					return lbList
+				}
+				continue
+			}
+			newLBList = append(newLBList, lb)
+		}
+		if listening == cloudingressv1alpha1.External {
+			newLBList = append(newLBList, extTargetPool)
+		}
+		return newLBList
+	}
+
+	masterList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return err
+	}
+	for _, machine := range masterList.Items {
+		if machine.DeletionTimestamp != nil {
+			continue
+		}
+		providerSpecDecoded, err := getGCPDecodedProviderSpec(machine, kclient.Scheme())
+		if err != nil {
+			return err
+		}
+		lbList := providerSpecDecoded.TargetPools
+		newLBList := desiredLBList(lbList)
+		if equalGCPLBLists(lbList, newLBList) {
+			continue
+		}
+		if err := updateGCPLBList(kclient, lbList, newLBList, machine, providerSpecDecoded); err != nil {
+			return err
+		}
+		cioevents.Normal(ctx, cioevents.ReasonControlPlaneLoadBalancersUpdated, cioevents.ActionUpdate, "Set the target pools of Machine %s to %v", machine.Name, newLBList)
+	}
+
+	cpms, err := baseutils.GetControlPlaneMachineSet(kclient)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	cpmsPatch := k8s.MergeFrom(cpms.DeepCopy())
+	spec, err := baseutils.ConvertFromRawExtension[machineapi.GCPMachineProviderSpec](cpms.Spec.Template.OpenShiftMachineV1Beta1Machine.Spec.ProviderSpec.Value)
+	if err != nil {
+		return err
+	}
+	newLBList := desiredLBList(spec.TargetPools)
+	if equalGCPLBLists(spec.TargetPools, newLBList) {
+		return nil
+	}
+	spec.TargetPools = newLBList
+	extension, err := baseutils.ConvertToRawBytes(spec)
+	if err != nil {
+		return err
+	}
+	cpms.Spec.Template.OpenShiftMachineV1Beta1Machine.Spec.ProviderSpec.Value.Raw = extension
+	if err := kclient.Patch(ctx, cpms, cpmsPatch); err != nil {
+		return fmt.Errorf("could not update CPMS: %v", err)
+	}
+	cioevents.Normal(ctx, cioevents.ReasonControlPlaneLoadBalancersUpdated, cioevents.ActionUpdate, "Set the target pools of the ControlPlaneMachineSet to %v", newLBList)
+	return nil
+}
+
+// equalGCPLBLists doesn't tell a nil list from an empty one
+func equalGCPLBLists(a, b []string) bool {
+	return len(a) == len(b) && (len(a) == 0 || reflect.DeepEqual(a, b))
+}
+
 func (gc *Client) createExternalIP(name string, scheme string) (ipAddress string, err error) {
 	// Check if an external IP with the correct name already exists
 	addyList, err := gc.computeService.Addresses.List(gc.projectID, gc.region).Do()
@@ -477,47 +962,79 @@ func (gc *Client) createNetworkLoadBalancer(name string, scheme string, targetPo
 	return nil
 }
 
-func (gc *Client) updateAPIARecord(kclient k8s.Client, recordName string, newIP string) (oldIP string, err error) {
-	clusterDNS, err := getClusterDNS(kclient)
+func (gc *Client) updateAPIARecord(ctx context.Context, kclient k8s.Client, recordName string, newIP string) (oldIP string, err error) {
+	apiRRSet, zoneID, err := gc.getAPIARecord(kclient, recordName)
 	if err != nil {
 		return "", err
 	}
-
-	clusterDNS.Spec.PublicZone.ID = sanitizeZoneID(clusterDNS.Spec.PublicZone.ID)
-
-	pubZoneRecords, err := gc.dnsService.ResourceRecordSets.List(gc.projectID, clusterDNS.Spec.PublicZone.ID).Do()
-	if err != nil {
-		return "", fmt.Errorf("failed to retrieve list of ResourceRecordSets from public zone %v : %v", clusterDNS.Spec.PublicZone.ID, err)
-	}
-	apiRRSets := []*gdnsv1.ResourceRecordSet{}
-	for _, rrset := range pubZoneRecords.Rrsets {
-		if rrset.Name == recordName {
-			apiRRSets = append(apiRRSets, rrset)
-		}
-	}
-	if len(apiRRSets) != 1 {
-		return "", fmt.Errorf("expected to find 1 A record for API, found %d", len(apiRRSets))
-	}
-	oldIP = apiRRSets[0].Rrdatas[0]
+	oldIP = apiRRSet.Rrdatas[0]
 	if oldIP == newIP {
 		// A record is already pointing to the correct IP, nothing to do
 		log.Info("Default API A record is already pointing to the correct IP. No update necessary.", "IP address", newIP)
 		return oldIP, nil
 	}
 	dnsChange := &gdnsv1.Change{}
-	dnsChange.Deletions = append(dnsChange.Deletions, apiRRSets[0])
-	updatedRRSet := *apiRRSets[0]
+	dnsChange.Deletions = append(dnsChange.Deletions, apiRRSet)
+	updatedRRSet := *apiRRSet
 	updatedRRSet.Rrdatas = []string{newIP}
 	dnsChange.Additions = append(dnsChange.Additions, &updatedRRSet)
-	changesCall := gc.dnsService.Changes.Create(gc.projectID, clusterDNS.Spec.PublicZone.ID, dnsChange)
+	changesCall := gc.dnsService.Changes.Create(gc.projectID, zoneID, dnsChange)
 	_, err = changesCall.Do()
 	if err != nil {
 		return "", err
 	}
+	cioevents.Normal(ctx, cioevents.ReasonDNSRecordUpdated, cioevents.ActionUpdate, "Pointed %s to %s in Cloud DNS zone %s", recordName, newIP, zoneID)
 
 	return oldIP, nil
 }
 
+// getAPIARecord returns the A record named recordName from the public zone, along with the zone ID
+func (gc *Client) getAPIARecord(kclient k8s.Client, recordName string) (*gdnsv1.ResourceRecordSet, string, error) {
+	clusterDNS, err := getClusterDNS(kclient)
+	if err != nil {
+		return nil, "", err
+	}
+
+	zoneID := sanitizeZoneID(clusterDNS.Spec.PublicZone.ID)
+
+	pubZoneRecords, err := gc.dnsService.ResourceRecordSets.List(gc.projectID, zoneID).Do()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to retrieve list of ResourceRecordSets from public zone %v : %v", zoneID, err)
+	}
+	apiRRSets := []*gdnsv1.ResourceRecordSet{}
+	for _, rrset := range pubZoneRecords.Rrsets {
+		if rrset.Name == recordName {
+			apiRRSets = append(apiRRSets, rrset)
+		}
+	}
+	if len(apiRRSets) != 1 {
+		return nil, "", fmt.Errorf("expected to find 1 A record for API, found %d", len(apiRRSets))
+	}
+	return apiRRSets[0], zoneID, nil
+}
+
+// clusterZone is one of the Cloud DNS zones of the cluster
+type clusterZone struct {
+	zone cloudingressv1alpha1.DNSRecordZone
+	id   string
+}
+
+// getClusterZones returns the public and private zones of the cluster, those which are set
+func getClusterZones(kclient k8s.Client) ([]clusterZone, error) {
+	clusterDNS, err := getClusterDNS(kclient)
+	if err != nil {
+		return nil, err
+	}
+	var zones []clusterZone
+	if clusterDNS.Spec.PublicZone != nil {
+		zones = append(zones, clusterZone{zone: cloudingressv1alpha1.DNSRecordZonePublic, id: sanitizeZoneID(clusterDNS.Spec.PublicZone.ID)})
+	}
+	if clusterDNS.Spec.PrivateZone != nil {
+		zones = append(zones, clusterZone{zone: cloudingressv1alpha1.DNSRecordZonePrivate, id: sanitizeZoneID(clusterDNS.Spec.PrivateZone.ID)})
+	}
+	return zones, nil
+}
+
 func getClusterDNS(kclient k8s.Client) (*configv1.DNS, error) {
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(schema.GroupVersionKind{
@@ -573,56 +1090,45 @@ func removeLoadBalancerCPMS(ctx context.Context, kclient k8s.Client, lbName stri
 	return nil
 }
 
-func getLoadBalancerRemovalFunc(ctx context.Context, kclient k8s.Client, masterList *machineapi.MachineList, cpms *machinev1.ControlPlaneMachineSet) func(string) error {
+func getLoadBalancerRemovalFunc(ctx context.Context, kclient k8s.Client, instance *cloudingressv1alpha1.PublishingStrategy, masterList *machineapi.MachineList, cpms *machinev1.ControlPlaneMachineSet) func(string) error {
 	if cpms.Spec.State == machinev1.ControlPlaneMachineSetStateActive {
 		return func(lbName string) error {
-			err := baseutils.DeleteCPMS(ctx, kclient, cpms)
+			// Record the reactivation before deleting the CPMS, so it is
+			// resumed if the operator restarts before it's active again
+			pending := instance != nil && instance.Status.ControlPlaneMachineSetReactivation != nil
+			err := baseutils.StartCPMSReactivation(ctx, kclient, instance, lbName)
+			if err != nil {
+				return err
+			}
+			if pending {
+				// The CPMS was already deleted for another LB
+				return removeGCPLBFromMasterMachines(kclient, lbName, masterList)
+			}
+			err = baseutils.DeleteCPMS(ctx, kclient, cpms)
 			if err != nil {
 				log.Error(err, "failed to delete CPMS")
+				cioevents.Warning(ctx, cioevents.ReasonControlPlaneMachineSetFailed, cioevents.ActionDelete, "Couldn't delete the active ControlPlaneMachineSet to remove load balancer %s: %v", lbName, err)
 				return err
 			}
+			cioevents.Normal(ctx, cioevents.ReasonControlPlaneMachineSetDeleted, cioevents.ActionDelete, "Deleted the active ControlPlaneMachineSet to remove load balancer %s from it", lbName)
 			err = removeGCPLBFromMasterMachines(kclient, lbName, masterList)
 			if err != nil {
 				log.Error(err, "faild to remove load balancer from machines")
 				return err
 			}
-			go func() {
-				maxRetries := 5
-				for {
-					time.Sleep(60 * time.Second)
-					log.Info("Retrieve cpms again")
-					cpms, err = baseutils.GetControlPlaneMachineSet(kclient)
-					if err != nil {
-						log.Error(err, "could not get updated CPMS")
-					}
-					log.Info("Removing LB from cpms")
-					err = removeLoadBalancerCPMS(ctx, kclient, lbName, cpms)
-					if err != nil {
-						log.Error(err, "failed to update CPMS")
-					}
-					log.Info("Retrieve cpms again")
-					cpms, err = baseutils.GetControlPlaneMachineSet(kclient)
-					if err != nil {
-						log.Error(err, "Could not retrieve CPMS")
-					}
-					err = baseutils.SetCPMSActive(context.Background(), kclient, cpms)
-					if err != nil {
-						log.Error(err, "Could not set CPMS active")
-					} else {
-						break
-					}
-					if maxRetries == 0 {
-						log.Info("Could not set CPMS back to active after 5 attempts")
-						break
-					}
-					maxRetries = maxRetries - 1
-				}
-			}()
-			// Don't fail the following steps - setting CPMS back to active will be tried again
+			// The PublishingStrategy controller removes the LB from the recreated
+			// CPMS and sets it back to active, see ReactivateControlPlaneMachineSet
 			return nil
 		}
 	} else {
 		return func(lbName string) error {
+			// A CPMS which is still to be reactivated mustn't get the LB back
+			if instance != nil && instance.Status.ControlPlaneMachineSetReactivation != nil {
+				err := baseutils.StartCPMSReactivation(ctx, kclient, instance, lbName)
+				if err != nil {
+					return err
+				}
+			}
 			return removeGCPLBFromMasterMachines(kclient, lbName, masterList)
 		}
 	}
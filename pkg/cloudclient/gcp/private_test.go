@@ -2,25 +2,32 @@ package gcp
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
 	machineapi "github.com/openshift/api/machine/v1beta1"
 	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	gdnsv1 "google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
 	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
 )
 
 func TestGetIPAddressesFromService(t *testing.T) {
 	tests := []struct {
-		name         string
-		svc          *corev1.Service
-		expected_ips []string
-		expected_err error
+		name           string
+		svc            *corev1.Service
+		expected_ips   []string
+		expected_ipv6s []string
+		expected_err   error
 	}{
 		{
 			name: "single IP",
@@ -68,6 +75,33 @@ func TestGetIPAddressesFromService(t *testing.T) {
 				"10.0.0.1",
 			},
 		},
+		{
+			name: "dual-stack IPs",
+			svc: &corev1.Service{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Service",
+					APIVersion: corev1.SchemeGroupVersion.String(),
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{
+							{
+								IP: "10.0.0.1",
+							},
+							{
+								IP: "2600:1900:4000::1",
+							},
+						},
+					},
+				},
+			},
+			expected_ips: []string{
+				"10.0.0.1",
+			},
+			expected_ipv6s: []string{
+				"2600:1900:4000::1",
+			},
+		},
 		{
 			name: "no IPs",
 			svc: &corev1.Service{
@@ -88,6 +122,9 @@ func TestGetIPAddressesFromService(t *testing.T) {
 
 	for _, test := range tests {
 		actual, err := getIPAddressesFromService(test.svc)
+		if actual := getIPv6AddressesFromService(test.svc); !reflect.DeepEqual(actual, test.expected_ipv6s) {
+			t.Errorf("%s: expected IPv6 addresses %v, got %v", test.name, test.expected_ipv6s, actual)
+		}
 
 		if !reflect.DeepEqual(actual, test.expected_ips) {
 			t.Errorf("%s: expected %v, got %v", test.name, actual, test.expected_ips)
@@ -169,3 +206,90 @@ func Test_sanitizeZoneID(t *testing.T) {
 		t.Fatalf("sanitizeZoneId() did not return a sanitized zone ID")
 	}
 }
+
+func TestCheckRecord(t *testing.T) {
+	zone := clusterZone{zone: cloudingressv1alpha1.DNSRecordZonePrivate, id: "private"}
+	record := func(ttl int64, ips ...string) *gdnsv1.ResourceRecordSet {
+		return &gdnsv1.ResourceRecordSet{Name: "api.cluster.sut.example.com.", Type: "A", Ttl: ttl, Rrdatas: ips}
+	}
+
+	tests := []struct {
+		Name             string
+		Actual           *gdnsv1.ResourceRecordSet
+		Expected         *gdnsv1.ResourceRecordSet
+		Repair           bool
+		ExpectedDrift    *cloudingressv1alpha1.DNSRecordDrift
+		ExpectedAddition *gdnsv1.ResourceRecordSet
+	}{
+		{
+			Name:     "Should not report a record with the same addresses in another order",
+			Actual:   record(30, "10.0.0.2", "10.0.0.1"),
+			Expected: record(30, "10.0.0.1", "10.0.0.2"),
+		},
+		{
+			Name:     "Should keep the TTL of the record when none is expected",
+			Actual:   record(300, "10.0.0.1"),
+			Expected: record(0, "10.0.0.1"),
+		},
+		{
+			Name:     "Should report a record with another TTL",
+			Actual:   record(300, "10.0.0.1"),
+			Expected: record(30, "10.0.0.1"),
+			ExpectedDrift: &cloudingressv1alpha1.DNSRecordDrift{
+				Name: "api.cluster.sut.example.com.", Zone: cloudingressv1alpha1.DNSRecordZonePrivate,
+				Expected: "A 10.0.0.1 TTL 30", Actual: "A 10.0.0.1 TTL 300",
+			},
+		},
+		{
+			Name:     "Should repair a missing record with the default TTL",
+			Expected: record(0, "10.0.0.1"),
+			Repair:   true,
+			ExpectedDrift: &cloudingressv1alpha1.DNSRecordDrift{
+				Name: "api.cluster.sut.example.com.", Zone: cloudingressv1alpha1.DNSRecordZonePrivate,
+				Expected: "A 10.0.0.1 TTL 60", Repaired: true,
+			},
+			ExpectedAddition: record(defaultAPIRecordTTL, "10.0.0.1"),
+		},
+	}
+
+	for _, test := range tests {
+		var changes []*gdnsv1.Change
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				change := &gdnsv1.Change{}
+				_ = json.NewDecoder(r.Body).Decode(change)
+				changes = append(changes, change)
+				_ = json.NewEncoder(w).Encode(change)
+				return
+			}
+			response := &gdnsv1.ResourceRecordSetsListResponse{}
+			if test.Actual != nil {
+				response.Rrsets = []*gdnsv1.ResourceRecordSet{test.Actual}
+			}
+			_ = json.NewEncoder(w).Encode(response)
+		}))
+		dnsService, err := gdnsv1.NewService(context.TODO(), option.WithEndpoint(server.URL), option.WithHTTPClient(http.DefaultClient))
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: %v", test.Name, err)
+		}
+		client := &Client{projectID: "sut", dnsService: dnsService}
+
+		drift, err := client.checkRecord(context.TODO(), zone, test.Expected, test.Repair)
+		server.Close()
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(drift, test.ExpectedDrift) {
+			t.Errorf("Test [%v] FAILED: expected %+v, got %+v", test.Name, test.ExpectedDrift, drift)
+		}
+		if test.ExpectedAddition == nil {
+			if len(changes) != 0 {
+				t.Errorf("Test [%v] FAILED: unexpected changes %+v", test.Name, changes)
+			}
+			continue
+		}
+		if len(changes) != 1 || len(changes[0].Additions) != 1 || !recordSetMatches(changes[0].Additions[0], test.ExpectedAddition) {
+			t.Errorf("Test [%v] FAILED: expected %+v to be added, got %+v", test.Name, test.ExpectedAddition, changes)
+		}
+	}
+}
@@ -43,6 +43,36 @@ func (m *MockCloudClient) EXPECT() *MockCloudClientMockRecorder {
 	return m.recorder
 }
 
+// CheckAdminAPIDNS mocks base method.
+func (m *MockCloudClient) CheckAdminAPIDNS(ctx context.Context, kclient client.Client, instance *v1alpha1.APIScheme, svc *v1.Service, repair bool) ([]v1alpha1.DNSRecordDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAdminAPIDNS", ctx, kclient, instance, svc, repair)
+	ret0, _ := ret[0].([]v1alpha1.DNSRecordDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAdminAPIDNS indicates an expected call of CheckAdminAPIDNS.
+func (mr *MockCloudClientMockRecorder) CheckAdminAPIDNS(ctx, kclient, instance, svc, repair any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAdminAPIDNS", reflect.TypeOf((*MockCloudClient)(nil).CheckAdminAPIDNS), ctx, kclient, instance, svc, repair)
+}
+
+// CheckDefaultAPIDNS mocks base method.
+func (m *MockCloudClient) CheckDefaultAPIDNS(ctx context.Context, kclient client.Client, listening v1alpha1.Listening, repair bool) ([]v1alpha1.DNSRecordDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDefaultAPIDNS", ctx, kclient, listening, repair)
+	ret0, _ := ret[0].([]v1alpha1.DNSRecordDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDefaultAPIDNS indicates an expected call of CheckDefaultAPIDNS.
+func (mr *MockCloudClientMockRecorder) CheckDefaultAPIDNS(ctx, kclient, listening, repair any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDefaultAPIDNS", reflect.TypeOf((*MockCloudClient)(nil).CheckDefaultAPIDNS), ctx, kclient, listening, repair)
+}
+
 // DeleteAdminAPIDNS mocks base method.
 func (m *MockCloudClient) DeleteAdminAPIDNS(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
 	m.ctrl.T.Helper()
@@ -57,6 +87,34 @@ func (mr *MockCloudClientMockRecorder) DeleteAdminAPIDNS(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminAPIDNS", reflect.TypeOf((*MockCloudClient)(nil).DeleteAdminAPIDNS), arg0, arg1, arg2, arg3)
 }
 
+// DeleteAdminAPIDNSWithoutService mocks base method.
+func (m *MockCloudClient) DeleteAdminAPIDNSWithoutService(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminAPIDNSWithoutService", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminAPIDNSWithoutService indicates an expected call of DeleteAdminAPIDNSWithoutService.
+func (mr *MockCloudClientMockRecorder) DeleteAdminAPIDNSWithoutService(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminAPIDNSWithoutService", reflect.TypeOf((*MockCloudClient)(nil).DeleteAdminAPIDNSWithoutService), arg0, arg1, arg2)
+}
+
+// DeleteOrphanedResource mocks base method.
+func (m *MockCloudClient) DeleteOrphanedResource(ctx context.Context, kclient client.Client, resource v1alpha1.OrphanedResource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanedResource", ctx, kclient, resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrphanedResource indicates an expected call of DeleteOrphanedResource.
+func (mr *MockCloudClientMockRecorder) DeleteOrphanedResource(ctx, kclient, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedResource", reflect.TypeOf((*MockCloudClient)(nil).DeleteOrphanedResource), ctx, kclient, resource)
+}
+
 // EnsureAdminAPIDNS mocks base method.
 func (m *MockCloudClient) EnsureAdminAPIDNS(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
 	m.ctrl.T.Helper()
@@ -71,6 +129,50 @@ func (mr *MockCloudClientMockRecorder) EnsureAdminAPIDNS(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIDNS", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIDNS), arg0, arg1, arg2, arg3)
 }
 
+// EnsureControlPlaneLoadBalancers mocks base method.
+func (m *MockCloudClient) EnsureControlPlaneLoadBalancers(arg0 context.Context, arg1 client.Client, arg2 v1alpha1.Listening) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureControlPlaneLoadBalancers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureControlPlaneLoadBalancers indicates an expected call of EnsureControlPlaneLoadBalancers.
+func (mr *MockCloudClientMockRecorder) EnsureControlPlaneLoadBalancers(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureControlPlaneLoadBalancers", reflect.TypeOf((*MockCloudClient)(nil).EnsureControlPlaneLoadBalancers), arg0, arg1, arg2)
+}
+
+// FindOrphanedResources mocks base method.
+func (m *MockCloudClient) FindOrphanedResources(ctx context.Context, kclient client.Client, listening v1alpha1.Listening) ([]v1alpha1.OrphanedResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrphanedResources", ctx, kclient, listening)
+	ret0, _ := ret[0].([]v1alpha1.OrphanedResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrphanedResources indicates an expected call of FindOrphanedResources.
+func (mr *MockCloudClientMockRecorder) FindOrphanedResources(ctx, kclient, listening any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrphanedResources", reflect.TypeOf((*MockCloudClient)(nil).FindOrphanedResources), ctx, kclient, listening)
+}
+
+// GetDefaultAPIListening mocks base method.
+func (m *MockCloudClient) GetDefaultAPIListening(arg0 context.Context, arg1 client.Client) (v1alpha1.Listening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultAPIListening", arg0, arg1)
+	ret0, _ := ret[0].(v1alpha1.Listening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultAPIListening indicates an expected call of GetDefaultAPIListening.
+func (mr *MockCloudClientMockRecorder) GetDefaultAPIListening(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultAPIListening", reflect.TypeOf((*MockCloudClient)(nil).GetDefaultAPIListening), arg0, arg1)
+}
+
 // Healthcheck mocks base method.
 func (m *MockCloudClient) Healthcheck(arg0 context.Context, arg1 client.Client) error {
 	m.ctrl.T.Helper()
@@ -85,6 +187,50 @@ func (mr *MockCloudClientMockRecorder) Healthcheck(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Healthcheck", reflect.TypeOf((*MockCloudClient)(nil).Healthcheck), arg0, arg1)
 }
 
+// PlanDefaultAPIPrivate mocks base method.
+func (m *MockCloudClient) PlanDefaultAPIPrivate(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.PublishingStrategy) ([]v1alpha1.PlannedAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanDefaultAPIPrivate", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]v1alpha1.PlannedAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanDefaultAPIPrivate indicates an expected call of PlanDefaultAPIPrivate.
+func (mr *MockCloudClientMockRecorder) PlanDefaultAPIPrivate(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanDefaultAPIPrivate", reflect.TypeOf((*MockCloudClient)(nil).PlanDefaultAPIPrivate), arg0, arg1, arg2)
+}
+
+// PlanDefaultAPIPublic mocks base method.
+func (m *MockCloudClient) PlanDefaultAPIPublic(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.PublishingStrategy) ([]v1alpha1.PlannedAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanDefaultAPIPublic", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]v1alpha1.PlannedAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanDefaultAPIPublic indicates an expected call of PlanDefaultAPIPublic.
+func (mr *MockCloudClientMockRecorder) PlanDefaultAPIPublic(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanDefaultAPIPublic", reflect.TypeOf((*MockCloudClient)(nil).PlanDefaultAPIPublic), arg0, arg1, arg2)
+}
+
+// ReactivateControlPlaneMachineSet mocks base method.
+func (m *MockCloudClient) ReactivateControlPlaneMachineSet(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.PublishingStrategy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateControlPlaneMachineSet", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactivateControlPlaneMachineSet indicates an expected call of ReactivateControlPlaneMachineSet.
+func (mr *MockCloudClientMockRecorder) ReactivateControlPlaneMachineSet(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateControlPlaneMachineSet", reflect.TypeOf((*MockCloudClient)(nil).ReactivateControlPlaneMachineSet), arg0, arg1, arg2)
+}
+
 // SetDefaultAPIPrivate mocks base method.
 func (m *MockCloudClient) SetDefaultAPIPrivate(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.PublishingStrategy) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,208 @@
+// Package retry retries the failed cloud API calls from the next reconcile
+// instead of sleeping in the reconcile loop.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	"google.golang.org/api/googleapi"
+)
+
+// Class tells whether a failed cloud API call is worth retrying
+type Class string
+
+const (
+	// Permanent errors fail again when retried, such as an invalid request
+	Permanent Class = "permanent"
+	// Transient errors are likely to go away, such as a timeout or a 5xx
+	Transient Class = "transient"
+	// Throttled errors are returned when the API rate limit is exceeded
+	Throttled Class = "throttled"
+)
+
+const (
+	// baseDelay is how long to wait before the first retry of a transient error
+	baseDelay = time.Second
+	// maxDelay caps the exponential backoff
+	maxDelay = time.Minute
+)
+
+// gcpThrottleReasons are the reasons a googleapi.Error is returned with when a quota is exceeded
+var gcpThrottleReasons = []string{"rateLimitExceeded", "userRateLimitExceeded", "quotaExceeded"}
+
+// Classify sorts an error returned by the AWS or GCP SDK
+func Classify(err error) Class {
+	if err == nil {
+		return Permanent
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Transient
+	}
+
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		if request.IsErrorThrottle(aerr) {
+			return Throttled
+		}
+		var rerr awserr.RequestFailure
+		if errors.As(err, &rerr) {
+			if class, ok := classifyStatusCode(rerr.StatusCode()); ok {
+				return class
+			}
+		}
+		if request.IsErrorRetryable(aerr) {
+			return Transient
+		}
+		return Permanent
+	}
+
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		for _, item := range gerr.Errors {
+			if slices.Contains(gcpThrottleReasons, item.Reason) {
+				return Throttled
+			}
+		}
+		if class, ok := classifyStatusCode(gerr.Code); ok {
+			return class
+		}
+		return Permanent
+	}
+
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return Transient
+	}
+	return Permanent
+}
+
+func classifyStatusCode(code int) (Class, bool) {
+	switch {
+	case code == http.StatusTooManyRequests:
+		return Throttled, true
+	case code >= http.StatusInternalServerError:
+		return Transient, true
+	}
+	return "", false
+}
+
+// Error is returned instead of a throttled or transient error while there are
+// retries left. The reconcile should be requeued after After.
+type Error struct {
+	Operation string
+	Class     Class
+	Attempt   int
+	After     time.Duration
+	Err       error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s failed (%s, attempt %d), retrying in %s: %v", e.Operation, e.Class, e.Attempt, e.After, e.Err)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// RequeueAfter returns how long to wait before reconciling again when err, or
+// every error joined in it, can be retried
+func RequeueAfter(err error) (time.Duration, bool) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var after time.Duration
+		for _, e := range joined.Unwrap() {
+			d, ok := RequeueAfter(e)
+			if !ok {
+				return 0, false
+			}
+			after = max(after, d)
+		}
+		return after, after > 0
+	}
+	var rerr *Error
+	if errors.As(err, &rerr) {
+		return rerr.After, true
+	}
+	return 0, false
+}
+
+// Backoff counts the failed attempts of each call across reconciles
+type Backoff struct {
+	provider string
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+// NewBackoff returns a Backoff reporting its retries for the provider
+func NewBackoff(provider string) *Backoff {
+	return &Backoff{provider: provider, attempts: map[string]int{}}
+}
+
+// Do runs call once. key identifies the call, such as the record and zone of a
+// DNS change, and operation names it in the metrics. A throttled or transient
+// error is returned as an *Error until the call has failed
+// operatorconfig.MaxAPIRetries times, after which the error is returned as is.
+func (b *Backoff) Do(operation, key string, call func() error) error {
+	err := call()
+	key = operation + "/" + key
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		delete(b.attempts, key)
+		return nil
+	}
+	class := Classify(err)
+	if class == Permanent {
+		delete(b.attempts, key)
+		return err
+	}
+	b.attempts[key]++
+	attempt := b.attempts[key]
+	if attempt >= operatorconfig.Get().MaxAPIRetries {
+		delete(b.attempts, key)
+		localmetrics.MetricCloudAPIRetriesExhausted.WithLabelValues(b.provider, operation).Inc()
+		return fmt.Errorf("%s failed %d times: %w", operation, attempt, err)
+	}
+	localmetrics.MetricCloudAPIRetries.WithLabelValues(b.provider, operation, string(class)).Inc()
+	return &Error{Operation: operation, Class: class, Attempt: attempt, After: delay(class, attempt), Err: err}
+}
+
+// delay doubles with every attempt, starting higher for throttled calls. It
+// is jittered so the calls throttled together aren't retried together.
+func delay(class Class, attempt int) time.Duration {
+	d := baseDelay << min(attempt-1, 10)
+	if class == Throttled {
+		d *= 2
+	}
+	d = min(d, maxDelay)
+	return d/2 + rand.N(d/2+1)
+}
+
+// Parallel runs the calls with at most limit of them at the same time, and
+// returns their errors joined
+func Parallel(limit int, calls ...func() error) error {
+	sem := make(chan struct{}, max(limit, 1))
+	errs := make([]error, len(calls))
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = call()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
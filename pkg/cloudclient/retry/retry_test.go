@@ -0,0 +1,155 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	"google.golang.org/api/googleapi"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Err      error
+		Expected Class
+	}{
+		{Name: "AWS throttling", Err: awserr.New("Throttling", "Rate exceeded", nil), Expected: Throttled},
+		{Name: "Route53 change in progress", Err: awserr.New("PriorRequestNotComplete", "", nil), Expected: Throttled},
+		{Name: "AWS 429", Err: awserr.NewRequestFailure(awserr.New("SlowDown", "", nil), http.StatusTooManyRequests, "id"), Expected: Throttled},
+		{Name: "AWS 503", Err: awserr.NewRequestFailure(awserr.New("ServiceUnavailable", "", nil), http.StatusServiceUnavailable, "id"), Expected: Transient},
+		{Name: "AWS request error", Err: awserr.New("RequestError", "send request failed", nil), Expected: Transient},
+		{Name: "AWS invalid input", Err: awserr.NewRequestFailure(awserr.New("InvalidChangeBatch", "", nil), http.StatusBadRequest, "id"), Expected: Permanent},
+		{Name: "Wrapped AWS throttling", Err: fmt.Errorf("upsert: %w", awserr.New("Throttling", "", nil)), Expected: Throttled},
+		{Name: "GCP rate limit", Err: &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}, Expected: Throttled},
+		{Name: "GCP 429", Err: &googleapi.Error{Code: http.StatusTooManyRequests}, Expected: Throttled},
+		{Name: "GCP 502", Err: &googleapi.Error{Code: http.StatusBadGateway}, Expected: Transient},
+		{Name: "GCP not found", Err: &googleapi.Error{Code: http.StatusNotFound}, Expected: Permanent},
+		{Name: "Deadline", Err: context.DeadlineExceeded, Expected: Transient},
+		{Name: "Unknown", Err: errors.New("route53 Zone not found"), Expected: Permanent},
+	}
+
+	for _, test := range tests {
+		if class := Classify(test.Err); class != test.Expected {
+			t.Errorf("Test [%v] FAILED: expected %s, got %s", test.Name, test.Expected, class)
+		}
+	}
+}
+
+func TestBackoffDo(t *testing.T) {
+	c := operatorconfig.Default()
+	c.MaxAPIRetries = 3
+	operatorconfig.Set(c)
+	defer operatorconfig.Reset()
+
+	throttled := awserr.New("Throttling", "Rate exceeded", nil)
+	b := NewBackoff("aws")
+
+	for attempt := 1; attempt < 3; attempt++ {
+		err := b.Do("route53:UpsertRecord", "zone/record", func() error { return throttled })
+		var rerr *Error
+		if !errors.As(err, &rerr) {
+			t.Fatalf("Attempt %d: expected a retry, got %v", attempt, err)
+		}
+		if rerr.Attempt != attempt || rerr.Class != Throttled || !errors.Is(err, throttled) {
+			t.Errorf("Attempt %d: unexpected retry %+v", attempt, rerr)
+		}
+		if rerr.After <= 0 || rerr.After > maxDelay {
+			t.Errorf("Attempt %d: unexpected delay %s", attempt, rerr.After)
+		}
+	}
+	// The last attempt gives up
+	err := b.Do("route53:UpsertRecord", "zone/record", func() error { return throttled })
+	if _, ok := RequeueAfter(err); ok || !errors.Is(err, throttled) {
+		t.Errorf("Expected the retries to be exhausted, got %v", err)
+	}
+	// And the next call starts over
+	err = b.Do("route53:UpsertRecord", "zone/record", func() error { return throttled })
+	var rerr *Error
+	if !errors.As(err, &rerr) || rerr.Attempt != 1 {
+		t.Errorf("Expected the attempts to be reset, got %v", err)
+	}
+
+	// A success resets the attempts
+	if err := b.Do("route53:UpsertRecord", "zone/record", func() error { return nil }); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	err = b.Do("route53:UpsertRecord", "zone/record", func() error { return throttled })
+	if !errors.As(err, &rerr) || rerr.Attempt != 1 {
+		t.Errorf("Expected the attempts to be reset after a success, got %v", err)
+	}
+
+	// Permanent errors aren't retried
+	permanent := awserr.New("InvalidChangeBatch", "", nil)
+	if err := b.Do("route53:UpsertRecord", "zone/other", func() error { return permanent }); err != permanent {
+		t.Errorf("Expected the permanent error as is, got %v", err)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	for attempt := 1; attempt <= 20; attempt++ {
+		for _, class := range []Class{Transient, Throttled} {
+			d := delay(class, attempt)
+			if d <= 0 || d > maxDelay {
+				t.Errorf("Unexpected %s delay %s for attempt %d", class, d, attempt)
+			}
+		}
+	}
+	if d := delay(Transient, 1); d > baseDelay {
+		t.Errorf("Expected the first delay to be at most %s, got %s", baseDelay, d)
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	short := &Error{After: time.Second, Err: errors.New("short")}
+	long := &Error{After: time.Minute, Err: errors.New("long")}
+
+	if after, ok := RequeueAfter(errors.Join(short, long)); !ok || after != time.Minute {
+		t.Errorf("Expected the longest delay, got %s %t", after, ok)
+	}
+	if _, ok := RequeueAfter(errors.Join(short, errors.New("permanent"))); ok {
+		t.Error("A permanent error mustn't be requeued")
+	}
+	if _, ok := RequeueAfter(nil); ok {
+		t.Error("No error mustn't be requeued")
+	}
+	if after, ok := RequeueAfter(fmt.Errorf("wrapped: %w", short)); !ok || after != time.Second {
+		t.Errorf("Expected the wrapped delay, got %s %t", after, ok)
+	}
+}
+
+func TestParallel(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	calls := []func() error{}
+	for i := 0; i < 6; i++ {
+		calls = append(calls, func() error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			if i == 3 {
+				return errors.New("failed")
+			}
+			return nil
+		})
+	}
+
+	err := Parallel(2, calls...)
+	if err == nil || err.Error() != "failed" {
+		t.Errorf("Expected the error of the failed call, got %v", err)
+	}
+	if maxRunning.Load() > 2 {
+		t.Errorf("Expected at most 2 calls at the same time, got %d", maxRunning.Load())
+	}
+}
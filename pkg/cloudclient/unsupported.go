@@ -0,0 +1,97 @@
+package cloudclient
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// unsupportedClient is the CloudClient of platforms the operator can't manage
+// the cloud resources of. Every change fails with an UnsupportedPlatformError
+// and the health check passes, as there is no cloud API to check.
+type unsupportedClient struct {
+	platform configv1.PlatformType
+}
+
+func (c *unsupportedClient) err() error {
+	return cioerrors.NewUnsupportedPlatformError(string(c.platform))
+}
+
+// EnsureAdminAPIDNS implements CloudClient
+func (c *unsupportedClient) EnsureAdminAPIDNS(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error {
+	return c.err()
+}
+
+// DeleteAdminAPIDNS implements CloudClient
+func (c *unsupportedClient) DeleteAdminAPIDNS(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error {
+	return c.err()
+}
+
+// DeleteAdminAPIDNSWithoutService implements CloudClient
+func (c *unsupportedClient) DeleteAdminAPIDNSWithoutService(context.Context, client.Client, *cloudingressv1alpha1.APIScheme) error {
+	return c.err()
+}
+
+// CheckAdminAPIDNS implements CloudClient
+func (c *unsupportedClient) CheckAdminAPIDNS(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service, bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	return nil, c.err()
+}
+
+// SetDefaultAPIPrivate implements CloudClient
+func (c *unsupportedClient) SetDefaultAPIPrivate(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) error {
+	return c.err()
+}
+
+// SetDefaultAPIPublic implements CloudClient
+func (c *unsupportedClient) SetDefaultAPIPublic(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) error {
+	return c.err()
+}
+
+// PlanDefaultAPIPrivate implements CloudClient
+func (c *unsupportedClient) PlanDefaultAPIPrivate(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	return nil, c.err()
+}
+
+// PlanDefaultAPIPublic implements CloudClient
+func (c *unsupportedClient) PlanDefaultAPIPublic(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) ([]cloudingressv1alpha1.PlannedAction, error) {
+	return nil, c.err()
+}
+
+// ReactivateControlPlaneMachineSet implements CloudClient
+func (c *unsupportedClient) ReactivateControlPlaneMachineSet(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) error {
+	return c.err()
+}
+
+// EnsureControlPlaneLoadBalancers implements CloudClient
+func (c *unsupportedClient) EnsureControlPlaneLoadBalancers(context.Context, client.Client, cloudingressv1alpha1.Listening) error {
+	return c.err()
+}
+
+// GetDefaultAPIListening implements CloudClient
+func (c *unsupportedClient) GetDefaultAPIListening(context.Context, client.Client) (cloudingressv1alpha1.Listening, error) {
+	return "", c.err()
+}
+
+// CheckDefaultAPIDNS implements CloudClient
+func (c *unsupportedClient) CheckDefaultAPIDNS(context.Context, client.Client, cloudingressv1alpha1.Listening, bool) ([]cloudingressv1alpha1.DNSRecordDrift, error) {
+	return nil, c.err()
+}
+
+// FindOrphanedResources implements CloudClient
+func (c *unsupportedClient) FindOrphanedResources(context.Context, client.Client, cloudingressv1alpha1.Listening) ([]cloudingressv1alpha1.OrphanedResource, error) {
+	return nil, c.err()
+}
+
+// DeleteOrphanedResource implements CloudClient
+func (c *unsupportedClient) DeleteOrphanedResource(context.Context, client.Client, cloudingressv1alpha1.OrphanedResource) error {
+	return c.err()
+}
+
+// Healthcheck implements CloudClient
+func (c *unsupportedClient) Healthcheck(context.Context, client.Client) error {
+	return nil
+}
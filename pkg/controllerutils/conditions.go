@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"regexp"
+
 	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -29,58 +32,102 @@ func UpdateConditionIfReasonOrMessageChange(oldReason, oldMessage, newReason, ne
 		oldMessage != newMessage
 }
 
-// SetAPISchemeCondition sets a condition on a APIScheme resource's status
+// apiSchemeConditionTypes are the condition types managed on an APIScheme
+var apiSchemeConditionTypes = map[string]bool{
+	string(cloudingressv1alpha1.ConditionReady):             true,
+	string(cloudingressv1alpha1.ConditionDegraded):          true,
+	string(cloudingressv1alpha1.ConditionLoadBalancerReady): true,
+	string(cloudingressv1alpha1.ConditionDNSReady):          true,
+}
+
+// conditionReasonRegexp is the validation applied by the API server to metav1.Condition reasons
+var conditionReasonRegexp = regexp.MustCompile(`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`)
+
+// SetAPISchemeCondition sets a condition on a APIScheme resource's status.
+// Changes in status, reason or message are recorded in the status history.
 func SetAPISchemeCondition(
-	conditions []cloudingressv1alpha1.APISchemeCondition,
+	apiSchemeStatus *cloudingressv1alpha1.APISchemeStatus,
+	generation int64,
 	conditionType cloudingressv1alpha1.APISchemeConditionType,
-	status corev1.ConditionStatus,
+	status metav1.ConditionStatus,
 	reason string,
 	message string,
-	updateConditionCheck UpdateConditionCheck,
-) []cloudingressv1alpha1.APISchemeCondition {
-	now := metav1.Now()
-	existingCondition := GetLastAPISchemeCondition(conditions)
-	if existingCondition == nil && status == corev1.ConditionFalse {
-		// while the LB is being recreate the first time, we don't update the status to avoid clogging it
-		return conditions
-	}
-
+) {
+	existingCondition := meta.FindStatusCondition(apiSchemeStatus.Conditions, string(conditionType))
 	if existingCondition == nil || shouldUpdateCondition(
-		existingCondition.Status, existingCondition.Reason, existingCondition.Message,
-		status, reason, message,
-		updateConditionCheck,
+		corev1.ConditionStatus(existingCondition.Status), existingCondition.Reason, existingCondition.Message,
+		corev1.ConditionStatus(status), reason, message,
+		UpdateConditionIfReasonOrMessageChange,
 	) {
-		conditions = append(
-			conditions,
-			cloudingressv1alpha1.APISchemeCondition{
-				Type:               conditionType,
-				Status:             status,
-				Reason:             reason,
-				Message:            message,
-				LastTransitionTime: now,
-				LastProbeTime:      now,
-			},
-		)
+		now := metav1.Now()
+		appendAPISchemeHistory(apiSchemeStatus, cloudingressv1alpha1.APISchemeCondition{
+			Type:               conditionType,
+			Status:             corev1.ConditionStatus(status),
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: now,
+			LastProbeTime:      now,
+		})
 	}
 
-	return conditions
+	meta.SetStatusCondition(&apiSchemeStatus.Conditions, metav1.Condition{
+		Type:               string(conditionType),
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
 }
 
-func GetLastAPISchemeCondition(conditions []cloudingressv1alpha1.APISchemeCondition) *cloudingressv1alpha1.APISchemeCondition {
-	if len(conditions) == 0 {
-		return nil
+// MigrateAPISchemeConditions moves the conditions written by previous versions of the operator,
+// which were an unbounded list of transitions, into the status history.
+// Returns true if the status was changed.
+func MigrateAPISchemeConditions(apiSchemeStatus *cloudingressv1alpha1.APISchemeStatus) bool {
+	if !isLegacyAPISchemeConditions(apiSchemeStatus.Conditions) {
+		return false
+	}
+
+	legacy := make([]cloudingressv1alpha1.APISchemeCondition, 0, len(apiSchemeStatus.Conditions))
+	for _, condition := range apiSchemeStatus.Conditions {
+		legacy = append(legacy, cloudingressv1alpha1.APISchemeCondition{
+			Type:               cloudingressv1alpha1.APISchemeConditionType(condition.Type),
+			Status:             corev1.ConditionStatus(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime,
+			LastProbeTime:      condition.LastTransitionTime,
+		})
 	}
-	return &conditions[len(conditions)-1]
+	apiSchemeStatus.History = append(legacy, apiSchemeStatus.History...)
+	trimAPISchemeHistory(apiSchemeStatus)
+	apiSchemeStatus.Conditions = nil
+
+	return true
 }
 
-// FindAPISchemeCondition finds in the condition that has the matching condition type
-func FindAPISchemeCondition(conditions []cloudingressv1alpha1.APISchemeCondition, conditionType cloudingressv1alpha1.APISchemeConditionType) *cloudingressv1alpha1.APISchemeCondition {
-	for i, condition := range conditions {
-		if condition.Type == conditionType {
-			return &conditions[i]
+// isLegacyAPISchemeConditions detects a transition list written by previous versions of the operator:
+// repeated or unknown condition types, or reasons the API server would reject for a metav1.Condition.
+func isLegacyAPISchemeConditions(conditions []metav1.Condition) bool {
+	seen := make(map[string]bool, len(conditions))
+	for _, condition := range conditions {
+		if seen[condition.Type] || !apiSchemeConditionTypes[condition.Type] || !conditionReasonRegexp.MatchString(condition.Reason) {
+			return true
 		}
+		seen[condition.Type] = true
+	}
+	return false
+}
+
+func appendAPISchemeHistory(apiSchemeStatus *cloudingressv1alpha1.APISchemeStatus, condition cloudingressv1alpha1.APISchemeCondition) {
+	apiSchemeStatus.History = append(apiSchemeStatus.History, condition)
+	trimAPISchemeHistory(apiSchemeStatus)
+}
+
+// trimAPISchemeHistory only keeps the most recent transitions
+func trimAPISchemeHistory(apiSchemeStatus *cloudingressv1alpha1.APISchemeStatus) {
+	if len(apiSchemeStatus.History) > cloudingressv1alpha1.APISchemeHistoryLimit {
+		apiSchemeStatus.History = apiSchemeStatus.History[len(apiSchemeStatus.History)-cloudingressv1alpha1.APISchemeHistoryLimit:]
 	}
-	return nil
 }
 
 func shouldUpdateCondition(
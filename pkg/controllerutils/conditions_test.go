@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetAPISchemeCondition(t *testing.T) {
+	status := &cloudingressv1alpha1.APISchemeStatus{}
+
+	SetAPISchemeCondition(status, 1, cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonLoadBalancerNotReady, "Load balancer isn't ready")
+	SetAPISchemeCondition(status, 1, cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonLoadBalancerNotReady, "Load balancer isn't ready")
+	SetAPISchemeCondition(status, 2, cloudingressv1alpha1.ConditionLoadBalancerReady, metav1.ConditionTrue, cloudingressv1alpha1.ReasonAsExpected, "Load balancer is ready")
+
+	if len(status.Conditions) != 1 {
+		t.Fatalf("Expected a single LoadBalancerReady condition, got %+v", status.Conditions)
+	}
+	condition := meta.FindStatusCondition(status.Conditions, string(cloudingressv1alpha1.ConditionLoadBalancerReady))
+	if condition.Status != metav1.ConditionTrue || condition.ObservedGeneration != 2 {
+		t.Fatalf("Expected LoadBalancerReady to be True for generation 2, got %+v", condition)
+	}
+	// The repeated condition isn't a transition
+	if len(status.History) != 2 {
+		t.Fatalf("Expected 2 transitions in the history, got %+v", status.History)
+	}
+	if status.History[1].Reason != cloudingressv1alpha1.ReasonAsExpected {
+		t.Fatalf("Expected the latest transition last, got %+v", status.History)
+	}
+}
+
+func TestSetAPISchemeConditionHistoryIsBounded(t *testing.T) {
+	status := &cloudingressv1alpha1.APISchemeStatus{}
+
+	for i := 0; i < cloudingressv1alpha1.APISchemeHistoryLimit+5; i++ {
+		SetAPISchemeCondition(status, 1, cloudingressv1alpha1.ConditionDNSReady, metav1.ConditionFalse, cloudingressv1alpha1.ReasonDNSUpdateFailed, fmt.Sprintf("attempt %d", i))
+	}
+
+	if len(status.History) != cloudingressv1alpha1.APISchemeHistoryLimit {
+		t.Fatalf("Expected the history to be trimmed to %d, got %d", cloudingressv1alpha1.APISchemeHistoryLimit, len(status.History))
+	}
+	if last := status.History[len(status.History)-1].Message; last != fmt.Sprintf("attempt %d", cloudingressv1alpha1.APISchemeHistoryLimit+4) {
+		t.Fatalf("Expected the most recent transition to be kept, got %v", last)
+	}
+}
+
+func TestMigrateAPISchemeConditions(t *testing.T) {
+	tests := []struct {
+		Name            string
+		Conditions      []metav1.Condition
+		ExpectMigration bool
+	}{
+		{
+			Name: "Should migrate a list of transitions",
+			Conditions: []metav1.Condition{
+				{Type: "Error", Status: metav1.ConditionTrue, Reason: "Couldn't reconcile", Message: "Load balancer isn't ready"},
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Success", Message: "Admin API Endpoint created"},
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Success", Message: "Admin API Endpoint created"},
+			},
+			ExpectMigration: true,
+		},
+		{
+			Name: "Should migrate a single condition with an invalid reason",
+			Conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Couldn't reconcile", Message: "Load balancer isn't ready"},
+			},
+			ExpectMigration: true,
+		},
+		{
+			Name: "Should keep keyed conditions",
+			Conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: cloudingressv1alpha1.ReasonAsExpected},
+				{Type: "DNSReady", Status: metav1.ConditionTrue, Reason: cloudingressv1alpha1.ReasonAsExpected},
+			},
+			ExpectMigration: false,
+		},
+	}
+
+	for _, test := range tests {
+		status := &cloudingressv1alpha1.APISchemeStatus{Conditions: test.Conditions}
+		migrated := MigrateAPISchemeConditions(status)
+
+		if migrated != test.ExpectMigration {
+			t.Fatalf("Test [%v] FAILED. Expected migration %t, got %t", test.Name, test.ExpectMigration, migrated)
+		}
+		if !migrated {
+			if len(status.Conditions) != len(test.Conditions) || len(status.History) != 0 {
+				t.Fatalf("Test [%v] FAILED. Status shouldn't have changed: %+v", test.Name, status)
+			}
+			continue
+		}
+		if len(status.Conditions) != 0 {
+			t.Fatalf("Test [%v] FAILED. Expected the legacy conditions to be cleared, got %+v", test.Name, status.Conditions)
+		}
+		if len(status.History) != len(test.Conditions) {
+			t.Fatalf("Test [%v] FAILED. Expected %d history entries, got %+v", test.Name, len(test.Conditions), status.History)
+		}
+		if string(status.History[0].Type) != test.Conditions[0].Type || status.History[0].Reason != test.Conditions[0].Reason {
+			t.Fatalf("Test [%v] FAILED. Expected history to keep the legacy order, got %+v", test.Name, status.History)
+		}
+	}
+}
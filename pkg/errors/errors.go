@@ -40,3 +40,35 @@ func NewDNSUpdateError(reason string) error {
 		e: fmt.Sprintf("DNS Update Error %s", reason),
 	}
 }
+
+// UnsupportedPlatformError is returned when there is no cloud client for the
+// platform of the cluster
+type UnsupportedPlatformError struct {
+	Platform string
+}
+
+func (e *UnsupportedPlatformError) Error() string {
+	return fmt.Sprintf("platform %s isn't supported, the cloud resources won't be managed", e.Platform)
+}
+
+func NewUnsupportedPlatformError(platform string) error {
+	return &UnsupportedPlatformError{Platform: platform}
+}
+
+// CloudClientError is returned when the cloud client couldn't be created,
+// such as when the credentials Secret is missing
+type CloudClientError struct {
+	e   string
+	err error
+}
+
+func (e *CloudClientError) Error() string { return e.e }
+
+func (e *CloudClientError) Unwrap() error { return e.err }
+
+func NewCloudClientError(platform string, err error) error {
+	return &CloudClientError{
+		e:   fmt.Sprintf("couldn't create the %s cloud client: %s", platform, err),
+		err: err,
+	}
+}
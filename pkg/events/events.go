@@ -0,0 +1,89 @@
+// Package events records Kubernetes Events on the custom resource that caused
+// the operator to change something on the cloud provider or in the cluster.
+package events
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sevents "k8s.io/client-go/tools/events"
+)
+
+// Reasons of the Events recorded by the operator
+const (
+	ReasonLoadBalancerCreated                = "LoadBalancerCreated"
+	ReasonLoadBalancerCreateFailed           = "LoadBalancerCreateFailed"
+	ReasonLoadBalancerDeleted                = "LoadBalancerDeleted"
+	ReasonLoadBalancerDeleteFailed           = "LoadBalancerDeleteFailed"
+	ReasonLoadBalancerUpdated                = "LoadBalancerUpdated"
+	ReasonLoadBalancerUpdateFailed           = "LoadBalancerUpdateFailed"
+	ReasonIPAddressReserved                  = "IPAddressReserved"
+	ReasonIPAddressReleased                  = "IPAddressReleased"
+	ReasonDNSRecordUpdated                   = "DNSRecordUpdated"
+	ReasonDNSRecordDeleted                   = "DNSRecordDeleted"
+	ReasonDNSUpdateFailed                    = "DNSUpdateFailed"
+	ReasonDNSDeleteFailed                    = "DNSDeleteFailed"
+	ReasonServiceCreated                     = "ServiceCreated"
+	ReasonServiceUpdated                     = "ServiceUpdated"
+	ReasonServiceDeleted                     = "ServiceDeleted"
+	ReasonDNSNameConflict                    = "DNSNameConflict"
+	ReasonIngressControllerCreated           = "IngressControllerCreated"
+	ReasonIngressControllerPatched           = "IngressControllerPatched"
+	ReasonIngressControllerDeleted           = "IngressControllerDeleted"
+	ReasonIngressControllerFailed            = "IngressControllerFailed"
+	ReasonControlPlaneMachineSetDeleted      = "ControlPlaneMachineSetDeleted"
+	ReasonControlPlaneMachineSetFailed       = "ControlPlaneMachineSetDeleteFailed"
+	ReasonControlPlaneMachineSetActive       = "ControlPlaneMachineSetActive"
+	ReasonControlPlaneMachineSetUpdateFailed = "ControlPlaneMachineSetUpdateFailed"
+	ReasonControlPlaneLoadBalancersUpdated   = "ControlPlaneLoadBalancersUpdated"
+	ReasonAPIScopeChangeFailed               = "APIScopeChangeFailed"
+	ReasonDNSRecordDrifted                   = "DNSRecordDrifted"
+	ReasonDNSDriftCheckFailed                = "DNSDriftCheckFailed"
+	ReasonOrphanedResourceFound              = "OrphanedResourceFound"
+	ReasonOrphanedResourceDeleted            = "OrphanedResourceDeleted"
+	ReasonOrphanedResourceDeleteFailed       = "OrphanedResourceDeleteFailed"
+	ReasonOrphanedResourcesCheckFailed       = "OrphanedResourcesCheckFailed"
+)
+
+// Actions of the Events recorded by the operator
+const (
+	ActionCreate = "Create"
+	ActionUpdate = "Update"
+	ActionDelete = "Delete"
+	ActionCheck  = "Check"
+)
+
+type recorderKey struct{}
+
+type recorder struct {
+	recorder  k8sevents.EventRecorder
+	regarding runtime.Object
+}
+
+// IntoContext returns a context carrying the recorder and the object the
+// Events recorded with it are about. The cloud clients only get the context,
+// this lets them record Events without knowing which resource they work for.
+func IntoContext(ctx context.Context, r k8sevents.EventRecorder, regarding runtime.Object) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder{recorder: r, regarding: regarding})
+}
+
+// Normal records a Normal Event on the object stored in the context
+func Normal(ctx context.Context, reason, action, note string, args ...interface{}) {
+	record(ctx, corev1.EventTypeNormal, reason, action, note, args...)
+}
+
+// Warning records a Warning Event on the object stored in the context
+func Warning(ctx context.Context, reason, action, note string, args ...interface{}) {
+	record(ctx, corev1.EventTypeWarning, reason, action, note, args...)
+}
+
+// record is a no-op when the context doesn't carry a recorder, such as in
+// unit tests or when called outside of a reconcile
+func record(ctx context.Context, eventtype, reason, action, note string, args ...interface{}) {
+	r, ok := ctx.Value(recorderKey{}).(recorder)
+	if !ok || r.recorder == nil || r.regarding == nil {
+		return
+	}
+	r.recorder.Eventf(r.regarding, nil, eventtype, reason, action, note, args...)
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sevents "k8s.io/client-go/tools/events"
+)
+
+func TestRecord(t *testing.T) {
+	recorder := k8sevents.NewFakeRecorder(10)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver"}}
+	ctx := IntoContext(context.TODO(), recorder, svc)
+
+	Normal(ctx, ReasonServiceCreated, ActionCreate, "Created Service %s", svc.Name)
+	Warning(ctx, ReasonDNSUpdateFailed, ActionUpdate, "Couldn't update %s", "rh-api")
+
+	expected := []string{
+		"Normal ServiceCreated Created Service rh-api",
+		"Warning DNSUpdateFailed Couldn't update rh-api",
+	}
+	for _, e := range expected {
+		select {
+		case event := <-recorder.Events:
+			if event != e {
+				t.Fatalf("Expected event %q, got %q", e, event)
+			}
+		default:
+			t.Fatalf("Expected event %q, got none", e)
+		}
+	}
+}
+
+func TestRecordWithoutRecorder(t *testing.T) {
+	// Must not panic
+	Normal(context.TODO(), ReasonServiceCreated, ActionCreate, "Created Service")
+	Warning(IntoContext(context.TODO(), nil, &corev1.Service{}), ReasonDNSUpdateFailed, ActionUpdate, "Couldn't update")
+}
@@ -1,6 +1,8 @@
 package localmetrics
 
 import (
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -11,11 +13,152 @@ var (
 	})
 	MetricAPISchemeConditionStatus = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "cloud_ingress_operator_apischeme_status",
-		Help: "Report the status of the APIScheme status",
+		Help: "Report the status of the APIScheme status, 0 as soon as one of the APISchemes isn't ready",
+	})
+	MetricAPISchemeReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_apischeme_ready",
+		Help: "Report if the APIScheme is ready (1) or not (0)",
+	}, []string{"name"})
+	MetricCPMSReactivationPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_cpms_reactivation_pending",
+		Help: "Report if the ControlPlaneMachineSet is waiting to be set back to active",
+	})
+	MetricCPMSReactivationFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_cpms_reactivation_failures_total",
+		Help: "Number of failed attempts to set the ControlPlaneMachineSet back to active",
+	})
+	MetricCloudAPIRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_cloud_api_retries_total",
+		Help: "Number of throttled or transient cloud API call failures that were retried at a later reconcile",
+	}, []string{"provider", "operation", "class"})
+	MetricCloudAPIRetriesExhausted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_cloud_api_retries_exhausted_total",
+		Help: "Number of cloud API calls that still failed after maxAPIRetries attempts",
+	}, []string{"provider", "operation"})
+	MetricCloudAPIRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_cloud_api_requests_total",
+		Help: "Number of cloud API calls",
+	}, []string{"provider", "operation"})
+	MetricCloudAPIErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_cloud_api_errors_total",
+		Help: "Number of failed cloud API calls by error code",
+	}, []string{"provider", "operation", "code"})
+	MetricCloudAPIThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_cloud_api_throttled_total",
+		Help: "Number of cloud API calls rejected by the rate limit of the provider",
+	}, []string{"provider", "operation"})
+	MetricCloudAPIDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cloud_ingress_operator_cloud_api_request_duration_seconds",
+		Help:    "Latency of the cloud API calls, including the retries of the SDK",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{"provider", "operation"})
+	MetricPublishingStrategyDefaultAPIExternal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_publishingstrategy_default_api_external",
+		Help: "Report if the default API is external (1) or internal (0), as desired in the spec and as observed on the cloud provider",
+	}, []string{"state"})
+	MetricPublishingStrategyApplicationIngresses = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_publishingstrategy_application_ingresses",
+		Help: "Number of ApplicationIngresses in the PublishingStrategy spec",
+	})
+	MetricPublishingStrategyOwnedIngressControllers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_publishingstrategy_owned_ingresscontrollers",
+		Help: "Number of IngressControllers owned by cloud-ingress-operator",
+	})
+	MetricPublishingStrategyIngressControllersDeleting = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_publishingstrategy_ingresscontrollers_deleting",
+		Help: "Number of IngressControllers waiting for cluster-ingress-operator to finish their deletion before they are recreated",
+	})
+	MetricPublishingStrategyLastSuccessfulReconcile = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_publishingstrategy_last_successful_reconcile_timestamp_seconds",
+		Help: "Time of the last PublishingStrategy reconcile which succeeded",
+	})
+	MetricDNSRecordsDrifted = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_dns_records_drifted",
+		Help: "Number of api (PublishingStrategy) or management API (APIScheme) DNS records which didn't match their load balancer at the last check",
+	}, []string{"kind"})
+	MetricDNSDriftRepairs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_dns_drift_repairs_total",
+		Help: "Number of drifted DNS records set back to their load balancer",
+	}, []string{"kind"})
+	MetricDNSDriftCheckFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_dns_drift_check_failures_total",
+		Help: "Number of DNS drift checks which failed",
+	}, []string{"kind"})
+	MetricOrphanedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_orphaned_resources",
+		Help: "Number of cloud resources of the cluster which nothing used at the last search and weren't deleted",
+	}, []string{"kind"})
+	MetricOrphanedResourcesDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_orphaned_resources_deleted_total",
+		Help: "Number of orphaned cloud resources deleted",
+	}, []string{"kind"})
+	MetricOrphanedResourcesCheckFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_orphaned_resources_check_failures_total",
+		Help: "Number of searches for orphaned cloud resources which failed",
 	})
 
 	MetricsList = []prometheus.Collector{
 		MetricDefaultIngressController,
 		MetricAPISchemeConditionStatus,
+		MetricAPISchemeReady,
+		MetricCPMSReactivationPending,
+		MetricCPMSReactivationFailures,
+		MetricCloudAPIRetries,
+		MetricCloudAPIRetriesExhausted,
+		MetricCloudAPIRequests,
+		MetricCloudAPIErrors,
+		MetricCloudAPIThrottled,
+		MetricCloudAPIDuration,
+		MetricPublishingStrategyDefaultAPIExternal,
+		MetricPublishingStrategyApplicationIngresses,
+		MetricPublishingStrategyOwnedIngressControllers,
+		MetricPublishingStrategyIngressControllersDeleting,
+		MetricPublishingStrategyLastSuccessfulReconcile,
+		MetricDNSRecordsDrifted,
+		MetricDNSDriftRepairs,
+		MetricDNSDriftCheckFailures,
+		MetricOrphanedResources,
+		MetricOrphanedResourcesDeleted,
+		MetricOrphanedResourcesCheckFailures,
 	}
 )
+
+var (
+	apiSchemesMu    sync.Mutex
+	apiSchemesReady = map[string]bool{}
+)
+
+// SetAPISchemeReady sets the gauge of the named APIScheme, and the status of
+// the APISchemes to the worst of them
+func SetAPISchemeReady(name string, ready bool) {
+	apiSchemesMu.Lock()
+	defer apiSchemesMu.Unlock()
+	apiSchemesReady[name] = ready
+	MetricAPISchemeReady.WithLabelValues(name).Set(boolToFloat(ready))
+	setAPISchemeConditionStatus()
+}
+
+// DeleteAPISchemeReady removes the gauge of the named APIScheme, and leaves it
+// out of the status of the APISchemes
+func DeleteAPISchemeReady(name string) {
+	apiSchemesMu.Lock()
+	defer apiSchemesMu.Unlock()
+	delete(apiSchemesReady, name)
+	MetricAPISchemeReady.DeleteLabelValues(name)
+	setAPISchemeConditionStatus()
+}
+
+func setAPISchemeConditionStatus() {
+	ready := true
+	for _, r := range apiSchemesReady {
+		ready = ready && r
+	}
+	MetricAPISchemeConditionStatus.Set(boolToFloat(ready))
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
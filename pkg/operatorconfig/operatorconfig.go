@@ -0,0 +1,211 @@
+// Package operatorconfig holds the configuration the operator runs with. It
+// starts with the defaults from the config package and is replaced by the
+// CloudIngressOperatorConfig controller whenever the resource changes, so the
+// other controllers and the cloud clients read it with Get on every use.
+package operatorconfig
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/config"
+)
+
+// Config is the operator configuration in effect
+type Config struct {
+	AdminAPIName                          string
+	AdminAPIListenerPort                  int32
+	ExternalAPITargetGroupSuffix          string
+	DedicatedExternalAPITargetGroupSuffix string
+	MaxAPIRetries                         int
+	AWSSecretName                         string
+	GCPSecretName                         string
+	AzureSecretName                       string
+	ShortRequeueInterval                  time.Duration
+	LongRequeueInterval                   time.Duration
+	ELBIdleTimeoutSeconds                 int32
+	DNSDriftPolicy                        v1alpha1.DNSDriftPolicy
+	DNSDriftCheckInterval                 time.Duration
+	OrphanedResourcesPolicy               v1alpha1.OrphanedResourcesPolicy
+	OrphanedResourcesCheckInterval        time.Duration
+	OrphanedResourcesGracePeriod          time.Duration
+}
+
+var targetGroupSuffixRegexp = regexp.MustCompile(`^[a-z0-9]{1,4}$`)
+
+var (
+	mu      sync.RWMutex
+	current = Default()
+)
+
+// Default returns the configuration built into the operator
+func Default() Config {
+	return Config{
+		AdminAPIName:                          config.AdminAPIName,
+		AdminAPIListenerPort:                  int32(config.AdminAPIListenerPort),
+		ExternalAPITargetGroupSuffix:          config.ExternalAPITargetGroupSuffix,
+		DedicatedExternalAPITargetGroupSuffix: config.DedicatedExternalAPITargetGroupSuffix,
+		MaxAPIRetries:                         config.MaxAPIRetries,
+		AWSSecretName:                         config.AWSSecretName,
+		GCPSecretName:                         config.GCPSecretName,
+		AzureSecretName:                       config.AzureSecretName,
+		ShortRequeueInterval:                  config.ShortRequeueInterval,
+		LongRequeueInterval:                   config.LongRequeueInterval,
+		ELBIdleTimeoutSeconds:                 config.ELBIdleTimeoutSeconds,
+		DNSDriftPolicy:                        v1alpha1.DNSDriftPolicy(config.DNSDriftPolicy),
+		DNSDriftCheckInterval:                 config.DNSDriftCheckInterval,
+		OrphanedResourcesPolicy:               v1alpha1.OrphanedResourcesPolicy(config.OrphanedResourcesPolicy),
+		OrphanedResourcesCheckInterval:        config.OrphanedResourcesCheckInterval,
+		OrphanedResourcesGracePeriod:          config.OrphanedResourcesGracePeriod,
+	}
+}
+
+// Get returns the configuration in effect
+func Get() Config {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Set replaces the configuration in effect
+func Set(c Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = c
+}
+
+// Reset goes back to the default configuration
+func Reset() {
+	Set(Default())
+}
+
+// FromSpec returns the configuration described by a CloudIngressOperatorConfig
+// spec. Empty fields keep their default. The CRD schema already validates the
+// fields, they are checked again here as the spec could have been written
+// before the schema was installed.
+func FromSpec(spec v1alpha1.CloudIngressOperatorConfigSpec) (Config, error) {
+	c := Default()
+	if spec.AdminAPIName != "" {
+		c.AdminAPIName = spec.AdminAPIName
+	}
+	if spec.AdminAPIListenerPort != 0 {
+		c.AdminAPIListenerPort = spec.AdminAPIListenerPort
+	}
+	if spec.TargetGroupSuffixes.ExternalAPI != "" {
+		c.ExternalAPITargetGroupSuffix = spec.TargetGroupSuffixes.ExternalAPI
+	}
+	if spec.TargetGroupSuffixes.DedicatedExternalAPI != "" {
+		c.DedicatedExternalAPITargetGroupSuffix = spec.TargetGroupSuffixes.DedicatedExternalAPI
+	}
+	if spec.MaxAPIRetries != 0 {
+		c.MaxAPIRetries = int(spec.MaxAPIRetries)
+	}
+	if spec.CredentialsSecrets.AWS != "" {
+		c.AWSSecretName = spec.CredentialsSecrets.AWS
+	}
+	if spec.CredentialsSecrets.GCP != "" {
+		c.GCPSecretName = spec.CredentialsSecrets.GCP
+	}
+	if spec.CredentialsSecrets.Azure != "" {
+		c.AzureSecretName = spec.CredentialsSecrets.Azure
+	}
+	if spec.RequeueIntervals.Short != nil {
+		c.ShortRequeueInterval = spec.RequeueIntervals.Short.Duration
+	}
+	if spec.RequeueIntervals.Long != nil {
+		c.LongRequeueInterval = spec.RequeueIntervals.Long.Duration
+	}
+	if spec.ELBIdleTimeoutSeconds != 0 {
+		c.ELBIdleTimeoutSeconds = spec.ELBIdleTimeoutSeconds
+	}
+	if spec.DNSDrift.Policy != "" {
+		c.DNSDriftPolicy = spec.DNSDrift.Policy
+	}
+	if spec.DNSDrift.CheckInterval != nil {
+		c.DNSDriftCheckInterval = spec.DNSDrift.CheckInterval.Duration
+	}
+	if spec.OrphanedResources.Policy != "" {
+		c.OrphanedResourcesPolicy = spec.OrphanedResources.Policy
+	}
+	if spec.OrphanedResources.CheckInterval != nil {
+		c.OrphanedResourcesCheckInterval = spec.OrphanedResources.CheckInterval.Duration
+	}
+	if spec.OrphanedResources.GracePeriod != nil {
+		c.OrphanedResourcesGracePeriod = spec.OrphanedResources.GracePeriod.Duration
+	}
+
+	if errs := validate(c); len(errs) > 0 {
+		return Config{}, errs.ToAggregate()
+	}
+	return c, nil
+}
+
+func validate(c Config) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	for _, msg := range validation.IsDNS1123Label(c.AdminAPIName) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("adminAPIName"), c.AdminAPIName, msg))
+	}
+	for _, msg := range validation.IsValidPortNum(int(c.AdminAPIListenerPort)) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("adminAPIListenerPort"), c.AdminAPIListenerPort, msg))
+	}
+	// target group names are limited to 32 characters, the infrastructure name takes up to 27 of them
+	if !targetGroupSuffixRegexp.MatchString(c.ExternalAPITargetGroupSuffix) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("targetGroupSuffixes", "externalAPI"), c.ExternalAPITargetGroupSuffix, "must be 1 to 4 lowercase alphanumeric characters"))
+	}
+	if !targetGroupSuffixRegexp.MatchString(c.DedicatedExternalAPITargetGroupSuffix) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("targetGroupSuffixes", "dedicatedExternalAPI"), c.DedicatedExternalAPITargetGroupSuffix, "must be 1 to 4 lowercase alphanumeric characters"))
+	} else if c.DedicatedExternalAPITargetGroupSuffix == c.ExternalAPITargetGroupSuffix {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("targetGroupSuffixes", "dedicatedExternalAPI"), c.DedicatedExternalAPITargetGroupSuffix, "must differ from externalAPI"))
+	}
+	if c.MaxAPIRetries < 1 || c.MaxAPIRetries > 100 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxAPIRetries"), c.MaxAPIRetries, "must be between 1 and 100"))
+	}
+	secretsPath := specPath.Child("credentialsSecrets")
+	for _, msg := range validation.IsDNS1123Subdomain(c.AWSSecretName) {
+		allErrs = append(allErrs, field.Invalid(secretsPath.Child("aws"), c.AWSSecretName, msg))
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(c.GCPSecretName) {
+		allErrs = append(allErrs, field.Invalid(secretsPath.Child("gcp"), c.GCPSecretName, msg))
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(c.AzureSecretName) {
+		allErrs = append(allErrs, field.Invalid(secretsPath.Child("azure"), c.AzureSecretName, msg))
+	}
+	intervalsPath := specPath.Child("requeueIntervals")
+	if c.ShortRequeueInterval <= 0 {
+		allErrs = append(allErrs, field.Invalid(intervalsPath.Child("short"), c.ShortRequeueInterval.String(), "must be positive"))
+	}
+	if c.LongRequeueInterval <= 0 {
+		allErrs = append(allErrs, field.Invalid(intervalsPath.Child("long"), c.LongRequeueInterval.String(), "must be positive"))
+	}
+	// the maximum idle timeout of an AWS load balancer
+	if c.ELBIdleTimeoutSeconds < 1 || c.ELBIdleTimeoutSeconds > 4000 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("elbIdleTimeoutSeconds"), c.ELBIdleTimeoutSeconds, "must be between 1 and 4000"))
+	}
+	dnsDriftPath := specPath.Child("dnsDrift")
+	if c.DNSDriftPolicy != v1alpha1.DNSDriftReportOnly && c.DNSDriftPolicy != v1alpha1.DNSDriftAutoHeal {
+		allErrs = append(allErrs, field.NotSupported(dnsDriftPath.Child("policy"), c.DNSDriftPolicy, []string{string(v1alpha1.DNSDriftReportOnly), string(v1alpha1.DNSDriftAutoHeal)}))
+	}
+	// every check lists the records of both zones, which counts against the provider's rate limits
+	if c.DNSDriftCheckInterval < time.Minute {
+		allErrs = append(allErrs, field.Invalid(dnsDriftPath.Child("checkInterval"), c.DNSDriftCheckInterval.String(), "must be at least 1m"))
+	}
+	orphanedResourcesPath := specPath.Child("orphanedResources")
+	if c.OrphanedResourcesPolicy != v1alpha1.OrphanedResourcesReportOnly && c.OrphanedResourcesPolicy != v1alpha1.OrphanedResourcesDelete {
+		allErrs = append(allErrs, field.NotSupported(orphanedResourcesPath.Child("policy"), c.OrphanedResourcesPolicy, []string{string(v1alpha1.OrphanedResourcesReportOnly), string(v1alpha1.OrphanedResourcesDelete)}))
+	}
+	// every search lists the load balancers, addresses and records of the cluster
+	if c.OrphanedResourcesCheckInterval < time.Minute {
+		allErrs = append(allErrs, field.Invalid(orphanedResourcesPath.Child("checkInterval"), c.OrphanedResourcesCheckInterval.String(), "must be at least 1m"))
+	}
+	if c.OrphanedResourcesGracePeriod < 0 {
+		allErrs = append(allErrs, field.Invalid(orphanedResourcesPath.Child("gracePeriod"), c.OrphanedResourcesGracePeriod.String(), "must not be negative"))
+	}
+	return allErrs
+}
@@ -0,0 +1,115 @@
+package operatorconfig
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+)
+
+func TestFromSpec(t *testing.T) {
+	custom := Default()
+	custom.AdminAPIName = "admin-api"
+	custom.ExternalAPITargetGroupSuffix = "ext2"
+	custom.DedicatedExternalAPITargetGroupSuffix = "ext3"
+	custom.MaxAPIRetries = 3
+	custom.LongRequeueInterval = 2 * time.Minute
+	custom.ELBIdleTimeoutSeconds = 600
+	custom.DNSDriftPolicy = v1alpha1.DNSDriftAutoHeal
+	custom.OrphanedResourcesPolicy = v1alpha1.OrphanedResourcesDelete
+
+	tests := []struct {
+		Name          string
+		Spec          v1alpha1.CloudIngressOperatorConfigSpec
+		Expected      Config
+		ErrorExpected bool
+	}{
+		{
+			Name:     "Should use the defaults for an empty spec",
+			Expected: Default(),
+		},
+		{
+			Name: "Should only override the fields which are set",
+			Spec: v1alpha1.CloudIngressOperatorConfigSpec{
+				AdminAPIName:          "admin-api",
+				TargetGroupSuffixes:   v1alpha1.TargetGroupSuffixes{ExternalAPI: "ext2", DedicatedExternalAPI: "ext3"},
+				MaxAPIRetries:         3,
+				RequeueIntervals:      v1alpha1.RequeueIntervals{Long: &metav1.Duration{Duration: 2 * time.Minute}},
+				ELBIdleTimeoutSeconds: 600,
+				DNSDrift:              v1alpha1.DNSDrift{Policy: v1alpha1.DNSDriftAutoHeal},
+				OrphanedResources:     v1alpha1.OrphanedResources{Policy: v1alpha1.OrphanedResourcesDelete},
+			},
+			Expected: custom,
+		},
+		{
+			Name:          "Should reject an admin API name which isn't a DNS label",
+			Spec:          v1alpha1.CloudIngressOperatorConfigSpec{AdminAPIName: "Admin_API"},
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Should reject a target group suffix which would make the name too long",
+			Spec:          v1alpha1.CloudIngressOperatorConfigSpec{TargetGroupSuffixes: v1alpha1.TargetGroupSuffixes{ExternalAPI: "external"}},
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Should reject a dedicated target group suffix naming the installer's target group",
+			Spec:          v1alpha1.CloudIngressOperatorConfigSpec{TargetGroupSuffixes: v1alpha1.TargetGroupSuffixes{DedicatedExternalAPI: "aext"}},
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Should reject a negative requeue interval",
+			Spec:          v1alpha1.CloudIngressOperatorConfigSpec{RequeueIntervals: v1alpha1.RequeueIntervals{Short: &metav1.Duration{Duration: -time.Second}}},
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Should reject an idle timeout above the AWS maximum",
+			Spec:          v1alpha1.CloudIngressOperatorConfigSpec{ELBIdleTimeoutSeconds: 4001},
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Should reject an unknown DNS drift policy",
+			Spec:          v1alpha1.CloudIngressOperatorConfigSpec{DNSDrift: v1alpha1.DNSDrift{Policy: "Delete"}},
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Should reject a DNS drift check interval below a minute",
+			Spec:          v1alpha1.CloudIngressOperatorConfigSpec{DNSDrift: v1alpha1.DNSDrift{CheckInterval: &metav1.Duration{Duration: 10 * time.Second}}},
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Should reject an unknown orphaned resources policy",
+			Spec:          v1alpha1.CloudIngressOperatorConfigSpec{OrphanedResources: v1alpha1.OrphanedResources{Policy: "AutoHeal"}},
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Should reject a negative grace period for the orphaned resources",
+			Spec:          v1alpha1.CloudIngressOperatorConfigSpec{OrphanedResources: v1alpha1.OrphanedResources{GracePeriod: &metav1.Duration{Duration: -time.Hour}}},
+			ErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		c, err := FromSpec(test.Spec)
+		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
+			t.Fatalf("Test [%v] return mismatch. Expect error? %t: Return %+v", test.Name, test.ErrorExpected, err)
+		}
+		if !test.ErrorExpected && c != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %+v. Got %+v", test.Name, test.Expected, c)
+		}
+	}
+}
+
+func TestSetAndReset(t *testing.T) {
+	c := Default()
+	c.MaxAPIRetries = 1
+	Set(c)
+	if Get().MaxAPIRetries != 1 {
+		t.Fatalf("Expected the configuration to be replaced, got %+v", Get())
+	}
+	Reset()
+	if Get() != Default() {
+		t.Fatalf("Expected the default configuration, got %+v", Get())
+	}
+}
@@ -24,6 +24,7 @@ const masterMachineLabel string = "machine.openshift.io/cluster-api-machine-role
 
 const DefaultRegionName string = "us-east-1"
 const DefaultAzName string = "us-east-1a"
+const DefaultProjectID string = "unit-test-project"
 const DefaultAPIEndpoint string = "https://api.unit.test:6443"
 const DefaultClusterDomain string = "unit.test"
 const DefaultAMIID string = "ami-123456"
@@ -460,7 +461,31 @@ func CreateGCPInfraObject(infraName, apiInternalURL, apiURL, region string) *con
 			PlatformStatus: &configv1.PlatformStatus{
 				Type: configv1.GCPPlatformType,
 				GCP: &configv1.GCPPlatformStatus{
-					Region: region,
+					ProjectID: DefaultProjectID,
+					Region:    region,
+				},
+			},
+		},
+	}
+}
+
+// CreateAzureInfraObject creates a configv1.Infrastructure object for an Azure cluster
+func CreateAzureInfraObject(infraName, apiInternalURL, apiURL, resourceGroup string) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "",
+		},
+		Status: configv1.InfrastructureStatus{
+			InfrastructureName:   infraName,
+			APIServerInternalURL: apiInternalURL,
+			APIServerURL:         apiURL,
+			Platform:             configv1.AzurePlatformType,
+			PlatformStatus: &configv1.PlatformStatus{
+				Type: configv1.AzurePlatformType,
+				Azure: &configv1.AzurePlatformStatus{
+					ResourceGroupName: resourceGroup,
+					CloudName:         configv1.AzurePublicCloud,
 				},
 			},
 		},
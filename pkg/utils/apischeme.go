@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AdminAPIServiceNamespace is the namespace of the Services of the management
+// APIs
+const AdminAPIServiceNamespace = "openshift-kube-apiserver"
+
+// AdminAPIServiceAppLabel returns the app label of the Services of the
+// management API named dnsName
+func AdminAPIServiceAppLabel(dnsName string) string {
+	return "cloud-ingress-operator-" + dnsName
+}
+
+// AdminAPIServices returns the Services of the management API named dnsName,
+// the one named after it and those with its app label, the oldest first.
+// There are several while the management API moves to another load balancer:
+// its records lead to the oldest one until the newest one is ready.
+func AdminAPIServices(ctx context.Context, kclient client.Client, dnsName string) ([]corev1.Service, error) {
+	serviceList := &corev1.ServiceList{}
+	err := kclient.List(ctx, serviceList, client.InNamespace(AdminAPIServiceNamespace),
+		client.MatchingLabels{"app": AdminAPIServiceAppLabel(dnsName)})
+	if err != nil {
+		return nil, err
+	}
+	services := serviceList.Items
+	if !slices.ContainsFunc(services, func(svc corev1.Service) bool { return svc.Name == dnsName }) {
+		// Created without the label, or by something else
+		svc := corev1.Service{}
+		err := kclient.Get(ctx, types.NamespacedName{Name: dnsName, Namespace: AdminAPIServiceNamespace}, &svc)
+		if err == nil {
+			services = append(services, svc)
+		} else if !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	// The Service named after the DNS name was the first one, the names
+	// break the other ties
+	slices.SortFunc(services, func(a, b corev1.Service) int {
+		switch {
+		case !a.CreationTimestamp.Equal(&b.CreationTimestamp):
+			if a.CreationTimestamp.Before(&b.CreationTimestamp) {
+				return -1
+			}
+			return 1
+		case a.Name == dnsName:
+			return -1
+		case b.Name == dnsName:
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return services, nil
+}
+
+// UnusedAdminAPINames returns the admin API names, the one from the operator
+// config and those of the APISchemes, which no enabled APIScheme uses. The
+// name of an APIScheme being deleted counts as used, as the APIScheme
+// controller is still removing its records.
+func UnusedAdminAPINames(ctx context.Context, kclient client.Client) ([]string, error) {
+	apiSchemes := &cloudingressv1alpha1.APISchemeList{}
+	if err := kclient.List(ctx, apiSchemes); err != nil {
+		return nil, err
+	}
+	names := []string{operatorconfig.Get().AdminAPIName}
+	used := map[string]bool{}
+	for _, apiScheme := range apiSchemes.Items {
+		name := apiScheme.Spec.ManagementAPIServerIngress.DNSName
+		if name == "" {
+			continue
+		}
+		if apiScheme.Spec.ManagementAPIServerIngress.Enabled || !apiScheme.DeletionTimestamp.IsZero() {
+			used[name] = true
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return slices.DeleteFunc(names, func(name string) bool { return used[name] }), nil
+}
+
+// ReservedAdminAPINames are the DNS names of the cluster records, which an
+// APIScheme can't use
+var ReservedAdminAPINames = []string{"api", "api-int"}
+
+// ConflictingAPIScheme returns the APIScheme which holds the DNS name of the
+// management API of the given one, nil when there is none. Among enabled
+// APISchemes with the same name, the oldest one holds it. An APIScheme being
+// deleted never holds its name, so any other enabled APIScheme with the name
+// is returned to take it over.
+func ConflictingAPIScheme(ctx context.Context, kclient client.Client, apiScheme *cloudingressv1alpha1.APIScheme) (*cloudingressv1alpha1.APIScheme, error) {
+	apiSchemes := &cloudingressv1alpha1.APISchemeList{}
+	if err := kclient.List(ctx, apiSchemes); err != nil {
+		return nil, err
+	}
+	name := apiScheme.Spec.ManagementAPIServerIngress.DNSName
+	for i := range apiSchemes.Items {
+		other := &apiSchemes.Items[i]
+		if other.Namespace == apiScheme.Namespace && other.Name == apiScheme.Name {
+			continue
+		}
+		if !other.Spec.ManagementAPIServerIngress.Enabled || !other.DeletionTimestamp.IsZero() ||
+			other.Spec.ManagementAPIServerIngress.DNSName != name {
+			continue
+		}
+		if !apiScheme.DeletionTimestamp.IsZero() || olderAPIScheme(other, apiScheme) {
+			return other, nil
+		}
+	}
+	return nil, nil
+}
+
+// olderAPIScheme tells whether a was created before b. An APIScheme which
+// isn't created yet is the newest, the names break ties.
+func olderAPIScheme(a, b *cloudingressv1alpha1.APIScheme) bool {
+	switch {
+	case b.CreationTimestamp.IsZero():
+		return true
+	case a.CreationTimestamp.IsZero():
+		return false
+	case !a.CreationTimestamp.Equal(&b.CreationTimestamp):
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func testAPIScheme(name, dnsName string, enabled bool) *cloudingressv1alpha1.APIScheme {
+	return &cloudingressv1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openshift-cloud-ingress-operator"},
+		Spec: cloudingressv1alpha1.APISchemeSpec{
+			ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{Enabled: enabled, DNSName: dnsName},
+		},
+	}
+}
+
+func TestUnusedAdminAPINames(t *testing.T) {
+	tests := []struct {
+		Name       string
+		APISchemes []runtime.Object
+		Expected   []string
+	}{
+		{
+			Name:     "Should report the default name without APIScheme",
+			Expected: []string{"rh-api"},
+		},
+		{
+			Name:       "Should not report the name of an enabled APIScheme",
+			APISchemes: []runtime.Object{testAPIScheme("rh-api", "rh-api", true)},
+			Expected:   []string{},
+		},
+		{
+			Name:       "Should report the names of the disabled APISchemes",
+			APISchemes: []runtime.Object{testAPIScheme("rh-api", "rh-api", true), testAPIScheme("old", "old-api", false)},
+			Expected:   []string{"old-api"},
+		},
+	}
+
+	for _, test := range tests {
+		mocks := testutils.NewTestMock(t, test.APISchemes)
+		names, err := UnusedAdminAPINames(context.TODO(), mocks.FakeKubeClient)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(names, test.Expected) {
+			t.Errorf("Test [%v] FAILED: expected %v, got %v", test.Name, test.Expected, names)
+		}
+	}
+}
+
+func TestConflictingAPIScheme(t *testing.T) {
+	created := func(apiScheme *cloudingressv1alpha1.APIScheme, age time.Duration) *cloudingressv1alpha1.APIScheme {
+		apiScheme.CreationTimestamp = metav1.NewTime(time.Now().Add(-age).Truncate(time.Second))
+		return apiScheme
+	}
+	deleting := func(apiScheme *cloudingressv1alpha1.APIScheme) *cloudingressv1alpha1.APIScheme {
+		now := metav1.Now()
+		apiScheme.DeletionTimestamp = &now
+		apiScheme.Finalizers = []string{"dns.cloudingress.managed.openshift.io"}
+		return apiScheme
+	}
+
+	tests := []struct {
+		Name       string
+		APIScheme  *cloudingressv1alpha1.APIScheme
+		APISchemes []runtime.Object
+		Expected   string
+	}{
+		{
+			Name:       "Should allow different DNS names",
+			APIScheme:  created(testAPIScheme("partner", "partner-api", true), time.Minute),
+			APISchemes: []runtime.Object{created(testAPIScheme("rh-api", "rh-api", true), time.Hour)},
+		},
+		{
+			Name:       "Should report the older APIScheme with the same DNS name",
+			APIScheme:  created(testAPIScheme("partner", "rh-api", true), time.Minute),
+			APISchemes: []runtime.Object{created(testAPIScheme("rh-api", "rh-api", true), time.Hour)},
+			Expected:   "rh-api",
+		},
+		{
+			Name:       "Should keep the DNS name of the older APIScheme",
+			APIScheme:  created(testAPIScheme("rh-api", "rh-api", true), time.Hour),
+			APISchemes: []runtime.Object{created(testAPIScheme("partner", "rh-api", true), time.Minute)},
+		},
+		{
+			Name:       "Should report any APIScheme to a new one",
+			APIScheme:  testAPIScheme("partner", "rh-api", true),
+			APISchemes: []runtime.Object{created(testAPIScheme("rh-api", "rh-api", true), time.Hour)},
+			Expected:   "rh-api",
+		},
+		{
+			Name:       "Should ignore disabled and deleted APISchemes",
+			APIScheme:  created(testAPIScheme("partner", "rh-api", true), time.Minute),
+			APISchemes: []runtime.Object{created(testAPIScheme("old", "rh-api", false), time.Hour), deleting(created(testAPIScheme("rh-api", "rh-api", true), time.Hour))},
+		},
+		{
+			Name:       "Should hand the DNS name of a deleted APIScheme over",
+			APIScheme:  deleting(created(testAPIScheme("rh-api", "rh-api", true), time.Hour)),
+			APISchemes: []runtime.Object{created(testAPIScheme("partner", "rh-api", true), time.Minute)},
+			Expected:   "partner",
+		},
+	}
+
+	for _, test := range tests {
+		mocks := testutils.NewTestMock(t, append(test.APISchemes, test.APIScheme))
+		other, err := ConflictingAPIScheme(context.TODO(), mocks.FakeKubeClient, test.APIScheme)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		name := ""
+		if other != nil {
+			name = other.Name
+		}
+		if name != test.Expected {
+			t.Errorf("Test [%v] FAILED: expected %q, got %q", test.Name, test.Expected, name)
+		}
+	}
+}
+
+func TestAdminAPIServices(t *testing.T) {
+	service := func(name, app string, age time.Duration) *corev1.Service {
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         AdminAPIServiceNamespace,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age).Truncate(time.Second)),
+		}}
+		if app != "" {
+			svc.Labels = map[string]string{"app": AdminAPIServiceAppLabel(app)}
+		}
+		return svc
+	}
+	objs := []runtime.Object{
+		// Moving from the Service named after the DNS name, created without the label
+		service("rh-api-3", "rh-api", time.Minute),
+		service("rh-api", "", time.Hour),
+		// Another management API
+		service("partner-api", "partner-api", time.Hour),
+	}
+	mocks := testutils.NewTestMock(t, objs)
+
+	services, err := AdminAPIServices(context.TODO(), mocks.FakeKubeClient, "rh-api")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	var names []string
+	for _, svc := range services {
+		names = append(names, svc.Name)
+	}
+	if expected := []string{"rh-api", "rh-api-3"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected the Services %v, oldest first, got %v", expected, names)
+	}
+
+	services, err = AdminAPIServices(context.TODO(), mocks.FakeKubeClient, "other-api")
+	if err != nil || len(services) != 0 {
+		t.Errorf("Expected no Service, got %v %v", services, err)
+	}
+}
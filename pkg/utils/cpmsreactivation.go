@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	cioevents "github.com/openshift/cloud-ingress-operator/pkg/events"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CPMSLoadBalancerRemovalFunc removes a load balancer from the provider spec of the CPMS
+type CPMSLoadBalancerRemovalFunc func(ctx context.Context, kclient client.Client, lbName string, cpms *machinev1.ControlPlaneMachineSet) error
+
+// StartCPMSReactivation records in the PublishingStrategy status that lbName
+// has to be removed from the CPMS before it is set back to active. The status
+// is written right away, so it has to be called before the CPMS is deleted to
+// have the reactivation resumed if the operator restarts in between.
+func StartCPMSReactivation(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy, lbName string) error {
+	if instance == nil {
+		return fmt.Errorf("can't track the reactivation of the controlplanemachineset without a publishingstrategy")
+	}
+	reactivation := instance.Status.ControlPlaneMachineSetReactivation
+	if reactivation == nil {
+		reactivation = &cloudingressv1alpha1.ControlPlaneMachineSetReactivation{StartTime: metav1.Now()}
+		instance.Status.ControlPlaneMachineSetReactivation = reactivation
+	}
+	reactivation.Phase = cloudingressv1alpha1.CPMSReactivationRemovingLoadBalancers
+	if !slices.Contains(reactivation.LoadBalancers, lbName) {
+		reactivation.LoadBalancers = append(reactivation.LoadBalancers, lbName)
+	}
+	if err := kclient.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("could not record the reactivation of the controlplanemachineset: %w", err)
+	}
+	localmetrics.MetricCPMSReactivationPending.Set(1)
+	return nil
+}
+
+// ResumeCPMSReactivation advances the reactivation recorded in the
+// PublishingStrategy status by one step: the load balancers are removed from
+// the CPMS once it has been recreated, then it is set back to active. The
+// progress is kept in the status, which the caller has to write back. The
+// reactivation is done when ControlPlaneMachineSetReactivation is nil.
+func ResumeCPMSReactivation(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy, removeLoadBalancer CPMSLoadBalancerRemovalFunc) error {
+	reactivation := instance.Status.ControlPlaneMachineSetReactivation
+	if reactivation == nil {
+		localmetrics.MetricCPMSReactivationPending.Set(0)
+		return nil
+	}
+	localmetrics.MetricCPMSReactivationPending.Set(1)
+
+	cpms, err := GetControlPlaneMachineSet(kclient)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// The CPMS hasn't been recreated yet
+			return nil
+		}
+		return failCPMSReactivation(ctx, reactivation, err)
+	}
+	if cpms.Spec.State == machinev1.ControlPlaneMachineSetStateActive {
+		// Either it was set active by someone else or the CPMS was never deleted
+		completeCPMSReactivation(ctx, instance)
+		return nil
+	}
+
+	if reactivation.Phase == cloudingressv1alpha1.CPMSReactivationRemovingLoadBalancers {
+		for _, lbName := range reactivation.LoadBalancers {
+			if err := removeLoadBalancer(ctx, kclient, lbName, cpms); err != nil {
+				return failCPMSReactivation(ctx, reactivation, fmt.Errorf("could not remove load balancer %s: %w", lbName, err))
+			}
+		}
+		reactivation.Phase = cloudingressv1alpha1.CPMSReactivationActivating
+	}
+
+	if err := SetCPMSActive(ctx, kclient, cpms); err != nil {
+		return failCPMSReactivation(ctx, reactivation, fmt.Errorf("could not set the controlplanemachineset active: %w", err))
+	}
+	completeCPMSReactivation(ctx, instance)
+	return nil
+}
+
+func failCPMSReactivation(ctx context.Context, reactivation *cloudingressv1alpha1.ControlPlaneMachineSetReactivation, err error) error {
+	now := metav1.Now()
+	reactivation.Attempts++
+	reactivation.LastAttemptTime = &now
+	reactivation.LastError = err.Error()
+	localmetrics.MetricCPMSReactivationFailures.Inc()
+	cioevents.Warning(ctx, cioevents.ReasonControlPlaneMachineSetUpdateFailed, cioevents.ActionUpdate, "Couldn't set the ControlPlaneMachineSet back to active (attempt %d): %v", reactivation.Attempts, err)
+	return err
+}
+
+func completeCPMSReactivation(ctx context.Context, instance *cloudingressv1alpha1.PublishingStrategy) {
+	instance.Status.ControlPlaneMachineSetReactivation = nil
+	localmetrics.MetricCPMSReactivationPending.Set(0)
+	cioevents.Normal(ctx, cioevents.ReasonControlPlaneMachineSetActive, cioevents.ActionUpdate, "Set the ControlPlaneMachineSet back to active")
+}
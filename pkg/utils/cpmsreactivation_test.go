@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func inactiveCPMS() *machinev1.ControlPlaneMachineSet {
+	return &machinev1.ControlPlaneMachineSet{
+		ObjectMeta: metav1.ObjectMeta{Name: cpmsName, Namespace: machineApiNamespace},
+		Spec:       machinev1.ControlPlaneMachineSetSpec{State: machinev1.ControlPlaneMachineSetStateInactive},
+	}
+}
+
+func TestStartCPMSReactivation(t *testing.T) {
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	instance := &cloudingressv1alpha1.PublishingStrategy{ObjectMeta: metav1.ObjectMeta{Name: "publishingstrategy", Namespace: "openshift-cloud-ingress-operator"}}
+	kclient := fake.NewClientBuilder().WithScheme(mocks.Scheme).WithObjects(instance).WithStatusSubresource(instance).Build()
+
+	for _, lbName := range []string{"sut-ext", "sut-ext", "sut-other"} {
+		if err := StartCPMSReactivation(context.TODO(), kclient, instance, lbName); err != nil {
+			t.Fatalf("Couldn't start the reactivation: %v", err)
+		}
+	}
+
+	// The reactivation must be persisted, not only set on the instance
+	persisted := &cloudingressv1alpha1.PublishingStrategy{}
+	if err := kclient.Get(context.TODO(), client.ObjectKeyFromObject(instance), persisted); err != nil {
+		t.Fatalf("Couldn't get the PublishingStrategy: %v", err)
+	}
+	reactivation := persisted.Status.ControlPlaneMachineSetReactivation
+	if reactivation == nil {
+		t.Fatal("The reactivation wasn't persisted")
+	}
+	if reactivation.Phase != cloudingressv1alpha1.CPMSReactivationRemovingLoadBalancers {
+		t.Errorf("Unexpected phase %s", reactivation.Phase)
+	}
+	if !reflect.DeepEqual(reactivation.LoadBalancers, []string{"sut-ext", "sut-other"}) {
+		t.Errorf("Unexpected load balancers %v", reactivation.LoadBalancers)
+	}
+}
+
+func TestResumeCPMSReactivation(t *testing.T) {
+	tests := []struct {
+		Name              string
+		CPMS              *machinev1.ControlPlaneMachineSet
+		RemovalErr        error
+		ExpectedErr       bool
+		ExpectedRemoved   []string
+		ExpectedPhase     cloudingressv1alpha1.ControlPlaneMachineSetReactivationPhase
+		ExpectedAttempts  int32
+		ExpectedCPMSState machinev1.ControlPlaneMachineSetState
+	}{
+		{
+			Name:          "Should wait for the CPMS to be recreated",
+			ExpectedPhase: cloudingressv1alpha1.CPMSReactivationRemovingLoadBalancers,
+		},
+		{
+			Name:              "Should remove the load balancers and set the CPMS active",
+			CPMS:              inactiveCPMS(),
+			ExpectedRemoved:   []string{"sut-ext"},
+			ExpectedCPMSState: machinev1.ControlPlaneMachineSetStateActive,
+		},
+		{
+			Name:              "Should record the failed attempt and keep the CPMS inactive",
+			CPMS:              inactiveCPMS(),
+			RemovalErr:        errors.New("conflict"),
+			ExpectedErr:       true,
+			ExpectedRemoved:   []string{"sut-ext"},
+			ExpectedPhase:     cloudingressv1alpha1.CPMSReactivationRemovingLoadBalancers,
+			ExpectedAttempts:  1,
+			ExpectedCPMSState: machinev1.ControlPlaneMachineSetStateInactive,
+		},
+	}
+
+	for _, test := range tests {
+		objs := []runtime.Object{}
+		if test.CPMS != nil {
+			objs = append(objs, test.CPMS)
+		}
+		mocks := testutils.NewTestMock(t, objs)
+		instance := &cloudingressv1alpha1.PublishingStrategy{}
+		instance.Status.ControlPlaneMachineSetReactivation = &cloudingressv1alpha1.ControlPlaneMachineSetReactivation{
+			Phase:         cloudingressv1alpha1.CPMSReactivationRemovingLoadBalancers,
+			LoadBalancers: []string{"sut-ext"},
+			StartTime:     metav1.Now(),
+		}
+		var removed []string
+		removeLoadBalancer := func(_ context.Context, _ client.Client, lbName string, _ *machinev1.ControlPlaneMachineSet) error {
+			removed = append(removed, lbName)
+			return test.RemovalErr
+		}
+
+		err := ResumeCPMSReactivation(context.TODO(), mocks.FakeKubeClient, instance, removeLoadBalancer)
+		if (err != nil) != test.ExpectedErr {
+			t.Errorf("Test [%v] FAILED: unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(removed, test.ExpectedRemoved) {
+			t.Errorf("Test [%v] FAILED: expected %v to be removed, got %v", test.Name, test.ExpectedRemoved, removed)
+		}
+		reactivation := instance.Status.ControlPlaneMachineSetReactivation
+		switch {
+		case test.ExpectedPhase == "" && reactivation != nil:
+			t.Errorf("Test [%v] FAILED: expected the reactivation to be done, got %+v", test.Name, reactivation)
+		case test.ExpectedPhase != "" && reactivation == nil:
+			t.Errorf("Test [%v] FAILED: expected the reactivation to be %s, it's done", test.Name, test.ExpectedPhase)
+		case reactivation != nil:
+			if reactivation.Phase != test.ExpectedPhase || reactivation.Attempts != test.ExpectedAttempts {
+				t.Errorf("Test [%v] FAILED: expected %s after %d failures, got %+v", test.Name, test.ExpectedPhase, test.ExpectedAttempts, reactivation)
+			}
+			if test.ExpectedAttempts > 0 && reactivation.LastError == "" {
+				t.Errorf("Test [%v] FAILED: the error wasn't recorded", test.Name)
+			}
+		}
+		if test.CPMS != nil {
+			cpms, err := GetControlPlaneMachineSet(mocks.FakeKubeClient)
+			if err != nil {
+				t.Fatalf("Test [%v] FAILED: %v", test.Name, err)
+			}
+			if cpms.Spec.State != test.ExpectedCPMSState {
+				t.Errorf("Test [%v] FAILED: expected the CPMS to be %s, got %s", test.Name, test.ExpectedCPMSState, cpms.Spec.State)
+			}
+		}
+	}
+}
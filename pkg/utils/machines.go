@@ -34,6 +34,11 @@ func GetMasterMachines(kclient client.Client) (*machineapi.MachineList, error) {
 	return machineList, nil
 }
 
+// IsMasterMachine reports whether the object is a control plane machine.
+func IsMasterMachine(object client.Object) bool {
+	return object.GetNamespace() == machineApiNamespace && object.GetLabels()[masterMachineLabel] == "master"
+}
+
 // GetControlPlaneMachineSet returns an OSD cluster's CPMS.
 func GetControlPlaneMachineSet(kclient client.Client) (*machinev1.ControlPlaneMachineSet, error) {
 	cpms := &machinev1.ControlPlaneMachineSet{}
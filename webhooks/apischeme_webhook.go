@@ -0,0 +1,206 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+
+	configv1 "github.com/openshift/api/config/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+)
+
+//+kubebuilder:webhook:path=/mutate-cloudingress-managed-openshift-io-v1alpha1-apischeme,mutating=true,failurePolicy=fail,sideEffects=None,groups=cloudingress.managed.openshift.io,resources=apischemes,verbs=create,versions=v1alpha1,name=mapischeme.cloudingress.managed.openshift.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-cloudingress-managed-openshift-io-v1alpha1-apischeme,mutating=false,failurePolicy=fail,sideEffects=None,groups=cloudingress.managed.openshift.io,resources=apischemes,verbs=create;update,versions=v1alpha1,name=vapischeme.cloudingress.managed.openshift.io,admissionReviewVersions=v1
+
+// APISchemeDefaulter fills in the DNS name of the management API
+type APISchemeDefaulter struct{}
+
+// APISchemeValidator rejects APISchemes the operator wouldn't be able to reconcile
+type APISchemeValidator struct {
+	// Client is used to read the other APISchemes and the cluster platform
+	Client client.Client
+}
+
+// SetupAPISchemeWebhookWithManager registers the APIScheme webhooks with the manager's webhook server
+func SetupAPISchemeWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &v1alpha1.APIScheme{}).
+		WithDefaulter(&APISchemeDefaulter{}).
+		WithValidator(&APISchemeValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// Default names a new management API after the configured admin API name when it has no DNS name, and makes
+// it external when it has no scope. Existing APISchemes are left alone, clearing the DNS name of one is still
+// rejected.
+func (d *APISchemeDefaulter) Default(ctx context.Context, apiScheme *v1alpha1.APIScheme) error {
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation != admissionv1.Create {
+		return nil
+	}
+	ingress := &apiScheme.Spec.ManagementAPIServerIngress
+	if ingress.Enabled && ingress.DNSName == "" {
+		ingress.DNSName = operatorconfig.Get().AdminAPIName
+	}
+	if ingress.Enabled && ingress.Listening == "" {
+		ingress.Listening = v1alpha1.External
+	}
+	return nil
+}
+
+// ValidateCreate validates a new APIScheme
+func (v *APISchemeValidator) ValidateCreate(ctx context.Context, apiScheme *v1alpha1.APIScheme) (admission.Warnings, error) {
+	warnings, err := validateAPIScheme(apiScheme)
+	if err != nil {
+		return warnings, err
+	}
+	if err := v.validateLoadBalancerType(apiScheme); err != nil {
+		return warnings, err
+	}
+	return warnings, v.validateDNSName(ctx, apiScheme)
+}
+
+// ValidateUpdate validates a changed APIScheme. Updates that leave the spec alone, such as the finalizer
+// being added or removed, are always allowed so an existing APIScheme can still be reconciled or deleted.
+func (v *APISchemeValidator) ValidateUpdate(ctx context.Context, oldAPIScheme, newAPIScheme *v1alpha1.APIScheme) (admission.Warnings, error) {
+	if reflect.DeepEqual(oldAPIScheme.Spec, newAPIScheme.Spec) {
+		return nil, nil
+	}
+	warnings, err := validateAPIScheme(newAPIScheme)
+	if err != nil {
+		return warnings, err
+	}
+	if err := v.validateLoadBalancerType(newAPIScheme); err != nil {
+		return warnings, err
+	}
+	// An APIScheme which already lost its DNS name to another one can still be changed otherwise
+	oldIngress, newIngress := oldAPIScheme.Spec.ManagementAPIServerIngress, newAPIScheme.Spec.ManagementAPIServerIngress
+	if oldIngress.Enabled == newIngress.Enabled && oldIngress.DNSName == newIngress.DNSName {
+		return warnings, nil
+	}
+	return warnings, v.validateDNSName(ctx, newAPIScheme)
+}
+
+// ValidateDelete allows every deletion
+func (v *APISchemeValidator) ValidateDelete(ctx context.Context, apiScheme *v1alpha1.APIScheme) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateDNSName rejects an enabled APIScheme whose DNS name is reserved for the cluster API or used by another
+// APIScheme. The APIScheme controller checks it again, as two APISchemes can be admitted at the same time.
+func (v *APISchemeValidator) validateDNSName(ctx context.Context, apiScheme *v1alpha1.APIScheme) error {
+	ingress := apiScheme.Spec.ManagementAPIServerIngress
+	if !ingress.Enabled {
+		return nil
+	}
+	dnsNamePath := field.NewPath("spec", "managementAPIServerIngress", "dnsName")
+	if slices.Contains(baseutils.ReservedAdminAPINames, ingress.DNSName) {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("APIScheme").GroupKind(), apiScheme.Name,
+			field.ErrorList{field.Invalid(dnsNamePath, ingress.DNSName, "is the DNS name of the cluster API")})
+	}
+	other, err := baseutils.ConflictingAPIScheme(ctx, v.Client, apiScheme)
+	if err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("couldn't list the APISchemes: %w", err))
+	}
+	if other != nil {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("APIScheme").GroupKind(), apiScheme.Name,
+			field.ErrorList{field.Duplicate(dnsNamePath, fmt.Sprintf("%s, used by APIScheme %s/%s", ingress.DNSName, other.Namespace, other.Name))})
+	}
+	return nil
+}
+
+// validateLoadBalancerType rejects an NLB outside of AWS, the other platforms have a single load balancer type.
+// A dual-stack APIScheme on AWS needs an NLB, as a Classic ELB only has IPv4 addresses in a VPC.
+func (v *APISchemeValidator) validateLoadBalancerType(apiScheme *v1alpha1.APIScheme) error {
+	ingress := apiScheme.Spec.ManagementAPIServerIngress
+	dualStack := ingress.IPFamilyPolicy == corev1.IPFamilyPolicyPreferDualStack || ingress.IPFamilyPolicy == corev1.IPFamilyPolicyRequireDualStack
+	if ingress.Type != "NLB" && !dualStack {
+		return nil
+	}
+	cloudPlatform, err := baseutils.GetPlatformType(v.Client)
+	if err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("couldn't get the cluster platform: %w", err))
+	}
+	ingressPath := field.NewPath("spec", "managementAPIServerIngress")
+	if ingress.Type == "NLB" && *cloudPlatform != configv1.AWSPlatformType {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("APIScheme").GroupKind(), apiScheme.Name,
+			field.ErrorList{field.Invalid(ingressPath.Child("type"), ingress.Type, fmt.Sprintf("NLB is only supported on AWS, this cluster runs on %s", *cloudPlatform))})
+	}
+	if dualStack && ingress.Type != "NLB" && *cloudPlatform == configv1.AWSPlatformType {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("APIScheme").GroupKind(), apiScheme.Name,
+			field.ErrorList{field.Invalid(ingressPath.Child("ipFamilyPolicy"), ingress.IPFamilyPolicy, "dual-stack needs an NLB on AWS, set spec.managementAPIServerIngress.type to NLB")})
+	}
+	return nil
+}
+
+func validateAPIScheme(apiScheme *v1alpha1.APIScheme) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	ingressPath := field.NewPath("spec", "managementAPIServerIngress")
+	ingress := apiScheme.Spec.ManagementAPIServerIngress
+
+	if ingress.Enabled {
+		dnsNamePath := ingressPath.Child("dnsName")
+		if ingress.DNSName == "" {
+			allErrs = append(allErrs, field.Required(dnsNamePath, "the DNS name of the management API is required when it is enabled"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(ingress.DNSName) {
+				allErrs = append(allErrs, field.Invalid(dnsNamePath, ingress.DNSName, msg))
+			}
+		}
+		if len(ingress.AllowedCIDRBlocks) == 0 {
+			warnings = append(warnings, "spec.managementAPIServerIngress.allowedCIDRBlocks is empty, the management API won't be reachable")
+		}
+	}
+
+	// Empty is external, as for the APISchemes created before the scope existed
+	if ingress.Listening != "" {
+		allErrs = append(allErrs, validateListening(ingressPath.Child("listening"), ingress.Listening)...)
+	}
+
+	// Empty is SingleStack
+	switch ingress.IPFamilyPolicy {
+	case "", corev1.IPFamilyPolicySingleStack, corev1.IPFamilyPolicyPreferDualStack, corev1.IPFamilyPolicyRequireDualStack:
+	default:
+		allErrs = append(allErrs, field.NotSupported(ingressPath.Child("ipFamilyPolicy"), ingress.IPFamilyPolicy,
+			[]string{string(corev1.IPFamilyPolicySingleStack), string(corev1.IPFamilyPolicyPreferDualStack), string(corev1.IPFamilyPolicyRequireDualStack)}))
+	}
+
+	for i, cidr := range ingress.AllowedCIDRBlocks {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(ingressPath.Child("allowedCIDRBlocks").Index(i), cidr, "must be a CIDR block, eg 10.0.0.0/16 or 2001:db8::/32"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("APIScheme").GroupKind(), apiScheme.Name, allErrs)
+	}
+	return warnings, nil
+}
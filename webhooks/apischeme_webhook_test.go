@@ -0,0 +1,319 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/operatorconfig"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+)
+
+func TestValidateAPIScheme(t *testing.T) {
+	tests := []struct {
+		Name        string
+		DNSName     string
+		Enabled     bool
+		CIDRs       []string
+		Listening   v1alpha1.Listening
+		ExpectError bool
+		ExpectWarn  bool
+	}{
+		{
+			Name:    "Should allow a valid APIScheme",
+			DNSName: "rh-api",
+			Enabled: true,
+			CIDRs:   []string{"0.0.0.0/0", "10.0.0.0/16"},
+		},
+		{
+			Name:    "Should allow IPv6 CIDR blocks",
+			DNSName: "rh-api",
+			Enabled: true,
+			CIDRs:   []string{"0.0.0.0/0", "::/0", "2001:db8::/32"},
+		},
+		{
+			Name:        "Should reject a malformed CIDR block",
+			DNSName:     "rh-api",
+			Enabled:     true,
+			CIDRs:       []string{"10.0.0.0/16", "10.0.0.300/16"},
+			ExpectError: true,
+		},
+		{
+			Name:        "Should reject an IP address without a prefix length",
+			DNSName:     "rh-api",
+			Enabled:     true,
+			CIDRs:       []string{"10.0.0.1"},
+			ExpectError: true,
+		},
+		{
+			Name:        "Should reject an empty DNS name",
+			Enabled:     true,
+			CIDRs:       []string{"0.0.0.0/0"},
+			ExpectError: true,
+		},
+		{
+			Name:        "Should reject a DNS name which isn't a DNS label",
+			DNSName:     "rh-api.example.com",
+			Enabled:     true,
+			CIDRs:       []string{"0.0.0.0/0"},
+			ExpectError: true,
+		},
+		{
+			Name:      "Should allow an internal management API",
+			DNSName:   "rh-api",
+			Enabled:   true,
+			CIDRs:     []string{"10.0.0.0/16"},
+			Listening: v1alpha1.Internal,
+		},
+		{
+			Name:        "Should reject an unknown scope",
+			DNSName:     "rh-api",
+			Enabled:     true,
+			CIDRs:       []string{"10.0.0.0/16"},
+			Listening:   "private",
+			ExpectError: true,
+		},
+		{
+			Name:    "Should allow an empty DNS name when disabled",
+			Enabled: false,
+		},
+		{
+			Name:       "Should warn when no CIDR block is allowed",
+			DNSName:    "rh-api",
+			Enabled:    true,
+			ExpectWarn: true,
+		},
+	}
+
+	validator := &APISchemeValidator{Client: testutils.NewTestMock(t, []runtime.Object{}).FakeKubeClient}
+	for _, test := range tests {
+		apiScheme := testutils.CreateAPISchemeObject(test.DNSName, test.Enabled, test.CIDRs)
+		apiScheme.Spec.ManagementAPIServerIngress.Listening = test.Listening
+		warnings, err := validator.ValidateCreate(context.TODO(), apiScheme)
+
+		if test.ExpectError != (err != nil) {
+			t.Fatalf("Test [%v] FAILED. Expected error %t, got %v", test.Name, test.ExpectError, err)
+		}
+		if err != nil && !apierrors.IsInvalid(err) {
+			t.Fatalf("Test [%v] FAILED. Expected an Invalid error, got %v", test.Name, err)
+		}
+		if test.ExpectWarn != (len(warnings) > 0) {
+			t.Fatalf("Test [%v] FAILED. Expected warnings %t, got %v", test.Name, test.ExpectWarn, warnings)
+		}
+	}
+}
+
+func TestValidateAPISchemeUpdate(t *testing.T) {
+	validator := &APISchemeValidator{Client: testutils.NewTestMock(t, []runtime.Object{}).FakeKubeClient}
+	oldAPIScheme := testutils.CreateAPISchemeObject("rh-api", true, []string{"10.0.0.300/16"})
+
+	// An update which only touches the metadata of an invalid APIScheme is allowed
+	newAPIScheme := oldAPIScheme.DeepCopy()
+	newAPIScheme.Finalizers = []string{"cloudingress.managed.openshift.io/apischeme"}
+	if _, err := validator.ValidateUpdate(context.TODO(), oldAPIScheme, newAPIScheme); err != nil {
+		t.Fatalf("Expected a metadata only update to be allowed, got %v", err)
+	}
+
+	newAPIScheme.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks = append(newAPIScheme.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks, "10.0.0.0/8")
+	if _, err := validator.ValidateUpdate(context.TODO(), oldAPIScheme, newAPIScheme); err == nil {
+		t.Fatalf("Expected a spec update to be validated")
+	}
+}
+
+func TestValidateAPISchemeDNSName(t *testing.T) {
+	existing := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	existing.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	validator := &APISchemeValidator{Client: testutils.NewTestMock(t, []runtime.Object{existing}).FakeKubeClient}
+
+	tests := []struct {
+		Name        string
+		DNSName     string
+		Enabled     bool
+		ExpectError bool
+	}{
+		{
+			Name:    "Should allow another DNS name",
+			DNSName: "partner-api",
+			Enabled: true,
+		},
+		{
+			Name:        "Should reject the DNS name of another APIScheme",
+			DNSName:     "rh-api",
+			Enabled:     true,
+			ExpectError: true,
+		},
+		{
+			Name:        "Should reject the DNS name of the cluster API",
+			DNSName:     "api",
+			Enabled:     true,
+			ExpectError: true,
+		},
+		{
+			Name:    "Should allow the DNS name of another APIScheme when disabled",
+			DNSName: "rh-api",
+		},
+	}
+
+	for _, test := range tests {
+		apiScheme := testutils.CreateAPISchemeObject(test.DNSName, test.Enabled, []string{"0.0.0.0/0"})
+		apiScheme.Name = "partner"
+		_, err := validator.ValidateCreate(context.TODO(), apiScheme)
+		if test.ExpectError != (err != nil) {
+			t.Fatalf("Test [%v] FAILED. Expected error %t, got %v", test.Name, test.ExpectError, err)
+		}
+		if err != nil && !apierrors.IsInvalid(err) {
+			t.Fatalf("Test [%v] FAILED. Expected an Invalid error, got %v", test.Name, err)
+		}
+	}
+
+	// The APIScheme holding the DNS name can still be updated
+	updated := existing.DeepCopy()
+	updated.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks = []string{"10.0.0.0/16"}
+	if _, err := validator.ValidateUpdate(context.TODO(), existing, updated); err != nil {
+		t.Fatalf("Expected the update to be allowed, got %v", err)
+	}
+}
+
+func TestAPISchemeDefault(t *testing.T) {
+	defer operatorconfig.Reset()
+	c := operatorconfig.Default()
+	c.AdminAPIName = "admin-api"
+	operatorconfig.Set(c)
+
+	defaulter := &APISchemeDefaulter{}
+	tests := []struct {
+		Name      string
+		DNSName   string
+		Operation admissionv1.Operation
+		Expected  string
+	}{
+		{
+			Name:      "Should use the configured admin API name for a new APIScheme",
+			Operation: admissionv1.Create,
+			Expected:  "admin-api",
+		},
+		{
+			Name:      "Should keep the DNS name of a new APIScheme",
+			DNSName:   "rh-api",
+			Operation: admissionv1.Create,
+			Expected:  "rh-api",
+		},
+		{
+			Name:      "Should not rename an existing APIScheme",
+			Operation: admissionv1.Update,
+		},
+	}
+
+	for _, test := range tests {
+		apiScheme := testutils.CreateAPISchemeObject(test.DNSName, true, []string{"0.0.0.0/0"})
+		ctx := admission.NewContextWithRequest(context.TODO(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: test.Operation}})
+		if err := defaulter.Default(ctx, apiScheme); err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		if apiScheme.Spec.ManagementAPIServerIngress.DNSName != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected DNS name %q, got %q", test.Name, test.Expected, apiScheme.Spec.ManagementAPIServerIngress.DNSName)
+		}
+		if expected := test.Operation == admissionv1.Create; (apiScheme.Spec.ManagementAPIServerIngress.Listening == v1alpha1.External) != expected {
+			t.Fatalf("Test [%v] FAILED. Unexpected scope %q", test.Name, apiScheme.Spec.ManagementAPIServerIngress.Listening)
+		}
+	}
+}
+
+func TestAPISchemeAdmission(t *testing.T) {
+	requireEnvtest(t)
+
+	valid := &v1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "default"},
+		Spec: v1alpha1.APISchemeSpec{
+			ManagementAPIServerIngress: v1alpha1.ManagementAPIServerIngress{
+				Enabled:           true,
+				DNSName:           "rh-api",
+				AllowedCIDRBlocks: []string{"0.0.0.0/0"},
+			},
+		},
+	}
+	if err := k8sClient.Create(context.TODO(), valid); err != nil {
+		t.Fatalf("Expected the APIScheme to be admitted, got %v", err)
+	}
+	t.Cleanup(func() { _ = k8sClient.Delete(context.TODO(), valid) })
+
+	invalid := valid.DeepCopy()
+	invalid.ResourceVersion = ""
+	invalid.Name = "rh-api-invalid"
+	invalid.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks = []string{"0.0.0.0"}
+	if err := k8sClient.Create(context.TODO(), invalid); !apierrors.IsInvalid(err) {
+		t.Fatalf("Expected the APIScheme to be rejected as invalid, got %v", err)
+	}
+
+	valid.Spec.ManagementAPIServerIngress.DNSName = ""
+	if err := k8sClient.Update(context.TODO(), valid); !apierrors.IsInvalid(err) {
+		t.Fatalf("Expected the update to be rejected as invalid, got %v", err)
+	}
+}
+
+func TestValidateAPISchemeLoadBalancerType(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Infra          runtime.Object
+		Type           v1alpha1.Type
+		IPFamilyPolicy corev1.IPFamilyPolicy
+		ExpectError    bool
+	}{
+		{
+			Name:  "Should allow an NLB on AWS",
+			Infra: testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName),
+			Type:  "NLB",
+		},
+		{
+			Name:        "Should reject an NLB on GCP",
+			Infra:       testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName),
+			Type:        "NLB",
+			ExpectError: true,
+		},
+		{
+			Name:  "Should allow a Classic ELB on GCP",
+			Infra: testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName),
+			Type:  "Classic",
+		},
+		{
+			Name:           "Should allow a dual-stack NLB on AWS",
+			Infra:          testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName),
+			Type:           "NLB",
+			IPFamilyPolicy: corev1.IPFamilyPolicyRequireDualStack,
+		},
+		{
+			Name:           "Should reject a dual-stack Classic ELB on AWS",
+			Infra:          testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName),
+			Type:           "Classic",
+			IPFamilyPolicy: corev1.IPFamilyPolicyPreferDualStack,
+			ExpectError:    true,
+		},
+		{
+			Name:           "Should allow dual-stack on GCP",
+			Infra:          testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName),
+			IPFamilyPolicy: corev1.IPFamilyPolicyPreferDualStack,
+		},
+	}
+
+	for _, test := range tests {
+		validator := &APISchemeValidator{Client: testutils.NewTestMock(t, []runtime.Object{test.Infra}).FakeKubeClient}
+		apiScheme := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+		apiScheme.Spec.ManagementAPIServerIngress.Type = test.Type
+		apiScheme.Spec.ManagementAPIServerIngress.IPFamilyPolicy = test.IPFamilyPolicy
+		_, err := validator.ValidateCreate(context.TODO(), apiScheme)
+		if test.ExpectError != (err != nil) {
+			t.Fatalf("Test [%v] FAILED. Expected error %t, got %v", test.Name, test.ExpectError, err)
+		}
+		if err != nil && !apierrors.IsInvalid(err) {
+			t.Fatalf("Test [%v] FAILED. Expected an Invalid error, got %v", test.Name, err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	configv1 "github.com/openshift/api/config/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+)
+
+//+kubebuilder:webhook:path=/mutate-cloudingress-managed-openshift-io-v1alpha1-publishingstrategy,mutating=true,failurePolicy=fail,sideEffects=None,groups=cloudingress.managed.openshift.io,resources=publishingstrategies,verbs=create;update,versions=v1alpha1,name=mpublishingstrategy.cloudingress.managed.openshift.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-cloudingress-managed-openshift-io-v1alpha1-publishingstrategy,mutating=false,failurePolicy=fail,sideEffects=None,groups=cloudingress.managed.openshift.io,resources=publishingstrategies,verbs=create;update,versions=v1alpha1,name=vpublishingstrategy.cloudingress.managed.openshift.io,admissionReviewVersions=v1
+
+// PublishingStrategyDefaulter fills in the listening scope and, on AWS, the load balancer type
+type PublishingStrategyDefaulter struct {
+	// Client is used to read the cluster platform
+	Client client.Client
+}
+
+// PublishingStrategyValidator rejects PublishingStrategies the operator wouldn't be able to reconcile
+type PublishingStrategyValidator struct {
+	// Client is used to read the cluster platform
+	Client client.Client
+}
+
+// SetupPublishingStrategyWebhookWithManager registers the PublishingStrategy webhooks with the manager's webhook server
+func SetupPublishingStrategyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &v1alpha1.PublishingStrategy{}).
+		WithDefaulter(&PublishingStrategyDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&PublishingStrategyValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// Default sets external listening when it is left empty and, on AWS, the Classic load balancer type
+// to match the default IngressController behavior
+func (d *PublishingStrategyDefaulter) Default(ctx context.Context, publishingStrategy *v1alpha1.PublishingStrategy) error {
+	if publishingStrategy.Spec.DefaultAPIServerIngress.Listening == "" {
+		publishingStrategy.Spec.DefaultAPIServerIngress.Listening = v1alpha1.External
+	}
+
+	cloudPlatform, err := baseutils.GetPlatformType(d.Client)
+	if err != nil {
+		return fmt.Errorf("couldn't get the cluster platform: %w", err)
+	}
+	for i := range publishingStrategy.Spec.ApplicationIngress {
+		ingressDefinition := &publishingStrategy.Spec.ApplicationIngress[i]
+		if ingressDefinition.Listening == "" {
+			ingressDefinition.Listening = v1alpha1.External
+		}
+		if *cloudPlatform == configv1.AWSPlatformType && ingressDefinition.Type == "" {
+			ingressDefinition.Type = "Classic"
+		}
+	}
+	return nil
+}
+
+// ValidateCreate validates a new PublishingStrategy
+func (v *PublishingStrategyValidator) ValidateCreate(ctx context.Context, publishingStrategy *v1alpha1.PublishingStrategy) (admission.Warnings, error) {
+	return nil, v.validatePublishingStrategy(publishingStrategy)
+}
+
+// ValidateUpdate validates a changed PublishingStrategy. Updates that leave the spec alone, such as the finalizer
+// being added or removed, are always allowed so an existing PublishingStrategy can still be reconciled or deleted.
+func (v *PublishingStrategyValidator) ValidateUpdate(ctx context.Context, oldPublishingStrategy, newPublishingStrategy *v1alpha1.PublishingStrategy) (admission.Warnings, error) {
+	if reflect.DeepEqual(oldPublishingStrategy.Spec, newPublishingStrategy.Spec) {
+		return nil, nil
+	}
+	return nil, v.validatePublishingStrategy(newPublishingStrategy)
+}
+
+// ValidateDelete allows every deletion
+func (v *PublishingStrategyValidator) ValidateDelete(ctx context.Context, publishingStrategy *v1alpha1.PublishingStrategy) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *PublishingStrategyValidator) validatePublishingStrategy(publishingStrategy *v1alpha1.PublishingStrategy) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	allErrs = append(allErrs, validateListening(specPath.Child("defaultAPIServerIngress", "listening"), publishingStrategy.Spec.DefaultAPIServerIngress.Listening)...)
+
+	cloudPlatform, err := baseutils.GetPlatformType(v.Client)
+	if err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("couldn't get the cluster platform: %w", err))
+	}
+
+	var defaultPath *field.Path
+	dnsNames := make(map[string]bool, len(publishingStrategy.Spec.ApplicationIngress))
+	for i, ingressDefinition := range publishingStrategy.Spec.ApplicationIngress {
+		ingressPath := specPath.Child("applicationIngress").Index(i)
+
+		allErrs = append(allErrs, validateListening(ingressPath.Child("listening"), ingressDefinition.Listening)...)
+
+		if ingressDefinition.DNSName == "" {
+			allErrs = append(allErrs, field.Required(ingressPath.Child("dnsName"), ""))
+		} else if dnsNames[ingressDefinition.DNSName] {
+			allErrs = append(allErrs, field.Duplicate(ingressPath.Child("dnsName"), ingressDefinition.DNSName))
+		}
+		dnsNames[ingressDefinition.DNSName] = true
+
+		if ingressDefinition.Default {
+			if defaultPath != nil {
+				allErrs = append(allErrs, field.Invalid(ingressPath.Child("default"), true, fmt.Sprintf("only one ApplicationIngress can be the default, %s is already set", defaultPath)))
+			} else {
+				defaultPath = ingressPath.Child("default")
+			}
+		}
+
+		if ingressDefinition.Type == "NLB" && *cloudPlatform != configv1.AWSPlatformType {
+			allErrs = append(allErrs, field.Invalid(ingressPath.Child("type"), ingressDefinition.Type, fmt.Sprintf("NLB is only supported on AWS, this cluster runs on %s", *cloudPlatform)))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("PublishingStrategy").GroupKind(), publishingStrategy.Name, allErrs)
+	}
+	return nil
+}
+
+func validateListening(fldPath *field.Path, listening v1alpha1.Listening) field.ErrorList {
+	if listening != v1alpha1.Internal && listening != v1alpha1.External {
+		return field.ErrorList{field.NotSupported(fldPath, listening, []string{string(v1alpha1.Internal), string(v1alpha1.External)})}
+	}
+	return nil
+}
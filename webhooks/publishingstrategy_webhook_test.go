@@ -0,0 +1,189 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+)
+
+func newPublishingStrategy(name string, applicationIngress ...v1alpha1.ApplicationIngress) *v1alpha1.PublishingStrategy {
+	return &v1alpha1.PublishingStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.PublishingStrategySpec{
+			DefaultAPIServerIngress: v1alpha1.DefaultAPIServerIngress{Listening: v1alpha1.External},
+			ApplicationIngress:      applicationIngress,
+		},
+	}
+}
+
+func TestPublishingStrategyDefault(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Platform     configv1.PlatformType
+		Type         v1alpha1.Type
+		ExpectedType v1alpha1.Type
+	}{
+		{
+			Name:         "Should default to Classic on AWS",
+			Platform:     configv1.AWSPlatformType,
+			ExpectedType: "Classic",
+		},
+		{
+			Name:         "Should keep NLB on AWS",
+			Platform:     configv1.AWSPlatformType,
+			Type:         "NLB",
+			ExpectedType: "NLB",
+		},
+		{
+			Name:     "Should leave the type empty on GCP",
+			Platform: configv1.GCPPlatformType,
+		},
+	}
+
+	defaulter := &PublishingStrategyDefaulter{Client: infraClient}
+	for _, test := range tests {
+		setPlatform(t, test.Platform)
+		publishingStrategy := newPublishingStrategy("publishingstrategy", v1alpha1.ApplicationIngress{Default: true, DNSName: "apps", Type: test.Type})
+		publishingStrategy.Spec.DefaultAPIServerIngress.Listening = ""
+
+		if err := defaulter.Default(context.TODO(), publishingStrategy); err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		if publishingStrategy.Spec.DefaultAPIServerIngress.Listening != v1alpha1.External {
+			t.Fatalf("Test [%v] FAILED. Expected the default API to be external, got %q", test.Name, publishingStrategy.Spec.DefaultAPIServerIngress.Listening)
+		}
+		ingressDefinition := publishingStrategy.Spec.ApplicationIngress[0]
+		if ingressDefinition.Listening != v1alpha1.External {
+			t.Fatalf("Test [%v] FAILED. Expected the ApplicationIngress to be external, got %q", test.Name, ingressDefinition.Listening)
+		}
+		if ingressDefinition.Type != test.ExpectedType {
+			t.Fatalf("Test [%v] FAILED. Expected type %q, got %q", test.Name, test.ExpectedType, ingressDefinition.Type)
+		}
+	}
+}
+
+func TestValidatePublishingStrategy(t *testing.T) {
+	tests := []struct {
+		Name               string
+		Platform           configv1.PlatformType
+		Listening          v1alpha1.Listening
+		ApplicationIngress []v1alpha1.ApplicationIngress
+		ExpectedError      string
+	}{
+		{
+			Name:      "Should allow a valid PublishingStrategy",
+			Platform:  configv1.AWSPlatformType,
+			Listening: v1alpha1.Internal,
+			ApplicationIngress: []v1alpha1.ApplicationIngress{
+				{Listening: v1alpha1.External, Default: true, DNSName: "apps", Type: "NLB"},
+				{Listening: v1alpha1.Internal, DNSName: "apps2"},
+			},
+		},
+		{
+			Name:          "Should reject an unknown default API listening",
+			Platform:      configv1.AWSPlatformType,
+			Listening:     "public",
+			ExpectedError: "spec.defaultAPIServerIngress.listening",
+		},
+		{
+			Name:      "Should reject an unknown ApplicationIngress listening",
+			Platform:  configv1.AWSPlatformType,
+			Listening: v1alpha1.External,
+			ApplicationIngress: []v1alpha1.ApplicationIngress{
+				{Listening: "private", Default: true, DNSName: "apps"},
+			},
+			ExpectedError: "spec.applicationIngress[0].listening",
+		},
+		{
+			Name:      "Should reject two default ApplicationIngresses",
+			Platform:  configv1.AWSPlatformType,
+			Listening: v1alpha1.External,
+			ApplicationIngress: []v1alpha1.ApplicationIngress{
+				{Listening: v1alpha1.External, Default: true, DNSName: "apps"},
+				{Listening: v1alpha1.External, Default: true, DNSName: "apps2"},
+			},
+			ExpectedError: "spec.applicationIngress[1].default",
+		},
+		{
+			Name:      "Should reject an empty DNS name",
+			Platform:  configv1.AWSPlatformType,
+			Listening: v1alpha1.External,
+			ApplicationIngress: []v1alpha1.ApplicationIngress{
+				{Listening: v1alpha1.External, Default: true},
+			},
+			ExpectedError: "spec.applicationIngress[0].dnsName",
+		},
+		{
+			Name:      "Should reject a duplicated DNS name",
+			Platform:  configv1.AWSPlatformType,
+			Listening: v1alpha1.External,
+			ApplicationIngress: []v1alpha1.ApplicationIngress{
+				{Listening: v1alpha1.External, Default: true, DNSName: "apps"},
+				{Listening: v1alpha1.Internal, DNSName: "apps"},
+			},
+			ExpectedError: "spec.applicationIngress[1].dnsName",
+		},
+		{
+			Name:      "Should reject NLB on GCP",
+			Platform:  configv1.GCPPlatformType,
+			Listening: v1alpha1.External,
+			ApplicationIngress: []v1alpha1.ApplicationIngress{
+				{Listening: v1alpha1.External, Default: true, DNSName: "apps", Type: "NLB"},
+			},
+			ExpectedError: "spec.applicationIngress[0].type",
+		},
+	}
+
+	validator := &PublishingStrategyValidator{Client: infraClient}
+	for _, test := range tests {
+		setPlatform(t, test.Platform)
+		publishingStrategy := newPublishingStrategy("publishingstrategy", test.ApplicationIngress...)
+		publishingStrategy.Spec.DefaultAPIServerIngress.Listening = test.Listening
+
+		_, err := validator.ValidateCreate(context.TODO(), publishingStrategy)
+		if test.ExpectedError == "" {
+			if err != nil {
+				t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+			}
+			continue
+		}
+		if !apierrors.IsInvalid(err) || !strings.Contains(err.Error(), test.ExpectedError) {
+			t.Fatalf("Test [%v] FAILED. Expected an Invalid error for %s, got %v", test.Name, test.ExpectedError, err)
+		}
+	}
+}
+
+func TestPublishingStrategyAdmission(t *testing.T) {
+	requireEnvtest(t)
+
+	publishingStrategy := newPublishingStrategy("publishingstrategy", v1alpha1.ApplicationIngress{Default: true, DNSName: "apps"})
+	publishingStrategy.Spec.DefaultAPIServerIngress.Listening = ""
+	if err := k8sClient.Create(context.TODO(), publishingStrategy); err != nil {
+		t.Fatalf("Expected the PublishingStrategy to be admitted, got %v", err)
+	}
+	t.Cleanup(func() { _ = k8sClient.Delete(context.TODO(), publishingStrategy) })
+
+	if publishingStrategy.Spec.DefaultAPIServerIngress.Listening != v1alpha1.External {
+		t.Fatalf("Expected the default API to default to external, got %q", publishingStrategy.Spec.DefaultAPIServerIngress.Listening)
+	}
+	if ingressDefinition := publishingStrategy.Spec.ApplicationIngress[0]; ingressDefinition.Listening != v1alpha1.External || ingressDefinition.Type != "Classic" {
+		t.Fatalf("Expected the ApplicationIngress to default to an external Classic LB, got %+v", ingressDefinition)
+	}
+
+	publishingStrategy.Spec.ApplicationIngress = append(publishingStrategy.Spec.ApplicationIngress, v1alpha1.ApplicationIngress{Default: true, DNSName: "apps2"})
+	if err := k8sClient.Update(context.TODO(), publishingStrategy); !apierrors.IsInvalid(err) {
+		t.Fatalf("Expected a second default ApplicationIngress to be rejected, got %v", err)
+	}
+
+	setPlatform(t, configv1.GCPPlatformType)
+	nlb := newPublishingStrategy("publishingstrategy-nlb", v1alpha1.ApplicationIngress{Default: true, DNSName: "apps", Type: "NLB"})
+	if err := k8sClient.Create(context.TODO(), nlb); !apierrors.IsInvalid(err) {
+		t.Fatalf("Expected NLB to be rejected on GCP, got %v", err)
+	}
+}
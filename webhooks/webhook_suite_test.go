@@ -0,0 +1,175 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/openshift/cloud-ingress-operator/api/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+)
+
+var (
+	testScheme = runtime.NewScheme()
+	// k8sClient talks to the envtest API server, it is nil when envtest isn't available
+	k8sClient client.Client
+	// infraClient holds the Infrastructure object the webhooks read the platform from,
+	// envtest doesn't serve the OpenShift config API
+	infraClient client.Client
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(testScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(testScheme))
+	utilruntime.Must(configv1.Install(testScheme))
+}
+
+// TestMain starts an API server with the operator CRDs and webhooks when the envtest binaries are
+// available, that is when KUBEBUILDER_ASSETS is set as done by `make test`
+func TestMain(m *testing.M) {
+	infraClient = fake.NewClientBuilder().WithScheme(testScheme).
+		WithObjects(testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)).
+		Build()
+
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		os.Exit(m.Run())
+	}
+
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "deploy", "crds")},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "deploy")},
+		},
+	}
+	if _, err := testEnv.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't start envtest: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	code, err := runWithWebhookServer(ctx, testEnv, m)
+	cancel()
+	if stopErr := testEnv.Stop(); stopErr != nil {
+		fmt.Fprintf(os.Stderr, "couldn't stop envtest: %v\n", stopErr)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't start the webhook server: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+func runWithWebhookServer(ctx context.Context, testEnv *envtest.Environment, m *testing.M) (int, error) {
+	webhookOptions := &testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(testEnv.Config, ctrl.Options{
+		Scheme: testScheme,
+		Metrics: metricsserver.Options{
+			BindAddress: "0",
+		},
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    webhookOptions.LocalServingHost,
+			Port:    webhookOptions.LocalServingPort,
+			CertDir: webhookOptions.LocalServingCertDir,
+		}),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := SetupAPISchemeWebhookWithManager(mgr); err != nil {
+		return 0, err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr, &v1alpha1.PublishingStrategy{}).
+		WithDefaulter(&PublishingStrategyDefaulter{Client: infraClient}).
+		WithValidator(&PublishingStrategyValidator{Client: infraClient}).
+		Complete(); err != nil {
+		return 0, err
+	}
+
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "manager stopped: %v\n", err)
+		}
+	}()
+
+	// Wait for the webhook server to serve before sending requests through the API server
+	addr := net.JoinHostPort(webhookOptions.LocalServingHost, fmt.Sprint(webhookOptions.LocalServingPort))
+	dialer := &net.Dialer{Timeout: time.Second}
+	for deadline := time.Now().Add(30 * time.Second); ; time.Sleep(100 * time.Millisecond) {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{InsecureSkipVerify: true}) // #nosec G402 -- test server
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			return 0, err
+		}
+	}
+
+	k8sClient, err = client.New(testEnv.Config, client.Options{Scheme: testScheme})
+	if err != nil {
+		return 0, err
+	}
+	return m.Run(), nil
+}
+
+// requireEnvtest skips the test when the envtest API server isn't running
+func requireEnvtest(t *testing.T) {
+	t.Helper()
+	if k8sClient == nil {
+		t.Skip("KUBEBUILDER_ASSETS isn't set, skipping the envtest based test")
+	}
+}
+
+// setPlatform changes the platform of the cluster as seen by the webhooks until the end of the test
+func setPlatform(t *testing.T, platform configv1.PlatformType) {
+	t.Helper()
+	updatePlatform(t, platform)
+	t.Cleanup(func() { updatePlatform(t, configv1.AWSPlatformType) })
+}
+
+func updatePlatform(t *testing.T, platform configv1.PlatformType) {
+	t.Helper()
+	infra := &configv1.Infrastructure{}
+	if err := infraClient.Get(context.TODO(), client.ObjectKey{Name: "cluster"}, infra); err != nil {
+		t.Fatalf("Couldn't get the Infrastructure object: %v", err)
+	}
+	infra.Status.Platform = platform
+	infra.Status.PlatformStatus.Type = platform
+	if err := infraClient.Update(context.TODO(), infra); err != nil {
+		t.Fatalf("Couldn't update the Infrastructure object: %v", err)
+	}
+}